@@ -2,7 +2,15 @@ package controllers
 
 import (
 	"Komentory/api/app/models"
+	"Komentory/api/pkg/helpers"
+	"Komentory/api/pkg/policy"
+	"Komentory/api/pkg/repository"
+	"Komentory/api/platform/auth"
 	"Komentory/api/platform/database"
+	"Komentory/api/platform/mail"
+	"Komentory/api/platform/unfurl"
+	"fmt"
+	"log"
 	"time"
 
 	"github.com/Komentory/utilities"
@@ -18,6 +26,12 @@ func GetAnswerByID(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, 400, "answer id", err.Error())
 	}
 
+	// Define format of the descriptions from URL query.
+	render, err := helpers.GetDescriptionFormat(c)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "format", err.Error())
+	}
+
 	// Create database connection.
 	db, err := database.OpenDBConnection()
 	if err != nil {
@@ -30,6 +44,11 @@ func GetAnswerByID(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, status, "answer", err.Error())
 	}
 
+	// Render Markdown descriptions to HTML, if it was requested.
+	if render != nil {
+		answer.Attrs.ConvertDescriptions(render)
+	}
+
 	// Return status 200 OK.
 	return c.JSON(fiber.Map{
 		"status": fiber.StatusOK,
@@ -45,6 +64,12 @@ func GetAnswersByTaskID(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, 400, "task id", err.Error())
 	}
 
+	// Define format of the descriptions from URL query.
+	render, err := helpers.GetDescriptionFormat(c)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "format", err.Error())
+	}
+
 	// Create database connection.
 	db, err := database.OpenDBConnection()
 	if err != nil {
@@ -57,6 +82,13 @@ func GetAnswersByTaskID(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, status, "answers", err.Error())
 	}
 
+	// Render Markdown descriptions to HTML, if it was requested.
+	if render != nil {
+		for i := range answers {
+			answers[i].Attrs.ConvertDescriptions(render)
+		}
+	}
+
 	// Return status 200 OK.
 	return c.JSON(fiber.Map{
 		"status":  fiber.StatusOK,
@@ -73,18 +105,80 @@ func GetAnswersByProjectID(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, 400, "project id", err.Error())
 	}
 
+	// Define format of the descriptions from URL query.
+	render, err := helpers.GetDescriptionFormat(c)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "format", err.Error())
+	}
+
 	// Create database connection.
 	db, err := database.OpenDBConnection()
 	if err != nil {
 		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
 	}
 
+	// Check, if request has a valid JWT of the project owner.
+	if claims, errToken := auth.TokenValidateExpireTime(c); errToken == nil {
+		// Checking, if project with given ID is exists.
+		foundedProject, status, err := db.FindProjectByID(projectID)
+		if err != nil {
+			return utilities.CheckForError(c, err, status, "project", err.Error())
+		}
+
+		// Get role of the current user in the project.
+		role, err := db.GetProjectRole(foundedProject.ID, claims.UserID)
+		if err != nil {
+			return utilities.CheckForError(c, err, 400, "project role", err.Error())
+		}
+
+		// Only collaborators of the project can see and filter triage of the answers.
+		if policy.Can(claims, policy.AnswerViewTriage, policy.Resource{ProjectRole: role}) {
+			// Define triage filters from URL query.
+			label, state := c.Query("label"), c.Query("state")
+
+			// Check, if triage filters are allowed.
+			if label != "" && !utilities.SearchStringInArray(label, repository.AnswerLabels) {
+				return utilities.ThrowJSONError(c, 400, "answers filter", fmt.Sprintf("wrong label %s", label))
+			}
+			if state != "" && !utilities.SearchStringInArray(state, repository.AnswerStates) {
+				return utilities.ThrowJSONError(c, 400, "answers filter", fmt.Sprintf("wrong state %s", state))
+			}
+
+			// Get all answers with triage.
+			answers, status, err := db.GetAnswersByProjectIDWithTriage(projectID, label, state)
+			if err != nil {
+				return utilities.CheckForError(c, err, status, "answers", err.Error())
+			}
+
+			// Render Markdown descriptions to HTML, if it was requested.
+			if render != nil {
+				for i := range answers {
+					answers[i].Attrs.ConvertDescriptions(render)
+				}
+			}
+
+			// Return status 200 OK.
+			return c.JSON(fiber.Map{
+				"status":  fiber.StatusOK,
+				"count":   len(answers),
+				"answers": answers,
+			})
+		}
+	}
+
 	// Get all answers.
 	answers, status, err := db.GetAnswersByProjectID(projectID)
 	if err != nil {
 		return utilities.CheckForError(c, err, status, "answers", err.Error())
 	}
 
+	// Render Markdown descriptions to HTML, if it was requested.
+	if render != nil {
+		for i := range answers {
+			answers[i].Attrs.ConvertDescriptions(render)
+		}
+	}
+
 	// Return status 200 OK.
 	return c.JSON(fiber.Map{
 		"status":  fiber.StatusOK,
@@ -101,7 +195,7 @@ func CreateNewAnswer(c *fiber.Ctx) error {
 	}
 
 	// Validate JWT token.
-	claims, err := utilities.TokenValidateExpireTimeAndCredentials(c, credentials)
+	claims, err := auth.TokenValidateExpireTimeAndCredentials(c, credentials)
 	if err != nil {
 		return utilities.CheckForError(c, err, 401, "jwt", err.Error())
 	}
@@ -132,6 +226,11 @@ func CreateNewAnswer(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, status, "task", err.Error())
 	}
 
+	// Checking, if task belongs to the project.
+	if foundedTask.ProjectID != foundedProject.ID {
+		return utilities.ThrowJSONError(c, 400, "task", "task does not belong to the project")
+	}
+
 	// Set user ID from JWT data of current user.
 	userID := claims.UserID
 
@@ -147,6 +246,12 @@ func CreateNewAnswer(c *fiber.Ctx) error {
 	answer.AnswerStatus = jsonBody.AnswerStatus // 0 == draft, 1 == active, 2 == unpublished
 	answer.AnswerAttrs = jsonBody.AnswerAttrs
 
+	// Sanitize Markdown descriptions.
+	answer.AnswerAttrs.ConvertDescriptions(helpers.SanitizeMarkdown)
+
+	// Link metadata can be set only by the link unfurl worker.
+	answer.AnswerAttrs.LinksMetadata = nil
+
 	// Create a new validator for a Answer model.
 	validate := utilities.NewValidator()
 
@@ -155,11 +260,62 @@ func CreateNewAnswer(c *fiber.Ctx) error {
 		return utilities.CheckForValidationError(c, err, 400, "answer")
 	}
 
+	// Validate links of the answer.
+	if err := helpers.ValidateLinks(answer.AnswerAttrs.Links); err != nil {
+		return utilities.CheckForError(c, err, 400, "answer links", err.Error())
+	}
+
+	// Validate answer responses against the task questions.
+	if err := helpers.ValidateAnswerResponses(foundedTask.Attrs.Questions, answer.AnswerAttrs.Responses); err != nil {
+		return utilities.CheckForError(c, err, 400, "answer responses", err.Error())
+	}
+
 	// Create a new answer with given attrs.
 	if err := db.CreateNewAnswer(answer); err != nil {
 		return utilities.CheckForError(c, err, 400, "answer", err.Error())
 	}
 
+	// Fetch metadata of the answer links in background.
+	if len(answer.AnswerAttrs.Links) > 0 {
+		unfurl.Enqueue(repository.LinkObjectAnswer, answer.ID, answer.AnswerAttrs.Links)
+	}
+
+	// Notify author of the task about a new active answer in the inbox.
+	if answer.AnswerStatus == 1 && foundedTask.UserID != userID {
+		createNotification(db, &models.Notification{
+			ID:         uuid.New(),
+			CreatedAt:  time.Now(),
+			UserID:     foundedTask.UserID,
+			ActorID:    &userID,
+			Type:       repository.NotificationTypeNewAnswer,
+			ObjectType: repository.NotificationObjectAnswer,
+			ObjectID:   answer.ID,
+			ProjectID:  foundedProject.ID,
+			Payload: models.NotificationPayload{
+				"project_title": foundedProject.Attrs.Title,
+				"task_id":       foundedTask.ID,
+				"task_name":     foundedTask.Attrs.Name,
+			},
+		})
+	}
+
+	// Notify project owner about a new active answer by email.
+	if answer.AnswerStatus == 1 && foundedProject.Author.ID != userID {
+		if err := mail.Enqueue(
+			foundedProject.Author.ID, repository.EmailStreamTransactional, repository.EmailTemplateNewAnswer,
+			map[string]interface{}{
+				"project_id":         foundedProject.ID,
+				"project_title":      foundedProject.Attrs.Title,
+				"task_id":            foundedTask.ID,
+				"task_name":          foundedTask.Attrs.Name,
+				"answer_id":          answer.ID,
+				"answer_description": helpers.TruncateText(answer.AnswerAttrs.Description, 500),
+			},
+		); err != nil {
+			log.Printf("mail: %v", err)
+		}
+	}
+
 	// Return status 201 created.
 	return c.SendStatus(fiber.StatusCreated)
 }
@@ -172,7 +328,7 @@ func UpdateAnswer(c *fiber.Ctx) error {
 	}
 
 	// Validate JWT token.
-	claims, err := utilities.TokenValidateExpireTimeAndCredentials(c, credentials)
+	claims, err := auth.TokenValidateExpireTimeAndCredentials(c, credentials)
 	if err != nil {
 		return utilities.CheckForError(c, err, 401, "jwt", err.Error())
 	}
@@ -185,6 +341,12 @@ func UpdateAnswer(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, 400, "answer json body", err.Error())
 	}
 
+	// Sanitize Markdown descriptions.
+	jsonBody.AnswerAttrs.ConvertDescriptions(helpers.SanitizeMarkdown)
+
+	// Link metadata can be set only by the link unfurl worker.
+	jsonBody.AnswerAttrs.LinksMetadata = nil
+
 	// Create a new validator.
 	validate := utilities.NewValidator()
 
@@ -193,6 +355,11 @@ func UpdateAnswer(c *fiber.Ctx) error {
 		return utilities.CheckForValidationError(c, err, 400, "answer")
 	}
 
+	// Validate links of the answer.
+	if err := helpers.ValidateLinks(jsonBody.AnswerAttrs.Links); err != nil {
+		return utilities.CheckForError(c, err, 400, "answer links", err.Error())
+	}
+
 	// Create database connection.
 	db, err := database.OpenDBConnection()
 	if err != nil {
@@ -205,16 +372,29 @@ func UpdateAnswer(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, status, "answer", err.Error())
 	}
 
-	// Set user ID from JWT data of current user.
-	userID := claims.UserID
-
 	// Only the creator can update his answer.
-	if foundedAnswer.UserID == userID {
+	if policy.Can(claims, policy.AnswerUpdate, policy.Resource{AuthorID: foundedAnswer.UserID}) {
+		// Checking, if task of the answer is exists.
+		foundedTask, status, err := db.GetTaskByID(foundedAnswer.TaskID)
+		if err != nil {
+			return utilities.CheckForError(c, err, status, "task", err.Error())
+		}
+
+		// Validate answer responses against the task questions.
+		if err := helpers.ValidateAnswerResponses(foundedTask.Attrs.Questions, jsonBody.AnswerAttrs.Responses); err != nil {
+			return utilities.CheckForError(c, err, 400, "answer responses", err.Error())
+		}
+
 		// Update answer by given ID.
 		if err := db.UpdateAnswer(foundedAnswer.ID, jsonBody); err != nil {
 			return utilities.CheckForError(c, err, 400, "answer", err.Error())
 		}
 
+		// Fetch metadata of the answer links in background.
+		if len(jsonBody.AnswerAttrs.Links) > 0 {
+			unfurl.Enqueue(repository.LinkObjectAnswer, foundedAnswer.ID, jsonBody.AnswerAttrs.Links)
+		}
+
 		// Return status 204 no content.
 		return c.SendStatus(fiber.StatusNoContent)
 	} else {
@@ -224,6 +404,102 @@ func UpdateAnswer(c *fiber.Ctx) error {
 	}
 }
 
+// UpdateAnswerTriage func for update label, workflow state and note of the answer by given ID.
+func UpdateAnswerTriage(c *fiber.Ctx) error {
+	// Set needed credentials.
+	credentials := []string{
+		utilities.GenerateCredential("projects", "update", true),
+	}
+
+	// Validate JWT token.
+	claims, err := auth.TokenValidateExpireTimeAndCredentials(c, credentials)
+	if err != nil {
+		return utilities.CheckForError(c, err, 401, "jwt", err.Error())
+	}
+
+	// Create a new struct for JSON body.
+	jsonBody := &models.UpdateAnswerTriage{}
+
+	// Check, if received JSON data is valid.
+	if err := c.BodyParser(jsonBody); err != nil {
+		return utilities.CheckForError(c, err, 400, "answer triage json body", err.Error())
+	}
+
+	// Create a new validator.
+	validate := utilities.NewValidator()
+
+	// Validate answer triage fields.
+	if err := validate.Struct(jsonBody); err != nil {
+		return utilities.CheckForValidationError(c, err, 400, "answer triage")
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Checking, if answer with given ID is exists.
+	foundedAnswer, status, err := db.FindAnswerByID(jsonBody.ID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "answer", err.Error())
+	}
+
+	// Checking, if project of the answer is exists.
+	foundedProject, status, err := db.FindProjectByID(foundedAnswer.ProjectID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "project", err.Error())
+	}
+
+	// Set user ID from JWT data of current user.
+	userID := claims.UserID
+
+	// Get role of the current user in the project.
+	role, err := db.GetProjectRole(foundedProject.ID, userID)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "project role", err.Error())
+	}
+
+	// Only owners and editors of the project can triage answers.
+	if policy.Can(claims, policy.AnswerTriage, policy.Resource{ProjectRole: role}) {
+		// Update answer triage by given ID.
+		previousState, err := db.UpdateAnswerTriage(foundedProject.ID, jsonBody)
+		if err != nil {
+			return utilities.CheckForError(c, err, 400, "answer triage", err.Error())
+		}
+
+		// Notify author of the answer about changed workflow state in the inbox.
+		if previousState != jsonBody.State && foundedAnswer.UserID != userID {
+			createNotification(db, &models.Notification{
+				ID:         uuid.New(),
+				CreatedAt:  time.Now(),
+				UserID:     foundedAnswer.UserID,
+				ActorID:    &userID,
+				Type:       repository.NotificationTypeAnswerStatusChanged,
+				ObjectType: repository.NotificationObjectAnswer,
+				ObjectID:   foundedAnswer.ID,
+				ProjectID:  foundedProject.ID,
+				Payload: models.NotificationPayload{
+					"project_title":  foundedProject.ProjectAttrs.Title,
+					"previous_state": previousState,
+					"state":          jsonBody.State,
+				},
+			})
+		}
+
+		// Notify author of the answer by email, if answer was accepted (acknowledged) right now.
+		if jsonBody.State == repository.AnswerStateAcknowledged && previousState != jsonBody.State && foundedAnswer.UserID != userID {
+			notifyAnswerAccepted(&foundedProject, &foundedAnswer)
+		}
+
+		// Return status 204 no content.
+		return c.SendStatus(fiber.StatusNoContent)
+	} else {
+		// Return status 403 and permission denied error message.
+		return utilities.ThrowJSONError(c, 403, "answer", "you have no permissions")
+	}
+}
+
 // DeleteAnswer func for delete answer by given ID.
 func DeleteAnswer(c *fiber.Ctx) error {
 	// Set needed credentials.
@@ -232,7 +508,7 @@ func DeleteAnswer(c *fiber.Ctx) error {
 	}
 
 	// Validate JWT token.
-	claims, err := utilities.TokenValidateExpireTimeAndCredentials(c, credentials)
+	claims, err := auth.TokenValidateExpireTimeAndCredentials(c, credentials)
 	if err != nil {
 		return utilities.CheckForError(c, err, 401, "jwt", err.Error())
 	}
@@ -265,11 +541,8 @@ func DeleteAnswer(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, status, "answer", err.Error())
 	}
 
-	// Set user ID from JWT data of current user.
-	userID := claims.UserID
-
 	// Only the creator can delete his answer.
-	if foundedAnswer.UserID == userID {
+	if policy.Can(claims, policy.AnswerDelete, policy.Resource{AuthorID: foundedAnswer.UserID}) {
 		// Delete answer by given ID.
 		if err := db.DeleteAnswer(foundedAnswer.ID); err != nil {
 			return utilities.CheckForError(c, err, 400, "answer", err.Error())
@@ -282,3 +555,35 @@ func DeleteAnswer(c *fiber.Ctx) error {
 		return utilities.ThrowJSONError(c, 403, "answer", "you have no permissions")
 	}
 }
+
+// notifyAnswerAccepted (private) func for sending email to the author of the accepted (acknowledged) answer.
+// Errors are only logged, because triage of the answer is already saved.
+func notifyAnswerAccepted(project *models.Project, answer *models.Answer) {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		log.Printf("mail: %v", err)
+		return
+	}
+
+	// Get task of the answer.
+	foundedTask, _, err := db.GetTaskByID(answer.TaskID)
+	if err != nil {
+		log.Printf("mail: %v", err)
+		return
+	}
+
+	// Add email to the queue.
+	if err := mail.Enqueue(
+		answer.UserID, repository.EmailStreamTransactional, repository.EmailTemplateAnswerAccepted,
+		map[string]interface{}{
+			"project_id":    project.ID,
+			"project_title": project.ProjectAttrs.Title,
+			"task_id":       foundedTask.ID,
+			"task_name":     foundedTask.Attrs.Name,
+			"answer_id":     answer.ID,
+		},
+	); err != nil {
+		log.Printf("mail: %v", err)
+	}
+}
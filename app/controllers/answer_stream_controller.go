@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"Komentory/api/platform/database"
+	"Komentory/api/platform/stream"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Komentory/utilities"
+	"github.com/fasthttp/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+)
+
+// answerStreamHeartbeat (private) interval of the heartbeat messages to keep stream connections alive.
+const answerStreamHeartbeat = 25 * time.Second
+
+// answerStreamUpgrader (private) upgrader of the answer stream requests to WebSocket.
+// Requests from all origins are allowed, because stream is public and read-only.
+var answerStreamUpgrader = websocket.FastHTTPUpgrader{
+	CheckOrigin: func(ctx *fasthttp.RequestCtx) bool { return true },
+}
+
+// StreamAnswersByProjectID func for stream answer events (created, updated, deleted) of the project.
+// Events are sent by Server-Sent Events or by WebSocket, if it was requested and enabled.
+func StreamAnswersByProjectID(c *fiber.Ctx) error {
+	// Catch project ID from URL.
+	projectID, err := uuid.Parse(c.Params("project_id"))
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "project id", err.Error())
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Checking, if project with given ID is exists.
+	if _, status, err := db.FindProjectByID(projectID); err != nil {
+		return utilities.CheckForError(c, err, status, "project", err.Error())
+	}
+
+	return streamAnswerEvents(c, stream.Filter{ProjectID: projectID})
+}
+
+// StreamAnswersByTaskID func for stream answer events (created, updated, deleted) of the task.
+// Events are sent by Server-Sent Events or by WebSocket, if it was requested and enabled.
+func StreamAnswersByTaskID(c *fiber.Ctx) error {
+	// Catch task ID from URL.
+	taskID, err := uuid.Parse(c.Params("task_id"))
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "task id", err.Error())
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Checking, if task with given ID is exists.
+	if _, status, err := db.FindTaskByID(taskID); err != nil {
+		return utilities.CheckForError(c, err, status, "task", err.Error())
+	}
+
+	return streamAnswerEvents(c, stream.Filter{TaskID: taskID})
+}
+
+// streamAnswerEvents (private) func for streaming events with the given filter by WebSocket
+// (if STREAM_WEBSOCKET_ENABLED is "true" and client requested upgrade) or by Server-Sent Events.
+// Stream is resumed after the event from Last-Event-ID header (or ?last_event_id= query).
+func streamAnswerEvents(c *fiber.Ctx, filter stream.Filter) error {
+	// Define ID of the last received event.
+	lastEventID := c.Get("Last-Event-ID", c.Query("last_event_id"))
+
+	// Stream events by WebSocket, if it was requested.
+	if websocket.FastHTTPIsWebSocketUpgrade(c.Context()) {
+		if os.Getenv("STREAM_WEBSOCKET_ENABLED") != "true" {
+			return utilities.ThrowJSONError(c, 400, "answer stream", "websocket is disabled, use server-sent events")
+		}
+
+		// Upgrade connection (error response is sent by upgrader).
+		_ = answerStreamUpgrader.Upgrade(c.Context(), func(conn *websocket.Conn) {
+			writeAnswerEventsToWebSocket(conn, filter, lastEventID)
+		})
+
+		return nil
+	}
+
+	// Set headers of the Server-Sent Events.
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	// Stream events until client is disconnected.
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		writeAnswerEventsToSSE(w, filter, lastEventID)
+	})
+
+	return nil
+}
+
+// writeAnswerEventsToSSE (private) func for writing events to the Server-Sent Events stream.
+func writeAnswerEventsToSSE(w *bufio.Writer, filter stream.Filter, lastEventID string) {
+	// Subscribe to the events.
+	subscription, missed := stream.DefaultBroadcaster.Subscribe(filter, lastEventID)
+	defer subscription.Close()
+
+	// Set reconnection time and send missed events.
+	fmt.Fprint(w, "retry: 3000\n\n")
+	for _, event := range missed {
+		writeSSEEvent(w, event)
+	}
+	if err := w.Flush(); err != nil {
+		return
+	}
+
+	// Create heartbeat ticker.
+	ticker := time.NewTicker(answerStreamHeartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return
+			}
+			writeSSEEvent(w, event)
+		case <-ticker.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		}
+
+		// Stop streaming, if client is disconnected.
+		if err := w.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// writeSSEEvent (private) func for writing one event in the Server-Sent Events format.
+func writeSSEEvent(w *bufio.Writer, event *stream.Event) {
+	data, _ := json.Marshal(event)
+	fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+}
+
+// writeAnswerEventsToWebSocket (private) func for writing events as JSON messages to the WebSocket connection.
+// Messages from client are ignored.
+func writeAnswerEventsToWebSocket(conn *websocket.Conn, filter stream.Filter, lastEventID string) {
+	// Subscribe to the events.
+	subscription, missed := stream.DefaultBroadcaster.Subscribe(filter, lastEventID)
+	defer subscription.Close()
+
+	// Read messages in background to detect closed connection.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	// Send missed events.
+	for _, event := range missed {
+		if err := conn.WriteJSON(event); err != nil {
+			return
+		}
+	}
+
+	// Create heartbeat ticker.
+	ticker := time.NewTicker(answerStreamHeartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return
+			}
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(time.Second)); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
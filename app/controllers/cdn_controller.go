@@ -3,6 +3,8 @@ package controllers
 import (
 	"Komentory/api/app/models"
 	"Komentory/api/pkg/helpers"
+	"Komentory/api/pkg/policy"
+	"Komentory/api/platform/auth"
 	"Komentory/api/platform/cdn"
 	"context"
 	"fmt"
@@ -10,6 +12,7 @@ import (
 
 	"github.com/Komentory/utilities"
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"github.com/minio/minio-go/v7"
 )
 
@@ -17,7 +20,7 @@ import (
 // Allowed types: image, document.
 func PutFileToCDN(c *fiber.Ctx) error {
 	// Get claims from JWT.
-	claims, err := utilities.TokenValidateExpireTime(c)
+	claims, err := auth.TokenValidateExpireTime(c)
 	if err != nil {
 		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
 	}
@@ -63,14 +66,11 @@ func PutFileToCDN(c *fiber.Ctx) error {
 // RemoveFileFromCDN func for remove exists file from CDN.
 func RemoveFileFromCDN(c *fiber.Ctx) error {
 	// Get claims from JWT.
-	claims, err := utilities.TokenValidateExpireTime(c)
+	claims, err := auth.TokenValidateExpireTime(c)
 	if err != nil {
 		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
 	}
 
-	// Get user ID from JWT.
-	userID := claims.UserID.String()
-
 	// Create new FileFromCDN struct
 	fileToDelete := &models.FileFromCDN{}
 
@@ -85,8 +85,8 @@ func RemoveFileFromCDN(c *fiber.Ctx) error {
 		return utilities.CheckForErrorWithStatusCode(c, err, 400, "user id", err.Error())
 	}
 
-	// Check, if user ID from JWT is equal to getted owner user ID from upload folder on CDN.
-	if userID != fileOwnerUserID {
+	// Only owner of the upload folder on CDN can remove the file.
+	if !policy.Can(claims, policy.FileRemove, policy.Resource{AuthorID: uuid.MustParse(fileOwnerUserID)}) {
 		return utilities.ThrowJSONErrorWithStatusCode(c, 403, "file object", "you have no permissions")
 	}
 
@@ -0,0 +1,212 @@
+package controllers
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/policy"
+	"Komentory/api/pkg/repository"
+	"Komentory/api/platform/auth"
+	"Komentory/api/platform/database"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Komentory/utilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// CreateNewAnswerReport func for report an answer to moderators.
+func CreateNewAnswerReport(c *fiber.Ctx) error {
+	// Get claims from JWT.
+	claims, err := auth.TokenValidateExpireTime(c)
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
+	}
+
+	// Create a new struct for JSON body.
+	jsonBody := &models.CreateNewAnswerReport{}
+
+	// Check, if received JSON data is valid.
+	if err := c.BodyParser(jsonBody); err != nil {
+		return utilities.CheckForError(c, err, 400, "answer report", err.Error())
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Checking, if answer with given ID is exists.
+	foundedAnswer, status, err := db.FindAnswerByID(jsonBody.AnswerID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "answer", err.Error())
+	}
+
+	// Set user ID from JWT data of current user.
+	userID := claims.UserID
+
+	// Nobody can report his own answer.
+	if foundedAnswer.UserID == userID {
+		return utilities.ThrowJSONError(c, 400, "answer report", "you can't report your own answer")
+	}
+
+	// Create new AnswerReport struct.
+	report := &models.AnswerReport{}
+
+	// Set initialized default data for report:
+	report.ID = uuid.New()
+	report.CreatedAt = time.Now()
+	report.UserID = userID
+	report.AnswerID = foundedAnswer.ID
+
+	// Set report attributes from JSON body:
+	report.Reason = jsonBody.Reason // spam, abuse, off_topic, other
+	report.Comment = jsonBody.Comment
+
+	// Create a new validator for a AnswerReport model.
+	validate := utilities.NewValidator()
+
+	// Validate report fields.
+	if err := validate.Struct(report); err != nil {
+		return utilities.CheckForValidationError(c, err, 400, "answer report")
+	}
+
+	// Create a new report and hide answer, if reports threshold is reached.
+	if _, err := db.CreateNewAnswerReport(report, foundedAnswer.UserID, answerReportsHideThreshold()); err != nil {
+		return utilities.CheckForError(c, err, 400, "answer report", err.Error())
+	}
+
+	// Return status 201 created.
+	return c.SendStatus(fiber.StatusCreated)
+}
+
+// GetModerationQueueAnswers func for get all answers with unresolved reports.
+func GetModerationQueueAnswers(c *fiber.Ctx) error {
+	// Get claims from JWT.
+	claims, err := auth.TokenValidateExpireTime(c)
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
+	}
+
+	// Only staff can moderate answers.
+	if policy.StaffRole(claims) == "" {
+		return utilities.ThrowJSONError(c, 403, "moderation", "you have no permissions")
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Get all answers from moderation queue.
+	answers, status, err := db.GetModerationQueueAnswers()
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "moderation queue", err.Error())
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"status":  fiber.StatusOK,
+		"count":   len(answers),
+		"answers": answers,
+	})
+}
+
+// ModerateAnswer func for hide, restore, delete answer or warn its author by moderator.
+func ModerateAnswer(c *fiber.Ctx) error {
+	// Get claims from JWT.
+	claims, err := auth.TokenValidateExpireTime(c)
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
+	}
+
+	// Only staff can moderate answers.
+	if policy.StaffRole(claims) == "" {
+		return utilities.ThrowJSONError(c, 403, "moderation", "you have no permissions")
+	}
+
+	// Create a new struct for JSON body.
+	jsonBody := &models.ModerateAnswer{}
+
+	// Check, if received JSON data is valid.
+	if err := c.BodyParser(jsonBody); err != nil {
+		return utilities.CheckForError(c, err, 400, "moderation json body", err.Error())
+	}
+
+	// Create a new validator.
+	validate := utilities.NewValidator()
+
+	// Validate moderation fields.
+	if err := validate.Struct(jsonBody); err != nil {
+		return utilities.CheckForValidationError(c, err, 400, "moderation")
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Checking, if answer with given ID is exists.
+	foundedAnswer, status, err := db.FindAnswerByID(jsonBody.ID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "answer", err.Error())
+	}
+
+	// Set moderator ID from JWT data of current user.
+	moderatorID := claims.UserID
+
+	// Apply moderator action and write it to the audit log.
+	if err := db.ModerateAnswer(&foundedAnswer, &models.AuditLog{
+		ID:           uuid.New(),
+		CreatedAt:    time.Now(),
+		ActorID:      &moderatorID,
+		ObjectType:   repository.AuditObjectAnswer,
+		ObjectID:     foundedAnswer.ID,
+		ObjectUserID: foundedAnswer.UserID,
+		Action:       jsonBody.Action, // hide, restore, delete, warn
+		Reason:       jsonBody.Reason,
+	}); err != nil {
+		return utilities.CheckForError(c, err, 400, "moderation", err.Error())
+	}
+
+	// Notify author of the answer about hidden, restored or deleted answer in the inbox.
+	if jsonBody.Action != repository.ModerationActionWarn {
+		notifyAnswerStatusChanged(db, &foundedAnswer, moderatorID, models.NotificationPayload{
+			"action": jsonBody.Action,
+			"reason": jsonBody.Reason,
+		})
+	}
+
+	// Notify author of the answer about warning of the moderator in the inbox.
+	if jsonBody.Action == repository.ModerationActionWarn {
+		createNotification(db, &models.Notification{
+			ID:         uuid.New(),
+			CreatedAt:  time.Now(),
+			UserID:     foundedAnswer.UserID,
+			Type:       repository.NotificationTypeModerationWarning,
+			ObjectType: repository.NotificationObjectAnswer,
+			ObjectID:   foundedAnswer.ID,
+			ProjectID:  foundedAnswer.ProjectID,
+			Payload: models.NotificationPayload{
+				"reason": jsonBody.Reason,
+			},
+		})
+	}
+
+	// Return status 204 no content.
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// answerReportsHideThreshold (private) func for getting count of reports to automatically hide the answer
+// from ANSWER_REPORTS_HIDE_THRESHOLD environment variable (default: 3, values below 1 are not allowed).
+func answerReportsHideThreshold() int {
+	threshold, err := strconv.Atoi(os.Getenv("ANSWER_REPORTS_HIDE_THRESHOLD"))
+	if err != nil || threshold < 1 {
+		return 3
+	}
+
+	return threshold
+}
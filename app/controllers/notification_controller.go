@@ -0,0 +1,184 @@
+package controllers
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/helpers"
+	"Komentory/api/pkg/repository"
+	"Komentory/api/platform/auth"
+	"Komentory/api/platform/database"
+	"log"
+	"time"
+
+	"github.com/Komentory/utilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// GetNotifications func for get notifications of the current user (newest first).
+// Notifications are paginated by ?limit= (max 100) and ?offset=, only unread are returned with ?unread=true.
+func GetNotifications(c *fiber.Ctx) error {
+	// Get claims from JWT.
+	claims, err := auth.TokenValidateExpireTime(c)
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
+	}
+
+	// Get pagination of the notifications from URL query.
+	limit, offset, err := helpers.GetPagination(c, 20, 100)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "pagination", err.Error())
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Get notifications of the current user.
+	notifications, status, err := db.GetNotificationsByUserID(claims.UserID, c.Query("unread") == "true", limit, offset)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "notifications", err.Error())
+	}
+
+	// Get count of the unread notifications.
+	unreadCount, err := db.CountUnreadNotifications(claims.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "notifications", err.Error())
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"status":        fiber.StatusOK,
+		"count":         len(notifications),
+		"unread_count":  unreadCount,
+		"notifications": notifications,
+	})
+}
+
+// GetUnreadNotificationsCount func for get count of the unread notifications of the current user.
+func GetUnreadNotificationsCount(c *fiber.Ctx) error {
+	// Get claims from JWT.
+	claims, err := auth.TokenValidateExpireTime(c)
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Get count of the unread notifications.
+	unreadCount, err := db.CountUnreadNotifications(claims.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "notifications", err.Error())
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"status":       fiber.StatusOK,
+		"unread_count": unreadCount,
+	})
+}
+
+// MarkNotificationRead func for mark one notification of the current user as read.
+func MarkNotificationRead(c *fiber.Ctx) error {
+	// Get claims from JWT.
+	claims, err := auth.TokenValidateExpireTime(c)
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
+	}
+
+	// Create a new struct for JSON body.
+	jsonBody := &models.MarkNotificationRead{}
+
+	// Check, if received JSON data is valid.
+	if err := c.BodyParser(jsonBody); err != nil {
+		return utilities.CheckForError(c, err, 400, "notification json body", err.Error())
+	}
+
+	// Create a new validator.
+	validate := utilities.NewValidator()
+
+	// Validate notification fields.
+	if err := validate.Struct(jsonBody); err != nil {
+		return utilities.CheckForValidationError(c, err, 400, "notification")
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Mark notification of the current user as read.
+	if status, err := db.MarkNotificationRead(claims.UserID, jsonBody.ID); err != nil {
+		return utilities.CheckForError(c, err, status, "notification", err.Error())
+	}
+
+	// Return status 204 no content.
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// MarkAllNotificationsRead func for mark all unread notifications of the current user as read.
+func MarkAllNotificationsRead(c *fiber.Ctx) error {
+	// Get claims from JWT.
+	claims, err := auth.TokenValidateExpireTime(c)
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Mark all notifications of the current user as read.
+	markedCount, err := db.MarkAllNotificationsRead(claims.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "notifications", err.Error())
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"status":       fiber.StatusOK,
+		"marked_count": markedCount,
+	})
+}
+
+// notifyAnswerStatusChanged (private) func for notifying author of the answer about changed status
+// or visibility of the answer by staff (moderator is not shown as actor of the notification).
+// Author is not notified about their own actions.
+func notifyAnswerStatusChanged(db *database.Queries, answer *models.Answer, actorID uuid.UUID, payload models.NotificationPayload) {
+	// Skip own actions of the author.
+	if answer.UserID == actorID {
+		return
+	}
+
+	// Add title of the project to the payload (like for the changed workflow state).
+	if project, _, err := db.FindProjectByID(answer.ProjectID); err == nil {
+		payload["project_title"] = project.ProjectAttrs.Title
+	}
+
+	createNotification(db, &models.Notification{
+		ID:         uuid.New(),
+		CreatedAt:  time.Now(),
+		UserID:     answer.UserID,
+		Type:       repository.NotificationTypeAnswerStatusChanged,
+		ObjectType: repository.NotificationObjectAnswer,
+		ObjectID:   answer.ID,
+		ProjectID:  answer.ProjectID,
+		Payload:    payload,
+	})
+}
+
+// createNotification (private) func for creating a new notification (if its type is enabled by the user).
+// Errors are only logged, because the action, which caused notification, is already saved.
+func createNotification(db *database.Queries, n *models.Notification) {
+	if _, err := db.CreateNotification(n); err != nil {
+		log.Printf("notification: %s for %s: %v", n.Type, n.UserID, err)
+	}
+}
@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/policy"
+	"Komentory/api/platform/auth"
+	"Komentory/api/platform/database"
+	"fmt"
+	"time"
+
+	"github.com/Komentory/utilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// GetPersonalAccessTokens func for get all personal access tokens of the current user (newest first).
+func GetPersonalAccessTokens(c *fiber.Ctx) error {
+	// Get claims from JWT.
+	claims, err := auth.TokenValidateExpireTime(c)
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Get all personal access tokens of the current user.
+	tokens, status, err := db.GetPersonalAccessTokensByUserID(claims.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "personal access tokens", err.Error())
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"status": fiber.StatusOK,
+		"count":  len(tokens),
+		"tokens": tokens,
+	})
+}
+
+// CreateNewPersonalAccessToken func for create a new personal access token of the current user
+// with a subset of the user credentials. Token is returned only in this response.
+func CreateNewPersonalAccessToken(c *fiber.Ctx) error {
+	// Get claims from JWT.
+	claims, err := auth.TokenValidateExpireTime(c)
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
+	}
+
+	// Personal access token can't be used to create other tokens.
+	if auth.IsPersonalAccessToken(c) {
+		return utilities.ThrowJSONError(c, 403, "personal access token", "you have no permissions")
+	}
+
+	// Create a new struct for JSON body.
+	jsonBody := &models.CreateNewPersonalAccessToken{}
+
+	// Check, if received JSON data is valid.
+	if err := c.BodyParser(jsonBody); err != nil {
+		return utilities.CheckForError(c, err, 400, "personal access token", err.Error())
+	}
+
+	// Create a new validator.
+	validate := utilities.NewValidator()
+
+	// Validate personal access token fields.
+	if err := validate.Struct(jsonBody); err != nil {
+		return utilities.CheckForValidationError(c, err, 400, "personal access token")
+	}
+
+	// Token can have only credentials of the current user.
+	for _, credential := range jsonBody.Credentials {
+		if !utilities.SearchStringInArray(credential, claims.Credentials) {
+			return utilities.ThrowJSONError(c, 403, "personal access token", fmt.Sprintf("you have no credential %s", credential))
+		}
+	}
+
+	// Generate a new token.
+	token, hash, err := auth.GeneratePersonalAccessToken()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "personal access token", err.Error())
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Create new PersonalAccessToken struct.
+	now := time.Now()
+	pat := &models.PersonalAccessToken{
+		ID:          uuid.New(),
+		CreatedAt:   now,
+		UserID:      claims.UserID,
+		Name:        jsonBody.Name,
+		TokenHash:   hash,
+		TokenPrefix: token[:len(auth.PersonalAccessTokenPrefix)+6],
+		Credentials: jsonBody.Credentials,
+		ExpiresAt:   now.AddDate(0, 0, jsonBody.ExpiresInDays),
+	}
+
+	// Create a new personal access token.
+	if err := db.CreateNewPersonalAccessToken(pat); err != nil {
+		return utilities.CheckForError(c, err, 400, "personal access token", err.Error())
+	}
+
+	// Return status 201 created.
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":                fiber.StatusCreated,
+		"personal_access_token": pat,
+		"token":                 token,
+	})
+}
+
+// RevokePersonalAccessToken func for revoke one personal access token of the current user by given ID.
+func RevokePersonalAccessToken(c *fiber.Ctx) error {
+	// Get claims from JWT.
+	claims, err := auth.TokenValidateExpireTime(c)
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
+	}
+
+	// Create a new struct for JSON body.
+	jsonBody := &models.RevokePersonalAccessToken{}
+
+	// Check, if received JSON data is valid.
+	if err := c.BodyParser(jsonBody); err != nil {
+		return utilities.CheckForError(c, err, 400, "personal access token", err.Error())
+	}
+
+	// Create a new validator.
+	validate := utilities.NewValidator()
+
+	// Validate personal access token fields.
+	if err := validate.Struct(jsonBody); err != nil {
+		return utilities.CheckForValidationError(c, err, 400, "personal access token")
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Checking, if personal access token with given ID is exists.
+	foundedToken, status, err := db.FindPersonalAccessTokenByID(jsonBody.ID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "personal access token", err.Error())
+	}
+
+	// Only the owner can revoke his token.
+	if !policy.Can(claims, policy.PersonalAccessTokenRevoke, policy.Resource{AuthorID: foundedToken.UserID}) {
+		return utilities.ThrowJSONError(c, 403, "personal access token", "you have no permissions")
+	}
+
+	// Revoke personal access token.
+	if err := db.RevokePersonalAccessToken(foundedToken.ID); err != nil {
+		return utilities.CheckForError(c, err, 400, "personal access token", err.Error())
+	}
+
+	// Return status 204 no content.
+	return c.SendStatus(fiber.StatusNoContent)
+}
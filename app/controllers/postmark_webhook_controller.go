@@ -2,71 +2,245 @@ package controllers
 
 import (
 	"Komentory/api/app/models"
+	"Komentory/api/pkg/repository"
 	"Komentory/api/platform/database"
+	"Komentory/api/platform/webhooks"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
+	"time"
 
 	"github.com/Komentory/utilities"
 	"github.com/gofiber/fiber/v2"
 )
 
-// UpdateUserSubscriptions method to update user email subscriptions.
+// UpdateUserSubscriptions method to receive Postmark subscription change webhook.
+// Event is processed in background by ProcessPostmarkSubscriptionChange.
 func UpdateUserSubscriptions(c *fiber.Ctx) error {
-	// Define User-Agent Header.
-	postmarkUserAgentHeader := c.Get("User-Agent")
+	return receivePostmarkWebhook(c, repository.WebhookEventPostmarkSubscriptionChange)
+}
 
-	// Check, if User-Agent Header is set.
-	if postmarkUserAgentHeader != os.Getenv("POSTMARK_USER_AGENT_HEADER") {
-		return utilities.ThrowJSONErrorWithStatusCode(c, 400, "postmark webhook", "bad User-Agent header")
-	}
+// UpdateUserBounces method to receive Postmark Bounce and SpamComplaint webhooks.
+// Event is processed in background by ProcessPostmarkBounce.
+func UpdateUserBounces(c *fiber.Ctx) error {
+	return receivePostmarkWebhook(c, repository.WebhookEventPostmarkBounce)
+}
 
+// UpdateUserDeliveries method to receive Postmark Delivery and Open webhooks.
+// Event is processed in background by ProcessPostmarkDelivery.
+func UpdateUserDeliveries(c *fiber.Ctx) error {
+	return receivePostmarkWebhook(c, repository.WebhookEventPostmarkDelivery)
+}
+
+// ProcessPostmarkSubscriptionChange func for processing stored Postmark subscription change event.
+func ProcessPostmarkSubscriptionChange(rawBody []byte) error {
 	// Create a new user change email subscription struct.
 	subscriptionChange := &models.PostmarkSuppressSendingWebhook{}
 
-	// Checking received data from JSON body.
-	if err := c.BodyParser(subscriptionChange); err != nil {
-		return utilities.CheckForError(c, err, 400, "postmark webhook", err.Error())
+	// Parse and validate webhook fields.
+	if err := parsePostmarkWebhook(rawBody, subscriptionChange); err != nil {
+		return err
 	}
 
-	// Create a new validator.
-	validate := utilities.NewValidator()
+	// Check, if time of the change is set (to ignore out-of-order events).
+	if subscriptionChange.ChangedAt.IsZero() {
+		return errors.New("ChangedAt is not set")
+	}
 
-	// Validate webhook fields.
-	if err := validate.Struct(subscriptionChange); err != nil {
-		return utilities.CheckForValidationError(c, err, 400, "postmark webhook")
+	// Define email stream of the user settings by Postmark message stream.
+	stream := postmarkEmailStream(subscriptionChange.MessageStream)
+	if stream == "" {
+		// Skip changes of the unknown message streams.
+		return nil
 	}
 
 	// Create database connection.
 	db, err := database.OpenDBConnection()
 	if err != nil {
-		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+		return err
 	}
 
 	// Get user by given email.
-	foundedUser, status, err := db.GetUserByEmail(subscriptionChange.Recipient)
+	foundedUser, _, err := db.GetUserByEmail(subscriptionChange.Recipient)
 	if err != nil {
-		return utilities.CheckForErrorWithStatusCode(c, err, status, "user", err.Error())
+		return fmt.Errorf("user: %w", err)
 	}
 
-	// Create a new user settings struct.
-	userSettings := &models.UserSettings{}
-
 	// If Postmark pushed SuppressSending attribute with false,
 	// it means reactivation (user was subscribed again).
-	if !subscriptionChange.SuppressSending {
-		userSettings.EmailSubscriptions.Transactional = true
-		userSettings.EmailSubscriptions.Marketing = true
+	// Change only subscription of the given stream, if this event is newer than the last one.
+	applied, err := db.UpdateUserEmailSubscription(
+		foundedUser.ID, stream, !subscriptionChange.SuppressSending, &models.EmailSubscriptionChange{
+			ChangedAt:         subscriptionChange.ChangedAt,
+			SuppressionReason: subscriptionChange.SuppressionReason,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("user settings: %w", err)
+	}
+
+	// Clear hard bounce and spam complaint of the email address, if it was reactivated
+	// (out-of-order reactivation is skipped).
+	if applied && !subscriptionChange.SuppressSending {
+		if err := db.ResetEmailDeliverability(foundedUser.ID, subscriptionChange.ChangedAt); err != nil {
+			return fmt.Errorf("email deliverability: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ProcessPostmarkBounce func for processing stored Postmark Bounce or SpamComplaint event.
+func ProcessPostmarkBounce(rawBody []byte) error {
+	// Create a new bounce struct.
+	bounce := &models.PostmarkBounceWebhook{}
+
+	// Parse and validate webhook fields.
+	if err := parsePostmarkWebhook(rawBody, bounce); err != nil {
+		return err
+	}
+
+	// Define email event by the bounce type.
+	// Postmark deactivates email address (Inactive == true) after hard bounces.
+	var event string
+	switch {
+	case bounce.RecordType == "SpamComplaint" || bounce.Type == "SpamComplaint":
+		event = repository.EmailEventSpamComplaint
+	case bounce.Type == "HardBounce" || bounce.Inactive:
+		event = repository.EmailEventHardBounce
+	default:
+		event = repository.EmailEventSoftBounce
+	}
+
+	// Save event to the deliverability state of the user.
+	return updateUserDeliverability(bounce.Email, event, bounce.Type, bounce.BouncedAt)
+}
+
+// ProcessPostmarkDelivery func for processing stored Postmark Delivery or Open event.
+func ProcessPostmarkDelivery(rawBody []byte) error {
+	// Create a new delivery struct.
+	delivery := &models.PostmarkDeliveryWebhook{}
+
+	// Parse and validate webhook fields.
+	if err := parsePostmarkWebhook(rawBody, delivery); err != nil {
+		return err
+	}
+
+	// Define email event by the record type.
+	event, eventAt := repository.EmailEventDelivery, delivery.DeliveredAt
+	if delivery.RecordType == "Open" {
+		event, eventAt = repository.EmailEventOpen, delivery.ReceivedAt
+	}
+
+	// Save event to the deliverability state of the user.
+	return updateUserDeliverability(delivery.Recipient, event, "", eventAt)
+}
+
+// receivePostmarkWebhook (private) func for saving Postmark webhook to the event log and
+// notifying background processor. Duplicates (retries) are skipped.
+func receivePostmarkWebhook(c *fiber.Ctx, eventType string) error {
+	// Check, if User-Agent Header is set.
+	if !isPostmarkUserAgent(c) {
+		return utilities.ThrowJSONErrorWithStatusCode(c, 400, "postmark webhook", "bad User-Agent header")
+	}
+
+	// Check, if received body is a JSON.
+	if !json.Valid(c.Body()) {
+		return utilities.ThrowJSONErrorWithStatusCode(c, 400, "postmark webhook", "body is not a valid JSON")
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
 	}
 
-	// Validate user settings fields.
-	if err := validate.Struct(userSettings); err != nil {
-		return utilities.CheckForValidationError(c, err, 400, "user settings")
+	// Save a new event to the event log.
+	created, err := db.CreateWebhookEvent(webhooks.NewEvent(repository.WebhookVendorPostmark, eventType, c.Body()))
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "webhook event", err.Error())
 	}
 
-	// Change user settings with validated data.
-	if err := db.UpdateUserSettings(foundedUser.ID, userSettings); err != nil {
-		return utilities.CheckForErrorWithStatusCode(c, err, 400, "user", err.Error())
+	// Notify background processor about a new event.
+	if created {
+		webhooks.DefaultProcessor.Notify()
 	}
 
 	// Return status 204 no content.
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+// parsePostmarkWebhook (private) func for parsing raw body of the Postmark webhook to the given struct and validate it.
+func parsePostmarkWebhook(rawBody []byte, webhook interface{}) error {
+	// Checking received data from JSON body.
+	if err := json.Unmarshal(rawBody, webhook); err != nil {
+		return err
+	}
+
+	// Create a new validator.
+	validate := utilities.NewValidator()
+
+	// Validate webhook fields.
+	if err := validate.Struct(webhook); err != nil {
+		return fmt.Errorf("wrong webhook fields: %v", utilities.ValidatorErrors(err))
+	}
+
+	return nil
+}
+
+// updateUserDeliverability (private) func for saving email event to the deliverability state of the user by email.
+func updateUserDeliverability(email, event, bounceType string, eventAt time.Time) error {
+	// Set time of the event, if it was not sent.
+	if eventAt.IsZero() {
+		eventAt = time.Now()
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return err
+	}
+
+	// Get user by given email.
+	foundedUser, _, err := db.GetUserByEmail(email)
+	if err != nil {
+		return fmt.Errorf("user: %w", err)
+	}
+
+	// Change deliverability state of the user email address.
+	if err := db.UpdateEmailDeliverability(foundedUser.ID, event, bounceType, eventAt); err != nil {
+		return fmt.Errorf("email deliverability: %w", err)
+	}
+
+	return nil
+}
+
+// isPostmarkUserAgent (private) func for checking User-Agent header of the Postmark webhook.
+func isPostmarkUserAgent(c *fiber.Ctx) bool {
+	return c.Get("User-Agent") == os.Getenv("POSTMARK_USER_AGENT_HEADER")
+}
+
+// postmarkEmailStream (private) func for getting email stream of the user settings by Postmark message stream ID.
+// Stream IDs are set by POSTMARK_TRANSACTIONAL_STREAM (default: outbound) and POSTMARK_MARKETING_STREAM (default: broadcast).
+// Returns empty string for unknown message stream.
+func postmarkEmailStream(messageStream string) string {
+	// Define Postmark message streams.
+	transactionalStream, marketingStream := os.Getenv("POSTMARK_TRANSACTIONAL_STREAM"), os.Getenv("POSTMARK_MARKETING_STREAM")
+	if transactionalStream == "" {
+		transactionalStream = "outbound"
+	}
+	if marketingStream == "" {
+		marketingStream = "broadcast"
+	}
+
+	// Switch message streams.
+	switch messageStream {
+	case transactionalStream:
+		return repository.EmailStreamTransactional
+	case marketingStream:
+		return repository.EmailStreamMarketing
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,289 @@
+package controllers
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/policy"
+	"Komentory/api/pkg/repository"
+	"Komentory/api/platform/auth"
+	"Komentory/api/platform/database"
+	"time"
+
+	"github.com/Komentory/utilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// GetProjectCollaborators func for get all collaborators of the given project (only for collaborators).
+func GetProjectCollaborators(c *fiber.Ctx) error {
+	// Get claims from JWT.
+	claims, err := auth.TokenValidateExpireTime(c)
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
+	}
+
+	// Catch project ID from URL.
+	projectID, err := uuid.Parse(c.Params("project_id"))
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "project id", err.Error())
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Checking, if project with given ID is exists.
+	foundedProject, status, err := db.FindProjectByID(projectID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "project", err.Error())
+	}
+
+	// Get role of the current user in the project.
+	role, err := db.GetProjectRole(foundedProject.ID, claims.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "project role", err.Error())
+	}
+
+	// Only collaborators of the project can see other collaborators.
+	if !policy.Can(claims, policy.ProjectViewCollaborators, policy.Resource{ProjectRole: role}) {
+		return utilities.ThrowJSONError(c, 403, "project collaborators", "you have no permissions")
+	}
+
+	// Get all collaborators of the project.
+	collaborators, status, err := db.GetProjectCollaboratorsByProjectID(foundedProject.ID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "project collaborators", err.Error())
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"status":        fiber.StatusOK,
+		"owner_id":      foundedProject.UserID,
+		"count":         len(collaborators),
+		"collaborators": collaborators,
+	})
+}
+
+// CreateNewProjectCollaborator func for invite the user to the project with the given role.
+// Invited user is notified in the inbox.
+func CreateNewProjectCollaborator(c *fiber.Ctx) error {
+	// Set needed credentials.
+	credentials := []string{
+		utilities.GenerateCredential("projects", "update", true),
+	}
+
+	// Validate JWT token.
+	claims, err := auth.TokenValidateExpireTimeAndCredentials(c, credentials)
+	if err != nil {
+		return utilities.CheckForError(c, err, 401, "jwt", err.Error())
+	}
+
+	// Create a new struct for JSON body.
+	jsonBody := &models.CreateNewProjectCollaborator{}
+
+	// Check, if received JSON data is valid.
+	if err := c.BodyParser(jsonBody); err != nil {
+		return utilities.CheckForError(c, err, 400, "project collaborator", err.Error())
+	}
+
+	// Create a new validator.
+	validate := utilities.NewValidator()
+
+	// Validate project collaborator fields.
+	if err := validate.Struct(jsonBody); err != nil {
+		return utilities.CheckForValidationError(c, err, 400, "project collaborator")
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Checking, if project with given ID is exists.
+	foundedProject, status, err := db.FindProjectByID(jsonBody.ProjectID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "project", err.Error())
+	}
+
+	// Get role of the current user in the project.
+	role, err := db.GetProjectRole(foundedProject.ID, claims.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "project role", err.Error())
+	}
+
+	// Only owners of the project can invite collaborators.
+	if !policy.Can(claims, policy.ProjectManageCollaborators, policy.Resource{ProjectRole: role}) {
+		return utilities.ThrowJSONError(c, 403, "project collaborator", "you have no permissions")
+	}
+
+	// Checking, if invited user is exists.
+	foundedUser, status, err := db.GetUserByID(jsonBody.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "user", err.Error())
+	}
+
+	// Checking, if user is not a collaborator yet.
+	invitedRole, err := db.GetProjectRole(foundedProject.ID, foundedUser.ID)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "project role", err.Error())
+	}
+	if invitedRole != "" {
+		return utilities.ThrowJSONError(c, 409, "project collaborator", "user is already a collaborator")
+	}
+
+	// Create new ProjectCollaborator struct.
+	collaborator := &models.ProjectCollaborator{
+		ProjectID: foundedProject.ID,
+		UserID:    foundedUser.ID,
+		CreatedAt: time.Now(),
+		Role:      jsonBody.Role,
+		InvitedBy: &claims.UserID,
+	}
+
+	// Add a new collaborator to the project.
+	if err := db.CreateNewProjectCollaborator(collaborator); err != nil {
+		return utilities.CheckForError(c, err, 400, "project collaborator", err.Error())
+	}
+
+	// Notify invited user in the inbox.
+	createNotification(db, &models.Notification{
+		ID:         uuid.New(),
+		CreatedAt:  time.Now(),
+		UserID:     foundedUser.ID,
+		ActorID:    &claims.UserID,
+		Type:       repository.NotificationTypeProjectInvitation,
+		ObjectType: repository.NotificationObjectProject,
+		ObjectID:   foundedProject.ID,
+		ProjectID:  foundedProject.ID,
+		Payload: models.NotificationPayload{
+			"project_title": foundedProject.ProjectAttrs.Title,
+			"role":          collaborator.Role,
+		},
+	})
+
+	// Return status 201 created.
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":       fiber.StatusCreated,
+		"collaborator": collaborator,
+	})
+}
+
+// UpdateProjectCollaborator func for change role of the collaborator of the project.
+func UpdateProjectCollaborator(c *fiber.Ctx) error {
+	// Set needed credentials.
+	credentials := []string{
+		utilities.GenerateCredential("projects", "update", true),
+	}
+
+	// Validate JWT token.
+	claims, err := auth.TokenValidateExpireTimeAndCredentials(c, credentials)
+	if err != nil {
+		return utilities.CheckForError(c, err, 401, "jwt", err.Error())
+	}
+
+	// Create a new struct for JSON body.
+	jsonBody := &models.UpdateProjectCollaborator{}
+
+	// Check, if received JSON data is valid.
+	if err := c.BodyParser(jsonBody); err != nil {
+		return utilities.CheckForError(c, err, 400, "project collaborator", err.Error())
+	}
+
+	// Create a new validator.
+	validate := utilities.NewValidator()
+
+	// Validate project collaborator fields.
+	if err := validate.Struct(jsonBody); err != nil {
+		return utilities.CheckForValidationError(c, err, 400, "project collaborator")
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Checking, if collaborator of the project is exists.
+	foundedCollaborator, status, err := db.FindProjectCollaborator(jsonBody.ProjectID, jsonBody.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "project collaborator", err.Error())
+	}
+
+	// Get role of the current user in the project.
+	role, err := db.GetProjectRole(foundedCollaborator.ProjectID, claims.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "project role", err.Error())
+	}
+
+	// Only owners of the project can change roles of collaborators.
+	if !policy.Can(claims, policy.ProjectManageCollaborators, policy.Resource{ProjectRole: role}) {
+		return utilities.ThrowJSONError(c, 403, "project collaborator", "you have no permissions")
+	}
+
+	// Update role of the collaborator.
+	if err := db.UpdateProjectCollaborator(foundedCollaborator.ProjectID, foundedCollaborator.UserID, jsonBody.Role); err != nil {
+		return utilities.CheckForError(c, err, 400, "project collaborator", err.Error())
+	}
+
+	// Return status 204 no content.
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// DeleteProjectCollaborator func for remove collaborator from the project.
+// Owners can remove any collaborator, other collaborators can only leave the project.
+func DeleteProjectCollaborator(c *fiber.Ctx) error {
+	// Get claims from JWT.
+	claims, err := auth.TokenValidateExpireTime(c)
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
+	}
+
+	// Create a new struct for JSON body.
+	jsonBody := &models.DeleteProjectCollaborator{}
+
+	// Check, if received JSON data is valid.
+	if err := c.BodyParser(jsonBody); err != nil {
+		return utilities.CheckForError(c, err, 400, "project collaborator", err.Error())
+	}
+
+	// Create a new validator.
+	validate := utilities.NewValidator()
+
+	// Validate project collaborator fields.
+	if err := validate.Struct(jsonBody); err != nil {
+		return utilities.CheckForValidationError(c, err, 400, "project collaborator")
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Checking, if collaborator of the project is exists.
+	foundedCollaborator, status, err := db.FindProjectCollaborator(jsonBody.ProjectID, jsonBody.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "project collaborator", err.Error())
+	}
+
+	// Get role of the current user in the project.
+	role, err := db.GetProjectRole(foundedCollaborator.ProjectID, claims.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "project role", err.Error())
+	}
+
+	// Only owners of the project or the collaborator himself can remove collaborator.
+	if !policy.Can(claims, policy.ProjectRemoveCollaborator, policy.Resource{AuthorID: foundedCollaborator.UserID, ProjectRole: role}) {
+		return utilities.ThrowJSONError(c, 403, "project collaborator", "you have no permissions")
+	}
+
+	// Remove collaborator from the project.
+	if err := db.DeleteProjectCollaborator(foundedCollaborator.ProjectID, foundedCollaborator.UserID); err != nil {
+		return utilities.CheckForError(c, err, 400, "project collaborator", err.Error())
+	}
+
+	// Return status 204 no content.
+	return c.SendStatus(fiber.StatusNoContent)
+}
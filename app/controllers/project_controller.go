@@ -2,7 +2,12 @@ package controllers
 
 import (
 	"Komentory/api/app/models"
+	"Komentory/api/pkg/helpers"
+	"Komentory/api/pkg/policy"
+	"Komentory/api/pkg/repository"
+	"Komentory/api/platform/auth"
 	"Komentory/api/platform/database"
+	"Komentory/api/platform/unfurl"
 
 	"github.com/Komentory/utilities"
 	"github.com/gofiber/fiber/v2"
@@ -11,6 +16,12 @@ import (
 
 // GetProjects func for get all exists projects.
 func GetProjects(c *fiber.Ctx) error {
+	// Define format of the descriptions from URL query.
+	render, err := helpers.GetDescriptionFormat(c)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "format", err.Error())
+	}
+
 	// Create database connection.
 	db, err := database.OpenDBConnection()
 	if err != nil {
@@ -23,6 +34,13 @@ func GetProjects(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, status, "projects", err.Error())
 	}
 
+	// Render Markdown descriptions to HTML, if it was requested.
+	if render != nil {
+		for i := range projects {
+			projects[i].Attrs.ConvertDescriptions(render)
+		}
+	}
+
 	// Return status 200 OK.
 	return c.JSON(fiber.Map{
 		"status":   fiber.StatusOK,
@@ -39,6 +57,12 @@ func GetProjectByID(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, 400, "project id", err.Error())
 	}
 
+	// Define format of the descriptions from URL query.
+	render, err := helpers.GetDescriptionFormat(c)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "format", err.Error())
+	}
+
 	// Create database connection.
 	db, err := database.OpenDBConnection()
 	if err != nil {
@@ -51,6 +75,11 @@ func GetProjectByID(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, status, "project", err.Error())
 	}
 
+	// Render Markdown descriptions to HTML, if it was requested.
+	if render != nil {
+		project.ConvertDescriptions(render)
+	}
+
 	// Return status 200 OK.
 	return c.JSON(fiber.Map{
 		"status":  fiber.StatusOK,
@@ -66,6 +95,12 @@ func GetProjectsByUserID(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, 400, "user id", err.Error())
 	}
 
+	// Define format of the descriptions from URL query.
+	render, err := helpers.GetDescriptionFormat(c)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "format", err.Error())
+	}
+
 	// Create database connection.
 	db, err := database.OpenDBConnection()
 	if err != nil {
@@ -78,6 +113,13 @@ func GetProjectsByUserID(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, status, "projects", err.Error())
 	}
 
+	// Render Markdown descriptions to HTML, if it was requested.
+	if render != nil {
+		for i := range projects {
+			projects[i].Attrs.ConvertDescriptions(render)
+		}
+	}
+
 	// Return status 200 OK.
 	return c.JSON(fiber.Map{
 		"status":   fiber.StatusOK,
@@ -94,7 +136,7 @@ func CreateNewProject(c *fiber.Ctx) error {
 	}
 
 	// Validate JWT token.
-	claims, err := utilities.TokenValidateExpireTimeAndCredentials(c, credentials)
+	claims, err := auth.TokenValidateExpireTimeAndCredentials(c, credentials)
 	if err != nil {
 		return utilities.CheckForError(c, err, 401, "jwt", err.Error())
 	}
@@ -118,6 +160,12 @@ func CreateNewProject(c *fiber.Ctx) error {
 	project.ProjectStatus = jsonBody.ProjectStatus // 0 == draft, 1 == active, 2 == unpublished
 	project.ProjectAttrs = jsonBody.ProjectAttrs
 
+	// Sanitize Markdown descriptions.
+	project.ProjectAttrs.ConvertDescriptions(helpers.SanitizeMarkdown)
+
+	// Link metadata can be set only by the link unfurl worker.
+	project.ProjectAttrs.LinksMetadata = nil
+
 	// Create a new validator for a Project model.
 	validate := utilities.NewValidator()
 
@@ -126,6 +174,13 @@ func CreateNewProject(c *fiber.Ctx) error {
 		return utilities.CheckForValidationError(c, err, 400, "project")
 	}
 
+	// Validate website URL of the project.
+	if project.ProjectAttrs.WebsiteURL != "" {
+		if err := helpers.ValidateURL(project.ProjectAttrs.WebsiteURL); err != nil {
+			return utilities.CheckForError(c, err, 400, "project website url", err.Error())
+		}
+	}
+
 	// Create database connection.
 	db, err := database.OpenDBConnection()
 	if err != nil {
@@ -137,6 +192,11 @@ func CreateNewProject(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, 400, "project", err.Error())
 	}
 
+	// Fetch metadata of the website URL in background.
+	if project.ProjectAttrs.WebsiteURL != "" {
+		unfurl.Enqueue(repository.LinkObjectProject, project.ID, []string{project.ProjectAttrs.WebsiteURL})
+	}
+
 	// Return status 201 created.
 	return c.SendStatus(fiber.StatusCreated)
 }
@@ -149,7 +209,7 @@ func UpdateProject(c *fiber.Ctx) error {
 	}
 
 	// Validate JWT token.
-	claims, err := utilities.TokenValidateExpireTimeAndCredentials(c, credentials)
+	claims, err := auth.TokenValidateExpireTimeAndCredentials(c, credentials)
 	if err != nil {
 		return utilities.CheckForError(c, err, 401, "jwt", err.Error())
 	}
@@ -162,6 +222,12 @@ func UpdateProject(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, 400, "project", err.Error())
 	}
 
+	// Sanitize Markdown descriptions.
+	jsonBody.ProjectAttrs.ConvertDescriptions(helpers.SanitizeMarkdown)
+
+	// Link metadata can be set only by the link unfurl worker.
+	jsonBody.ProjectAttrs.LinksMetadata = nil
+
 	// Create a new validator.
 	validate := utilities.NewValidator()
 
@@ -170,6 +236,13 @@ func UpdateProject(c *fiber.Ctx) error {
 		return utilities.CheckForValidationError(c, err, 400, "project")
 	}
 
+	// Validate website URL of the project.
+	if jsonBody.ProjectAttrs.WebsiteURL != "" {
+		if err := helpers.ValidateURL(jsonBody.ProjectAttrs.WebsiteURL); err != nil {
+			return utilities.CheckForError(c, err, 400, "project website url", err.Error())
+		}
+	}
+
 	// Create database connection.
 	db, err := database.OpenDBConnection()
 	if err != nil {
@@ -182,16 +255,24 @@ func UpdateProject(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, status, "project", err.Error())
 	}
 
-	// Set user ID from JWT data of current user.
-	userID := claims.UserID
+	// Get role of the current user in the project.
+	role, err := db.GetProjectRole(foundedProject.ID, claims.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "project role", err.Error())
+	}
 
-	// Only the creator can delete his project.
-	if foundedProject.UserID == userID {
+	// Only owners and editors of the project can update it.
+	if policy.Can(claims, policy.ProjectUpdate, policy.Resource{ProjectRole: role}) {
 		// Update project by given ID.
 		if err := db.UpdateProject(foundedProject.ID, jsonBody); err != nil {
 			return utilities.CheckForError(c, err, 400, "project", err.Error())
 		}
 
+		// Fetch metadata of the website URL in background.
+		if jsonBody.ProjectAttrs.WebsiteURL != "" {
+			unfurl.Enqueue(repository.LinkObjectProject, foundedProject.ID, []string{jsonBody.ProjectAttrs.WebsiteURL})
+		}
+
 		// Return status 204 no content.
 		return c.SendStatus(fiber.StatusNoContent)
 	} else {
@@ -208,7 +289,7 @@ func DeleteProject(c *fiber.Ctx) error {
 	}
 
 	// Validate JWT token.
-	claims, err := utilities.TokenValidateExpireTimeAndCredentials(c, credentials)
+	claims, err := auth.TokenValidateExpireTimeAndCredentials(c, credentials)
 	if err != nil {
 		return utilities.CheckForError(c, err, 401, "jwt", err.Error())
 	}
@@ -241,11 +322,14 @@ func DeleteProject(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, status, "project", err.Error())
 	}
 
-	// Set user ID from JWT data of current user.
-	userID := claims.UserID
+	// Get role of the current user in the project.
+	role, err := db.GetProjectRole(foundedProject.ID, claims.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "project role", err.Error())
+	}
 
-	// Only the creator can delete his project.
-	if foundedProject.UserID == userID {
+	// Only owners of the project can delete it.
+	if policy.Can(claims, policy.ProjectDelete, policy.Resource{ProjectRole: role}) {
 		// Delete project by given ID.
 		if err := db.DeleteProject(foundedProject.ID); err != nil {
 			return utilities.CheckForError(c, err, 400, "project", err.Error())
@@ -0,0 +1,440 @@
+package controllers
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/helpers"
+	"Komentory/api/pkg/policy"
+	"Komentory/api/platform/auth"
+	"Komentory/api/platform/database"
+	"Komentory/api/platform/webhooks"
+	"time"
+
+	"github.com/Komentory/utilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// GetProjectWebhooks func for get all webhooks of the given project (only for the project owner).
+func GetProjectWebhooks(c *fiber.Ctx) error {
+	// Set needed credentials.
+	credentials := []string{
+		utilities.GenerateCredential("projects", "update", true),
+	}
+
+	// Validate JWT token.
+	claims, err := auth.TokenValidateExpireTimeAndCredentials(c, credentials)
+	if err != nil {
+		return utilities.CheckForError(c, err, 401, "jwt", err.Error())
+	}
+
+	// Catch project ID from URL.
+	projectID, err := uuid.Parse(c.Params("project_id"))
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "project id", err.Error())
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Checking, if project with given ID is exists.
+	foundedProject, status, err := db.FindProjectByID(projectID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "project", err.Error())
+	}
+
+	// Get role of the current user in the project.
+	role, err := db.GetProjectRole(foundedProject.ID, claims.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "project role", err.Error())
+	}
+
+	// Only owners of the project can see its webhooks.
+	if !policy.Can(claims, policy.ProjectManageWebhooks, policy.Resource{ProjectRole: role}) {
+		return utilities.ThrowJSONError(c, 403, "project webhooks", "you have no permissions")
+	}
+
+	// Get all webhooks of the project.
+	projectWebhooks, status, err := db.GetProjectWebhooksByProjectID(foundedProject.ID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "project webhooks", err.Error())
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"status":   fiber.StatusOK,
+		"count":    len(projectWebhooks),
+		"webhooks": projectWebhooks,
+	})
+}
+
+// GetProjectWebhookDeliveries func for get delivery log of the given webhook (newest first).
+// Deliveries are paginated by ?limit= (max 100) and ?offset=.
+func GetProjectWebhookDeliveries(c *fiber.Ctx) error {
+	// Set needed credentials.
+	credentials := []string{
+		utilities.GenerateCredential("projects", "update", true),
+	}
+
+	// Validate JWT token.
+	claims, err := auth.TokenValidateExpireTimeAndCredentials(c, credentials)
+	if err != nil {
+		return utilities.CheckForError(c, err, 401, "jwt", err.Error())
+	}
+
+	// Catch webhook ID from URL.
+	webhookID, err := uuid.Parse(c.Params("webhook_id"))
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "webhook id", err.Error())
+	}
+
+	// Get pagination of the deliveries from URL query.
+	limit, offset, err := helpers.GetPagination(c, 20, 100)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "pagination", err.Error())
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Checking, if webhook with given ID is exists.
+	foundedWebhook, status, err := db.FindProjectWebhookByID(webhookID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "project webhook", err.Error())
+	}
+
+	// Checking, if project of the webhook is exists.
+	foundedProject, status, err := db.FindProjectByID(foundedWebhook.ProjectID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "project", err.Error())
+	}
+
+	// Get role of the current user in the project.
+	role, err := db.GetProjectRole(foundedProject.ID, claims.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "project role", err.Error())
+	}
+
+	// Only owners of the project can see its webhook deliveries.
+	if !policy.Can(claims, policy.ProjectManageWebhooks, policy.Resource{ProjectRole: role}) {
+		return utilities.ThrowJSONError(c, 403, "project webhook deliveries", "you have no permissions")
+	}
+
+	// Get delivery log of the webhook.
+	deliveries, status, err := db.GetProjectWebhookDeliveriesByWebhookID(foundedWebhook.ID, limit, offset)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "project webhook deliveries", err.Error())
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"status":     fiber.StatusOK,
+		"count":      len(deliveries),
+		"deliveries": deliveries,
+	})
+}
+
+// CreateNewProjectWebhook func for register a new webhook of the project.
+// Secret of the webhook is returned only in this response.
+func CreateNewProjectWebhook(c *fiber.Ctx) error {
+	// Set needed credentials.
+	credentials := []string{
+		utilities.GenerateCredential("projects", "update", true),
+	}
+
+	// Validate JWT token.
+	claims, err := auth.TokenValidateExpireTimeAndCredentials(c, credentials)
+	if err != nil {
+		return utilities.CheckForError(c, err, 401, "jwt", err.Error())
+	}
+
+	// Create a new struct for JSON body.
+	jsonBody := &models.CreateNewProjectWebhook{}
+
+	// Check, if received JSON data is valid.
+	if err := c.BodyParser(jsonBody); err != nil {
+		return utilities.CheckForError(c, err, 400, "project webhook", err.Error())
+	}
+
+	// Create a new validator.
+	validate := utilities.NewValidator()
+
+	// Validate project webhook fields.
+	if err := validate.Struct(jsonBody); err != nil {
+		return utilities.CheckForValidationError(c, err, 400, "project webhook")
+	}
+
+	// Validate URL of the webhook.
+	if err := helpers.ValidateURL(jsonBody.URL); err != nil {
+		return utilities.CheckForError(c, err, 400, "project webhook url", err.Error())
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Checking, if project with given ID is exists.
+	foundedProject, status, err := db.FindProjectByID(jsonBody.ProjectID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "project", err.Error())
+	}
+
+	// Get role of the current user in the project.
+	role, err := db.GetProjectRole(foundedProject.ID, claims.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "project role", err.Error())
+	}
+
+	// Only owners of the project can register its webhooks.
+	if !policy.Can(claims, policy.ProjectManageWebhooks, policy.Resource{ProjectRole: role}) {
+		return utilities.ThrowJSONError(c, 403, "project webhook", "you have no permissions")
+	}
+
+	// Generate a new secret of the webhook.
+	secret, err := webhooks.GenerateSecret()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "project webhook secret", err.Error())
+	}
+
+	// Create new ProjectWebhook struct.
+	webhook := &models.ProjectWebhook{
+		ID:        uuid.New(),
+		CreatedAt: time.Now(),
+		ProjectID: foundedProject.ID,
+		UserID:    claims.UserID,
+		URL:       jsonBody.URL,
+		Secret:    secret,
+		Events:    jsonBody.Events,
+		Active:    true,
+	}
+
+	// Create a new webhook of the project.
+	if err := db.CreateNewProjectWebhook(webhook); err != nil {
+		return utilities.CheckForError(c, err, 400, "project webhook", err.Error())
+	}
+
+	// Return status 201 created.
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  fiber.StatusCreated,
+		"webhook": webhook,
+		"secret":  secret,
+	})
+}
+
+// UpdateProjectWebhook func for update URL, events or state of the project webhook by given ID.
+func UpdateProjectWebhook(c *fiber.Ctx) error {
+	// Set needed credentials.
+	credentials := []string{
+		utilities.GenerateCredential("projects", "update", true),
+	}
+
+	// Validate JWT token.
+	claims, err := auth.TokenValidateExpireTimeAndCredentials(c, credentials)
+	if err != nil {
+		return utilities.CheckForError(c, err, 401, "jwt", err.Error())
+	}
+
+	// Create a new struct for JSON body.
+	jsonBody := &models.UpdateProjectWebhook{}
+
+	// Check, if received JSON data is valid.
+	if err := c.BodyParser(jsonBody); err != nil {
+		return utilities.CheckForError(c, err, 400, "project webhook", err.Error())
+	}
+
+	// Create a new validator.
+	validate := utilities.NewValidator()
+
+	// Validate project webhook fields.
+	if err := validate.Struct(jsonBody); err != nil {
+		return utilities.CheckForValidationError(c, err, 400, "project webhook")
+	}
+
+	// Validate URL of the webhook.
+	if err := helpers.ValidateURL(jsonBody.URL); err != nil {
+		return utilities.CheckForError(c, err, 400, "project webhook url", err.Error())
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Checking, if webhook with given ID is exists.
+	foundedWebhook, status, err := db.FindProjectWebhookByID(jsonBody.ID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "project webhook", err.Error())
+	}
+
+	// Checking, if project of the webhook is exists.
+	foundedProject, status, err := db.FindProjectByID(foundedWebhook.ProjectID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "project", err.Error())
+	}
+
+	// Get role of the current user in the project.
+	role, err := db.GetProjectRole(foundedProject.ID, claims.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "project role", err.Error())
+	}
+
+	// Only owners of the project can update its webhooks.
+	if !policy.Can(claims, policy.ProjectManageWebhooks, policy.Resource{ProjectRole: role}) {
+		return utilities.ThrowJSONError(c, 403, "project webhook", "you have no permissions")
+	}
+
+	// Update webhook by given ID.
+	if err := db.UpdateProjectWebhook(foundedWebhook.ID, jsonBody); err != nil {
+		return utilities.CheckForError(c, err, 400, "project webhook", err.Error())
+	}
+
+	// Return status 204 no content.
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// DeleteProjectWebhook func for delete project webhook by given ID.
+func DeleteProjectWebhook(c *fiber.Ctx) error {
+	// Set needed credentials.
+	credentials := []string{
+		utilities.GenerateCredential("projects", "update", true),
+	}
+
+	// Validate JWT token.
+	claims, err := auth.TokenValidateExpireTimeAndCredentials(c, credentials)
+	if err != nil {
+		return utilities.CheckForError(c, err, 401, "jwt", err.Error())
+	}
+
+	// Create a new struct for JSON body.
+	jsonBody := &models.DeleteProjectWebhook{}
+
+	// Check, if received JSON data is valid.
+	if err := c.BodyParser(jsonBody); err != nil {
+		return utilities.CheckForError(c, err, 400, "project webhook", err.Error())
+	}
+
+	// Create a new validator.
+	validate := utilities.NewValidator()
+
+	// Validate project webhook fields.
+	if err := validate.Struct(jsonBody); err != nil {
+		return utilities.CheckForValidationError(c, err, 400, "project webhook")
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Checking, if webhook with given ID is exists.
+	foundedWebhook, status, err := db.FindProjectWebhookByID(jsonBody.ID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "project webhook", err.Error())
+	}
+
+	// Checking, if project of the webhook is exists.
+	foundedProject, status, err := db.FindProjectByID(foundedWebhook.ProjectID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "project", err.Error())
+	}
+
+	// Get role of the current user in the project.
+	role, err := db.GetProjectRole(foundedProject.ID, claims.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "project role", err.Error())
+	}
+
+	// Only owners of the project can delete its webhooks.
+	if !policy.Can(claims, policy.ProjectManageWebhooks, policy.Resource{ProjectRole: role}) {
+		return utilities.ThrowJSONError(c, 403, "project webhook", "you have no permissions")
+	}
+
+	// Delete webhook by given ID.
+	if err := db.DeleteProjectWebhook(foundedWebhook.ID); err != nil {
+		return utilities.CheckForError(c, err, 400, "project webhook", err.Error())
+	}
+
+	// Return status 204 no content.
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// PingProjectWebhook func for send test event to the project webhook right now.
+// Returns delivery with the response of the webhook.
+func PingProjectWebhook(c *fiber.Ctx) error {
+	// Set needed credentials.
+	credentials := []string{
+		utilities.GenerateCredential("projects", "update", true),
+	}
+
+	// Validate JWT token.
+	claims, err := auth.TokenValidateExpireTimeAndCredentials(c, credentials)
+	if err != nil {
+		return utilities.CheckForError(c, err, 401, "jwt", err.Error())
+	}
+
+	// Create a new struct for JSON body.
+	jsonBody := &models.PingProjectWebhook{}
+
+	// Check, if received JSON data is valid.
+	if err := c.BodyParser(jsonBody); err != nil {
+		return utilities.CheckForError(c, err, 400, "project webhook", err.Error())
+	}
+
+	// Create a new validator.
+	validate := utilities.NewValidator()
+
+	// Validate project webhook fields.
+	if err := validate.Struct(jsonBody); err != nil {
+		return utilities.CheckForValidationError(c, err, 400, "project webhook")
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Checking, if webhook with given ID is exists.
+	foundedWebhook, status, err := db.FindProjectWebhookByID(jsonBody.ID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "project webhook", err.Error())
+	}
+
+	// Checking, if project of the webhook is exists.
+	foundedProject, status, err := db.FindProjectByID(foundedWebhook.ProjectID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "project", err.Error())
+	}
+
+	// Get role of the current user in the project.
+	role, err := db.GetProjectRole(foundedProject.ID, claims.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "project role", err.Error())
+	}
+
+	// Only owners of the project can ping its webhooks.
+	if !policy.Can(claims, policy.ProjectManageWebhooks, policy.Resource{ProjectRole: role}) {
+		return utilities.ThrowJSONError(c, 403, "project webhook", "you have no permissions")
+	}
+
+	// Send test event to the webhook.
+	delivery, err := webhooks.DefaultDispatcher.Ping(c.Context(), &foundedWebhook)
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "project webhook delivery", err.Error())
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"status":   fiber.StatusOK,
+		"delivery": delivery,
+	})
+}
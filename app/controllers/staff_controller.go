@@ -0,0 +1,268 @@
+package controllers
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/helpers"
+	"Komentory/api/pkg/policy"
+	"Komentory/api/pkg/repository"
+	"Komentory/api/platform/auth"
+	"Komentory/api/platform/database"
+	"fmt"
+	"time"
+
+	"github.com/Komentory/utilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// overrideActions (private) actions of the policy for the staff overrides by type of the object.
+var overrideActions = map[string]map[string]policy.Action{
+	repository.StaffActionUpdateStatus: {
+		repository.AuditObjectProject: policy.ProjectOverrideStatus,
+		repository.AuditObjectTask:    policy.TaskOverrideStatus,
+		repository.AuditObjectAnswer:  policy.AnswerOverrideStatus,
+	},
+	repository.StaffActionDelete: {
+		repository.AuditObjectProject: policy.ProjectOverrideDelete,
+		repository.AuditObjectTask:    policy.TaskOverrideDelete,
+		repository.AuditObjectAnswer:  policy.AnswerOverrideDelete,
+	},
+}
+
+// overrideObject (private) struct to describe any project, task or answer, which is overridden by staff.
+type overrideObject struct {
+	UserID uuid.UUID
+	Answer *models.Answer // only for answers (to notify author)
+}
+
+// OverrideProjectStatus func for update status of any project by moderator or admin.
+func OverrideProjectStatus(c *fiber.Ctx) error {
+	return overrideStatus(c, repository.AuditObjectProject)
+}
+
+// OverrideTaskStatus func for update status of any task by moderator or admin.
+func OverrideTaskStatus(c *fiber.Ctx) error {
+	return overrideStatus(c, repository.AuditObjectTask)
+}
+
+// OverrideAnswerStatus func for update status of any answer by moderator or admin.
+func OverrideAnswerStatus(c *fiber.Ctx) error {
+	return overrideStatus(c, repository.AuditObjectAnswer)
+}
+
+// OverrideDeleteProject func for delete any project by admin.
+func OverrideDeleteProject(c *fiber.Ctx) error {
+	return overrideDelete(c, repository.AuditObjectProject)
+}
+
+// OverrideDeleteTask func for delete any task by admin.
+func OverrideDeleteTask(c *fiber.Ctx) error {
+	return overrideDelete(c, repository.AuditObjectTask)
+}
+
+// OverrideDeleteAnswer func for delete any answer by admin.
+func OverrideDeleteAnswer(c *fiber.Ctx) error {
+	return overrideDelete(c, repository.AuditObjectAnswer)
+}
+
+// GetAuditLogs func for get records of the audit log (newest first).
+// Records can be filtered by ?object_type= and ?object_id= and paginated by ?limit= (max 100) and ?offset=.
+func GetAuditLogs(c *fiber.Ctx) error {
+	// Get claims from JWT.
+	claims, err := auth.TokenValidateExpireTime(c)
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
+	}
+
+	// Only admins can see the audit log.
+	if policy.StaffRole(claims) != repository.StaffRoleAdmin {
+		return utilities.ThrowJSONError(c, 403, "audit logs", "you have no permissions")
+	}
+
+	// Get pagination of the records from URL query.
+	limit, offset, err := helpers.GetPagination(c, 20, 100)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "pagination", err.Error())
+	}
+
+	// Define object ID filter from URL query.
+	objectID := uuid.Nil
+	if c.Query("object_id") != "" {
+		if objectID, err = uuid.Parse(c.Query("object_id")); err != nil {
+			return utilities.CheckForError(c, err, 400, "object id", err.Error())
+		}
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Get records of the audit log.
+	logs, status, err := db.GetAuditLogs(c.Query("object_type"), objectID, limit, offset)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "audit logs", err.Error())
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"status": fiber.StatusOK,
+		"count":  len(logs),
+		"logs":   logs,
+	})
+}
+
+// overrideStatus (private) func for update status of any object by staff with writing it to the audit log.
+func overrideStatus(c *fiber.Ctx, objectType string) error {
+	// Get claims from JWT.
+	claims, err := auth.TokenValidateExpireTime(c)
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
+	}
+
+	// Only staff with credential for any object can update its status.
+	if !policy.Can(claims, overrideActions[repository.StaffActionUpdateStatus][objectType], policy.Resource{}) {
+		return utilities.ThrowJSONError(c, 403, objectType, "you have no permissions")
+	}
+
+	// Create a new struct for JSON body.
+	jsonBody := &models.OverrideStatus{}
+
+	// Check, if received JSON data is valid.
+	if err := c.BodyParser(jsonBody); err != nil {
+		return utilities.CheckForError(c, err, 400, objectType, err.Error())
+	}
+
+	// Create a new validator.
+	validate := utilities.NewValidator()
+
+	// Validate override fields.
+	if err := validate.Struct(jsonBody); err != nil {
+		return utilities.CheckForValidationError(c, err, 400, objectType)
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Checking, if object with given ID is exists.
+	foundedObject, status, err := findOverrideObject(db, objectType, jsonBody.ID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, objectType, err.Error())
+	}
+
+	// Update status of the object and write staff action to the audit log.
+	previousStatus, err := db.OverrideStatus(&models.AuditLog{
+		ID:           uuid.New(),
+		CreatedAt:    time.Now(),
+		ActorID:      &claims.UserID,
+		ObjectType:   objectType,
+		ObjectID:     jsonBody.ID,
+		ObjectUserID: foundedObject.UserID,
+		Action:       repository.StaffActionUpdateStatus,
+		Reason:       jsonBody.Reason,
+	}, jsonBody.Status)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, objectType, err.Error())
+	}
+
+	// Notify author of the answer about changed status in the inbox.
+	if foundedObject.Answer != nil && previousStatus != jsonBody.Status {
+		notifyAnswerStatusChanged(db, foundedObject.Answer, claims.UserID, models.NotificationPayload{
+			"action":          repository.StaffActionUpdateStatus,
+			"previous_status": previousStatus,
+			"status":          jsonBody.Status,
+			"reason":          jsonBody.Reason,
+		})
+	}
+
+	// Return status 204 no content.
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// overrideDelete (private) func for delete any object by staff with writing it to the audit log.
+func overrideDelete(c *fiber.Ctx, objectType string) error {
+	// Get claims from JWT.
+	claims, err := auth.TokenValidateExpireTime(c)
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
+	}
+
+	// Only staff with credential for any object can delete it.
+	if !policy.Can(claims, overrideActions[repository.StaffActionDelete][objectType], policy.Resource{}) {
+		return utilities.ThrowJSONError(c, 403, objectType, "you have no permissions")
+	}
+
+	// Create a new struct for JSON body.
+	jsonBody := &models.OverrideDelete{}
+
+	// Check, if received JSON data is valid.
+	if err := c.BodyParser(jsonBody); err != nil {
+		return utilities.CheckForError(c, err, 400, objectType, err.Error())
+	}
+
+	// Create a new validator.
+	validate := utilities.NewValidator()
+
+	// Validate override fields.
+	if err := validate.Struct(jsonBody); err != nil {
+		return utilities.CheckForValidationError(c, err, 400, objectType)
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Checking, if object with given ID is exists.
+	foundedObject, status, err := findOverrideObject(db, objectType, jsonBody.ID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, objectType, err.Error())
+	}
+
+	// Delete object and write staff action to the audit log.
+	if err := db.OverrideDelete(&models.AuditLog{
+		ID:           uuid.New(),
+		CreatedAt:    time.Now(),
+		ActorID:      &claims.UserID,
+		ObjectType:   objectType,
+		ObjectID:     jsonBody.ID,
+		ObjectUserID: foundedObject.UserID,
+		Action:       repository.StaffActionDelete,
+		Reason:       jsonBody.Reason,
+	}); err != nil {
+		return utilities.CheckForError(c, err, 400, objectType, err.Error())
+	}
+
+	// Notify author of the answer about deleted answer in the inbox.
+	if foundedObject.Answer != nil {
+		notifyAnswerStatusChanged(db, foundedObject.Answer, claims.UserID, models.NotificationPayload{
+			"action": repository.StaffActionDelete,
+			"reason": jsonBody.Reason,
+		})
+	}
+
+	// Return status 204 no content.
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// findOverrideObject (private) func for finding project, task or answer by given type and ID.
+func findOverrideObject(db *database.Queries, objectType string, id uuid.UUID) (overrideObject, int, error) {
+	switch objectType {
+	case repository.AuditObjectProject:
+		project, status, err := db.FindProjectByID(id)
+		return overrideObject{UserID: project.UserID}, status, err
+	case repository.AuditObjectTask:
+		task, status, err := db.FindTaskByID(id)
+		return overrideObject{UserID: task.UserID}, status, err
+	case repository.AuditObjectAnswer:
+		answer, status, err := db.FindAnswerByID(id)
+		return overrideObject{UserID: answer.UserID, Answer: &answer}, status, err
+	default:
+		return overrideObject{}, fiber.StatusBadRequest, fmt.Errorf("wrong object type %s", objectType)
+	}
+}
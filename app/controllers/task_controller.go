@@ -2,7 +2,12 @@ package controllers
 
 import (
 	"Komentory/api/app/models"
+	"Komentory/api/pkg/helpers"
+	"Komentory/api/pkg/policy"
+	"Komentory/api/pkg/repository"
+	"Komentory/api/platform/auth"
 	"Komentory/api/platform/database"
+	"Komentory/api/platform/unfurl"
 	"time"
 
 	"github.com/Komentory/utilities"
@@ -18,6 +23,12 @@ func GetTaskByID(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, 400, "task id", err.Error())
 	}
 
+	// Define format of the descriptions from URL query.
+	render, err := helpers.GetDescriptionFormat(c)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "format", err.Error())
+	}
+
 	// Create database connection.
 	db, err := database.OpenDBConnection()
 	if err != nil {
@@ -30,6 +41,11 @@ func GetTaskByID(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, status, "task", err.Error())
 	}
 
+	// Render Markdown descriptions to HTML, if it was requested.
+	if render != nil {
+		task.Attrs.ConvertDescriptions(render)
+	}
+
 	// Return status 200 OK.
 	return c.JSON(fiber.Map{
 		"status": fiber.StatusOK,
@@ -45,6 +61,12 @@ func GetTasksByProjectID(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, 400, "project id", err.Error())
 	}
 
+	// Define format of the descriptions from URL query.
+	render, err := helpers.GetDescriptionFormat(c)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "format", err.Error())
+	}
+
 	// Create database connection.
 	db, err := database.OpenDBConnection()
 	if err != nil {
@@ -57,6 +79,13 @@ func GetTasksByProjectID(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, status, "tasks", err.Error())
 	}
 
+	// Render Markdown descriptions to HTML, if it was requested.
+	if render != nil {
+		for i := range tasks {
+			tasks[i].Attrs.ConvertDescriptions(render)
+		}
+	}
+
 	// Return status 200 OK.
 	return c.JSON(fiber.Map{
 		"status": fiber.StatusOK,
@@ -65,6 +94,58 @@ func GetTasksByProjectID(c *fiber.Ctx) error {
 	})
 }
 
+// GetTaskResultsByTaskID func for get aggregated results of the task questions.
+func GetTaskResultsByTaskID(c *fiber.Ctx) error {
+	// Get claims from JWT.
+	claims, err := auth.TokenValidateExpireTime(c)
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
+	}
+
+	// Catch task ID from URL.
+	taskID, err := uuid.Parse(c.Params("task_id"))
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "task id", err.Error())
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Checking, if task with given ID is exists.
+	foundedTask, status, err := db.FindTaskByID(taskID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "task", err.Error())
+	}
+
+	// Get role of the current user in the project.
+	role, err := db.GetProjectRole(foundedTask.ProjectID, claims.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "project role", err.Error())
+	}
+
+	// Only collaborators of the project can see results of the task.
+	if !policy.Can(claims, policy.TaskViewResults, policy.Resource{ProjectRole: role}) {
+		// Return status 403 and permission denied error message.
+		return utilities.ThrowJSONError(c, 403, "task", "you have no permissions")
+	}
+
+	// Get aggregated results of the task questions.
+	results, status, err := db.GetTaskResultsByTaskID(foundedTask.ID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "task results", err.Error())
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"status":  fiber.StatusOK,
+		"count":   len(results),
+		"results": results,
+	})
+}
+
 // CreateNewTask func for create a new task for project.
 func CreateNewTask(c *fiber.Ctx) error {
 	// Set needed credentials.
@@ -73,7 +154,7 @@ func CreateNewTask(c *fiber.Ctx) error {
 	}
 
 	// Validate JWT token.
-	claims, err := utilities.TokenValidateExpireTimeAndCredentials(c, credentials)
+	claims, err := auth.TokenValidateExpireTimeAndCredentials(c, credentials)
 	if err != nil {
 		return utilities.CheckForError(c, err, 401, "jwt", err.Error())
 	}
@@ -101,8 +182,14 @@ func CreateNewTask(c *fiber.Ctx) error {
 	// Set user ID from JWT data of current user.
 	userID := claims.UserID
 
-	// Only the creator can add a new task for his project.
-	if foundedProject.UserID == userID {
+	// Get role of the current user in the project.
+	role, err := db.GetProjectRole(foundedProject.ID, userID)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "project role", err.Error())
+	}
+
+	// Only owners and editors of the project can add a new task.
+	if policy.Can(claims, policy.TaskCreate, policy.Resource{ProjectRole: role}) {
 		// Create new Task struct.
 		task := &models.Task{}
 
@@ -116,6 +203,12 @@ func CreateNewTask(c *fiber.Ctx) error {
 		task.TaskStatus = jsonBody.TaskStatus // 0 == draft, 1 == active, 2 == unpublished
 		task.TaskAttrs = jsonBody.TaskAttrs
 
+		// Sanitize Markdown descriptions.
+		task.TaskAttrs.ConvertDescriptions(helpers.SanitizeMarkdown)
+
+		// Link metadata can be set only by the link unfurl worker.
+		task.TaskAttrs.LinksMetadata = nil
+
 		// Create a new validator for a Task model.
 		validate := utilities.NewValidator()
 
@@ -124,11 +217,26 @@ func CreateNewTask(c *fiber.Ctx) error {
 			return utilities.CheckForValidationError(c, err, 400, "task")
 		}
 
+		// Validate schema of the task questions.
+		if err := helpers.ValidateTaskQuestions(task.TaskAttrs.Questions); err != nil {
+			return utilities.CheckForError(c, err, 400, "task questions", err.Error())
+		}
+
+		// Validate links of the task.
+		if err := helpers.ValidateLinks(task.TaskAttrs.Links); err != nil {
+			return utilities.CheckForError(c, err, 400, "task links", err.Error())
+		}
+
 		// Create a new task with given attrs.
 		if err := db.CreateNewTask(task); err != nil {
 			return utilities.CheckForError(c, err, 400, "task", err.Error())
 		}
 
+		// Fetch metadata of the task links in background.
+		if len(task.TaskAttrs.Links) > 0 {
+			unfurl.Enqueue(repository.LinkObjectTask, task.ID, task.TaskAttrs.Links)
+		}
+
 		// Return status 201 created.
 		return c.SendStatus(fiber.StatusCreated)
 	} else {
@@ -145,7 +253,7 @@ func UpdateTask(c *fiber.Ctx) error {
 	}
 
 	// Validate JWT token.
-	claims, err := utilities.TokenValidateExpireTimeAndCredentials(c, credentials)
+	claims, err := auth.TokenValidateExpireTimeAndCredentials(c, credentials)
 	if err != nil {
 		return utilities.CheckForError(c, err, 401, "jwt", err.Error())
 	}
@@ -158,6 +266,12 @@ func UpdateTask(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, 400, "task", err.Error())
 	}
 
+	// Sanitize Markdown descriptions.
+	jsonBody.TaskAttrs.ConvertDescriptions(helpers.SanitizeMarkdown)
+
+	// Link metadata can be set only by the link unfurl worker.
+	jsonBody.TaskAttrs.LinksMetadata = nil
+
 	// Create a new validator.
 	validate := utilities.NewValidator()
 
@@ -166,6 +280,16 @@ func UpdateTask(c *fiber.Ctx) error {
 		return utilities.CheckForValidationError(c, err, 400, "task")
 	}
 
+	// Validate schema of the task questions.
+	if err := helpers.ValidateTaskQuestions(jsonBody.TaskAttrs.Questions); err != nil {
+		return utilities.CheckForError(c, err, 400, "task questions", err.Error())
+	}
+
+	// Validate links of the task.
+	if err := helpers.ValidateLinks(jsonBody.TaskAttrs.Links); err != nil {
+		return utilities.CheckForError(c, err, 400, "task links", err.Error())
+	}
+
 	// Create database connection.
 	db, err := database.OpenDBConnection()
 	if err != nil {
@@ -178,16 +302,24 @@ func UpdateTask(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, status, "task", err.Error())
 	}
 
-	// Set user ID from JWT data of current user.
-	userID := claims.UserID
+	// Get role of the current user in the project.
+	role, err := db.GetProjectRole(foundedTask.ProjectID, claims.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "project role", err.Error())
+	}
 
-	// Only the creator can delete his task.
-	if foundedTask.UserID == userID {
+	// Only owners and editors of the project can update its tasks.
+	if policy.Can(claims, policy.TaskUpdate, policy.Resource{ProjectRole: role}) {
 		// Update task by given ID.
 		if err := db.UpdateTask(foundedTask.ID, jsonBody); err != nil {
 			return utilities.CheckForError(c, err, 400, "task", err.Error())
 		}
 
+		// Fetch metadata of the task links in background.
+		if len(jsonBody.TaskAttrs.Links) > 0 {
+			unfurl.Enqueue(repository.LinkObjectTask, foundedTask.ID, jsonBody.TaskAttrs.Links)
+		}
+
 		// Return status 204 no content.
 		return c.SendStatus(fiber.StatusNoContent)
 	} else {
@@ -204,7 +336,7 @@ func DeleteTask(c *fiber.Ctx) error {
 	}
 
 	// Validate JWT token.
-	claims, err := utilities.TokenValidateExpireTimeAndCredentials(c, credentials)
+	claims, err := auth.TokenValidateExpireTimeAndCredentials(c, credentials)
 	if err != nil {
 		return utilities.CheckForError(c, err, 401, "jwt", err.Error())
 	}
@@ -237,11 +369,14 @@ func DeleteTask(c *fiber.Ctx) error {
 		return utilities.CheckForError(c, err, status, "task", err.Error())
 	}
 
-	// Set user ID from JWT data of current user.
-	userID := claims.UserID
+	// Get role of the current user in the project.
+	role, err := db.GetProjectRole(foundedTask.ProjectID, claims.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "project role", err.Error())
+	}
 
-	// Only the creator can delete his task.
-	if foundedTask.UserID == userID {
+	// Only owners and editors of the project can delete its tasks.
+	if policy.Can(claims, policy.TaskDelete, policy.Resource{ProjectRole: role}) {
 		// Delete task by given ID.
 		if err := db.DeleteTask(jsonBody.ID); err != nil {
 			return utilities.CheckForError(c, err, 400, "task", err.Error())
@@ -254,3 +389,55 @@ func DeleteTask(c *fiber.Ctx) error {
 		return utilities.ThrowJSONError(c, 403, "task", "you have no permissions")
 	}
 }
+
+// GetDraftTasksByProjectID func for get not active (draft and unpublished) tasks of the project.
+// Only collaborators of the project can see drafts.
+func GetDraftTasksByProjectID(c *fiber.Ctx) error {
+	// Get claims from JWT.
+	claims, err := auth.TokenValidateExpireTime(c)
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
+	}
+
+	// Catch project ID from URL.
+	projectID, err := uuid.Parse(c.Params("project_id"))
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "project id", err.Error())
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Checking, if project with given ID is exists.
+	foundedProject, status, err := db.FindProjectByID(projectID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "project", err.Error())
+	}
+
+	// Get role of the current user in the project.
+	role, err := db.GetProjectRole(foundedProject.ID, claims.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "project role", err.Error())
+	}
+
+	// Only collaborators of the project can see draft tasks.
+	if !policy.Can(claims, policy.TaskViewDrafts, policy.Resource{ProjectRole: role}) {
+		return utilities.ThrowJSONError(c, 403, "tasks", "you have no permissions")
+	}
+
+	// Get draft tasks of the project.
+	tasks, status, err := db.GetDraftTasksByProjectID(foundedProject.ID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "tasks", err.Error())
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"status": fiber.StatusOK,
+		"count":  len(tasks),
+		"tasks":  tasks,
+	})
+}
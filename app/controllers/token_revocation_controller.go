@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/policy"
+	"Komentory/api/pkg/repository"
+	"Komentory/api/platform/auth"
+	"Komentory/api/platform/database"
+
+	"github.com/Komentory/utilities"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RevokeCurrentToken func for revoke the token of the current request (logout).
+// JWT is revoked by "jti" claim, personal access token is revoked by its ID.
+func RevokeCurrentToken(c *fiber.Ctx) error {
+	// Get claims from JWT.
+	if _, err := auth.TokenValidateExpireTime(c); err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
+	}
+
+	// Get current token.
+	token, err := auth.CurrentToken(c)
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
+	}
+
+	// Revoke personal access token by ID.
+	if patID, ok := auth.PersonalAccessTokenID(token); ok {
+		// Create database connection.
+		db, err := database.OpenDBConnection()
+		if err != nil {
+			return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+		}
+
+		// Revoke personal access token.
+		if err := db.RevokePersonalAccessToken(patID); err != nil {
+			return utilities.CheckForError(c, err, 400, "personal access token", err.Error())
+		}
+
+		// Return status 204 no content.
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+
+	// Revoke JWT by jti.
+	if err := auth.RevokeToken(token); err != nil {
+		return utilities.CheckForError(c, err, 400, "jwt", err.Error())
+	}
+
+	// Return status 204 no content.
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// RevokeUserTokens func for revoke all tokens (JWT and personal access tokens) of the given user,
+// issued before now. User has to sign in again.
+func RevokeUserTokens(c *fiber.Ctx) error {
+	// Get claims from JWT.
+	claims, err := auth.TokenValidateExpireTime(c)
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
+	}
+
+	// Only admin can revoke tokens of the user.
+	if policy.StaffRole(claims) != repository.StaffRoleAdmin {
+		return utilities.ThrowJSONError(c, 403, "user tokens", "you have no permissions")
+	}
+
+	// Create a new struct for JSON body.
+	jsonBody := &models.RevokeUserTokens{}
+
+	// Check, if received JSON data is valid.
+	if err := c.BodyParser(jsonBody); err != nil {
+		return utilities.CheckForError(c, err, 400, "user tokens", err.Error())
+	}
+
+	// Create a new validator.
+	validate := utilities.NewValidator()
+
+	// Validate user tokens fields.
+	if err := validate.Struct(jsonBody); err != nil {
+		return utilities.CheckForValidationError(c, err, 400, "user tokens")
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Checking, if user with given ID is exists.
+	foundedUser, status, err := db.GetUserByID(jsonBody.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "user", err.Error())
+	}
+
+	// Revoke all tokens of the user.
+	if err := auth.RevokeUserTokens(foundedUser.ID, &claims.UserID); err != nil {
+		return utilities.CheckForError(c, err, 400, "user tokens", err.Error())
+	}
+
+	// Return status 204 no content.
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,259 @@
+package controllers
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/helpers"
+	"Komentory/api/pkg/repository"
+	"Komentory/api/platform/auth"
+	"Komentory/api/platform/database"
+	"bytes"
+	"html/template"
+
+	"github.com/Komentory/utilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// GetUserByID func for get public profile of the user by ID.
+func GetUserByID(c *fiber.Ctx) error {
+	// Catch user ID from URL.
+	userID, err := uuid.Parse(c.Params("user_id"))
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "user id", err.Error())
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Get public profile of the user.
+	user, status, err := db.GetUserByID(userID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "user", err.Error())
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"status": fiber.StatusOK,
+		"user":   user,
+	})
+}
+
+// UpdateUserProfile func for update profile (names, picture, abilities) of the current user.
+func UpdateUserProfile(c *fiber.Ctx) error {
+	// Set needed credentials.
+	credentials := []string{
+		utilities.GenerateCredential("user_attrs", "update", true),
+	}
+
+	// Validate JWT token.
+	claims, err := auth.TokenValidateExpireTimeAndCredentials(c, credentials)
+	if err != nil {
+		return utilities.CheckForError(c, err, 401, "jwt", err.Error())
+	}
+
+	// Create a new struct for JSON body.
+	jsonBody := &models.UpdateUserProfile{}
+
+	// Check, if received JSON data is valid.
+	if err := c.BodyParser(jsonBody); err != nil {
+		return utilities.CheckForError(c, err, 400, "user profile", err.Error())
+	}
+
+	// Create a new validator.
+	validate := utilities.NewValidator()
+
+	// Validate user profile fields.
+	if err := validate.Struct(jsonBody); err != nil {
+		return utilities.CheckForValidationError(c, err, 400, "user profile")
+	}
+
+	// Set user ID from JWT data of current user.
+	userID := claims.UserID
+
+	// Picture can be only one of the files, uploaded to CDN by current user.
+	if jsonBody.Picture != nil && *jsonBody.Picture != "" {
+		if err := helpers.ValidateUserCDNFileURL(*jsonBody.Picture, userID); err != nil {
+			return utilities.CheckForError(c, err, 400, "user picture", err.Error())
+		}
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Update profile of the current user.
+	if err := db.UpdateUserProfile(userID, jsonBody); err != nil {
+		return utilities.CheckForError(c, err, 400, "user profile", err.Error())
+	}
+
+	// Return status 204 no content.
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetUserSettings func for get settings (email subscriptions) of the current user.
+func GetUserSettings(c *fiber.Ctx) error {
+	// Get claims from JWT.
+	claims, err := auth.TokenValidateExpireTime(c)
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Get settings of the current user.
+	settings, status, err := db.GetUserSettingsByID(claims.UserID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "user settings", err.Error())
+	}
+
+	// Get deliverability state of the current user's email address (empty, if there were no events).
+	deliverability, status, err := db.GetEmailDeliverabilityByUserID(claims.UserID)
+	if err != nil && status != fiber.StatusNotFound {
+		return utilities.CheckForError(c, err, status, "email deliverability", err.Error())
+	}
+	deliverability.UserID = claims.UserID
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"status":         fiber.StatusOK,
+		"settings":       settings,
+		"deliverability": deliverability,
+	})
+}
+
+// UpdateUserSettings func for toggle email subscriptions and notification types of the current user.
+func UpdateUserSettings(c *fiber.Ctx) error {
+	// Set needed credentials.
+	credentials := []string{
+		utilities.GenerateCredential("user_settings", "update", true),
+	}
+
+	// Validate JWT token.
+	claims, err := auth.TokenValidateExpireTimeAndCredentials(c, credentials)
+	if err != nil {
+		return utilities.CheckForError(c, err, 401, "jwt", err.Error())
+	}
+
+	// Create a new struct for JSON body.
+	jsonBody := &models.UpdateUserSettings{}
+
+	// Check, if received JSON data is valid.
+	if err := c.BodyParser(jsonBody); err != nil {
+		return utilities.CheckForError(c, err, 400, "user settings", err.Error())
+	}
+
+	// Create a new validator.
+	validate := utilities.NewValidator()
+
+	// Validate user settings fields.
+	if err := validate.Struct(jsonBody); err != nil {
+		return utilities.CheckForValidationError(c, err, 400, "user settings")
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Toggle only given subscriptions.
+	emailSubscriptions := map[string]bool{}
+	if jsonBody.EmailSubscriptions.Transactional != nil {
+		emailSubscriptions[repository.EmailStreamTransactional] = *jsonBody.EmailSubscriptions.Transactional
+	}
+	if jsonBody.EmailSubscriptions.Marketing != nil {
+		emailSubscriptions[repository.EmailStreamMarketing] = *jsonBody.EmailSubscriptions.Marketing
+	}
+
+	// Change user settings (only given notification types are toggled).
+	settings, err := db.UpdateUserSettings(claims.UserID, emailSubscriptions, jsonBody.Notifications)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "user settings", err.Error())
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"status":   fiber.StatusOK,
+		"settings": settings,
+	})
+}
+
+// unsubscribeConfirmation (private) template of the page with button to confirm unsubscribe.
+var unsubscribeConfirmation = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><meta name="robots" content="noindex"><title>Unsubscribe</title></head>
+<body>
+<form method="post" action="?token={{.Token}}">
+<p>Unsubscribe from the {{.Stream}} emails?</p>
+<button type="submit">Unsubscribe</button>
+</form>
+</body>
+</html>`))
+
+// ConfirmUnsubscribeUser func for render page to confirm unsubscribe by signed token (from email footer).
+// Nothing is changed here, because links in emails are opened by mail scanners and prefetchers.
+func ConfirmUnsubscribeUser(c *fiber.Ctx) error {
+	// Check signature of the token and get email stream.
+	_, stream, err := helpers.ParseUnsubscribeToken(c.Query("token"))
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "unsubscribe token", err.Error())
+	}
+
+	// Render confirmation page with the token.
+	page := &bytes.Buffer{}
+	if err := unsubscribeConfirmation.Execute(page, fiber.Map{"Token": c.Query("token"), "Stream": stream}); err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "unsubscribe", err.Error())
+	}
+
+	// Return status 200 OK.
+	c.Type("html")
+	return c.Send(page.Bytes())
+}
+
+// UnsubscribeUser func for unsubscribe user from email stream by signed token
+// (from confirmation page or one-click POST of the mail client by RFC 8058).
+// Token is generated by helpers.GenerateUnsubscribeToken and passed in ?token= URL query.
+func UnsubscribeUser(c *fiber.Ctx) error {
+	// Check signature of the token and get user ID with email stream.
+	userID, stream, err := helpers.ParseUnsubscribeToken(c.Query("token"))
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "unsubscribe token", err.Error())
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Get current settings of the user.
+	settings, status, err := db.GetUserSettingsByID(userID)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "user settings", err.Error())
+	}
+
+	// Unsubscribe user from the given email stream.
+	if !settings.EmailSubscriptions.SetStream(stream, false) {
+		return utilities.ThrowJSONError(c, 400, "unsubscribe token", "unknown email stream")
+	}
+
+	// Change user settings.
+	if _, err := db.UpdateUserSettings(userID, map[string]bool{stream: false}, nil); err != nil {
+		return utilities.CheckForError(c, err, 400, "user settings", err.Error())
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"status": fiber.StatusOK,
+		"stream": stream,
+	})
+}
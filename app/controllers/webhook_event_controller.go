@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/helpers"
+	"Komentory/api/pkg/policy"
+	"Komentory/api/pkg/repository"
+	"Komentory/api/platform/auth"
+	"Komentory/api/platform/database"
+	"Komentory/api/platform/webhooks"
+
+	"github.com/Komentory/utilities"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetWebhookEvents func for get received webhook events (newest first).
+// Events can be filtered by ?vendor= and ?status= and paginated by ?limit= (max 100) and ?offset=.
+func GetWebhookEvents(c *fiber.Ctx) error {
+	// Get claims from JWT.
+	claims, err := auth.TokenValidateExpireTime(c)
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
+	}
+
+	// Only admins can manage webhook events (raw bodies contain email addresses of the users).
+	if policy.StaffRole(claims) != repository.StaffRoleAdmin {
+		return utilities.ThrowJSONError(c, 403, "webhook events", "you have no permissions")
+	}
+
+	// Get pagination of the events from URL query.
+	limit, offset, err := helpers.GetPagination(c, 20, 100)
+	if err != nil {
+		return utilities.CheckForError(c, err, 400, "pagination", err.Error())
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Get webhook events.
+	events, status, err := db.GetWebhookEvents(c.Query("vendor"), c.Query("status"), limit, offset)
+	if err != nil {
+		return utilities.CheckForError(c, err, status, "webhook events", err.Error())
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"status": fiber.StatusOK,
+		"count":  len(events),
+		"events": events,
+	})
+}
+
+// ReplayWebhookEvent func for return received webhook event to the processing queue.
+func ReplayWebhookEvent(c *fiber.Ctx) error {
+	// Get claims from JWT.
+	claims, err := auth.TokenValidateExpireTime(c)
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
+	}
+
+	// Only admins can manage webhook events (raw bodies contain email addresses of the users).
+	if policy.StaffRole(claims) != repository.StaffRoleAdmin {
+		return utilities.ThrowJSONError(c, 403, "webhook events", "you have no permissions")
+	}
+
+	// Create a new struct for JSON body.
+	jsonBody := &models.ReplayWebhookEvent{}
+
+	// Check, if received JSON data is valid.
+	if err := c.BodyParser(jsonBody); err != nil {
+		return utilities.CheckForError(c, err, 400, "webhook event json body", err.Error())
+	}
+
+	// Create a new validator.
+	validate := utilities.NewValidator()
+
+	// Validate replay fields.
+	if err := validate.Struct(jsonBody); err != nil {
+		return utilities.CheckForValidationError(c, err, 400, "webhook event")
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return utilities.CheckForErrorWithStatusCode(c, err, 500, "database", err.Error())
+	}
+
+	// Return event to the processing queue.
+	if status, err := db.ReplayWebhookEvent(jsonBody.ID); err != nil {
+		return utilities.CheckForError(c, err, status, "webhook event", err.Error())
+	}
+
+	// Notify background processor about replayed event.
+	webhooks.DefaultProcessor.Notify()
+
+	// Return status 202 accepted.
+	return c.SendStatus(fiber.StatusAccepted)
+}
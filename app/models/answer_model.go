@@ -23,14 +23,24 @@ type Answer struct {
 	TaskID       uuid.UUID   `db:"task_id" json:"task_id" validate:"required,uuid"`
 	AnswerStatus int         `db:"answer_status" json:"answer_status" validate:"int"`
 	AnswerAttrs  AnswerAttrs `db:"answer_attrs" json:"answer_attrs" validate:"required,dive"`
+	HiddenAt     *time.Time  `db:"hidden_at" json:"hidden_at"`
 }
 
 // AnswerAttrs struct to describe answer attributes.
 type AnswerAttrs struct {
-	Description string   `json:"description" validate:"required"`
-	Documents   []string `json:"documents"`
-	Images      []string `json:"images"`
-	Links       []string `json:"links"`
+	Description   string           `json:"description" validate:"required"`
+	Responses     []AnswerResponse `json:"responses" validate:"dive"`
+	Documents     []string         `json:"documents"`
+	Images        []string         `json:"images"`
+	Links         []string         `json:"links"`
+	LinksMetadata []LinkMetadata   `json:"links_metadata"`
+}
+
+// AnswerResponse struct to describe response to one structured question of the task.
+//  - Value == int (rating) | string (single_choice, short_text) | []string (multiple_choice) | bool (yes_no);
+type AnswerResponse struct {
+	QuestionID string      `json:"question_id" validate:"required"`
+	Value      interface{} `json:"value"`
 }
 
 // ---
@@ -56,6 +66,20 @@ type UpdateAnswer struct {
 	AnswerAttrs  AnswerAttrs `json:"answer_attrs" validate:"required,dive"`
 }
 
+// ---
+// Structures to triaging one answer.
+// ---
+
+// UpdateAnswerTriage struct to describe triage process of the given answer by project owner.
+//  - Label == "" | bug | idea | praise | question;
+//  - State == new | acknowledged | in_progress | resolved | wont_fix;
+type UpdateAnswerTriage struct {
+	ID    uuid.UUID `json:"id" validate:"required,uuid"`
+	Label string    `json:"label" validate:"omitempty,oneof=bug idea praise question"`
+	State string    `json:"state" validate:"required,oneof=new acknowledged in_progress resolved wont_fix"`
+	Note  string    `json:"note" validate:"lte=4096"`
+}
+
 // ---
 // Structures to deleting one answer.
 // ---
@@ -98,6 +122,33 @@ type GetAnswers struct {
 	Author AuthorAttrs `db:"author" json:"author"`
 }
 
+// GetAnswersWithTriage struct to describe answers list object for project owner.
+type GetAnswersWithTriage struct {
+	ID        uuid.UUID   `db:"id" json:"id"`
+	CreatedAt time.Time   `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time   `db:"updated_at" json:"updated_at"`
+	TaskID    uuid.UUID   `db:"task_id" json:"task_id"`
+	Attrs     AnswerAttrs `db:"answer_attrs" json:"attrs"`
+
+	// Fields for JOIN tables:
+	Author AuthorAttrs       `db:"author" json:"author"`
+	Triage AnswerTriageAttrs `db:"triage" json:"triage"`
+}
+
+// AnswerTriageAttrs struct to describe private triage attributes of the answer.
+type AnswerTriageAttrs struct {
+	Label     string     `json:"label"`
+	State     string     `json:"state"`
+	Note      string     `json:"note"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+// ConvertDescriptions method for converting Markdown description of the answer attributes
+// with the given func (sanitize, render, etc).
+func (a *AnswerAttrs) ConvertDescriptions(convert func(string) string) {
+	a.Description = convert(a.Description)
+}
+
 // Value make the AnswerAttrs struct implement the driver.Valuer interface.
 // This method simply returns the JSON-encoded representation of the struct.
 func (a *AnswerAttrs) Value() (driver.Value, error) {
@@ -114,3 +165,14 @@ func (a *AnswerAttrs) Scan(value interface{}) error {
 
 	return json.Unmarshal(j, &a)
 }
+
+// Scan make the AnswerTriageAttrs struct implement the sql.Scanner interface.
+// This method simply decodes a JSON-encoded value into the struct fields.
+func (a *AnswerTriageAttrs) Scan(value interface{}) error {
+	j, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(j, &a)
+}
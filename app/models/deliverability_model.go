@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ---
+// Structures to describing email deliverability model.
+// ---
+
+// EmailDeliverability struct to describe deliverability state of the user's email address.
+//  - HardBouncedAt != nil, if email address is not exists or inactive;
+//  - ComplainedAt != nil, if user marked email as spam;
+type EmailDeliverability struct {
+	UserID          uuid.UUID  `db:"user_id" json:"user_id"`
+	UpdatedAt       time.Time  `db:"updated_at" json:"updated_at"`
+	HardBouncedAt   *time.Time `db:"hard_bounced_at" json:"hard_bounced_at"`
+	BounceType      string     `db:"bounce_type" json:"bounce_type"`
+	ComplainedAt    *time.Time `db:"complained_at" json:"complained_at"`
+	LastDeliveredAt *time.Time `db:"last_delivered_at" json:"last_delivered_at"`
+	LastOpenedAt    *time.Time `db:"last_opened_at" json:"last_opened_at"`
+}
+
+// ---
+// This methods checks deliverability state.
+// ---
+
+// CanReceiveEmails method for checking, if email address was not hard-bounced and user did not complain.
+func (e *EmailDeliverability) CanReceiveEmails() bool {
+	return e.HardBouncedAt == nil && e.ComplainedAt == nil
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ---
+// Structures to describing weekly digest model.
+// ---
+
+// WeeklyDigestProject struct to describe one project with new answers in the weekly digest of its owner.
+type WeeklyDigestProject struct {
+	UserID            uuid.UUID `db:"user_id" json:"user_id"`
+	Email             string    `db:"email" json:"email"`
+	FirstName         string    `db:"first_name" json:"first_name"`
+	PeriodStart       time.Time `db:"period_start" json:"period_start"`
+	ProjectID         uuid.UUID `db:"project_id" json:"project_id"`
+	ProjectTitle      string    `db:"project_title" json:"project_title"`
+	AnswersCount      int       `db:"answers_count" json:"answers_count"`
+	ContributorsCount int       `db:"contributors_count" json:"contributors_count"`
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ---
+// Structures to describing domain event model.
+// ---
+
+// DomainEvent struct to describe one change of the project, task or answer,
+// saved to the outbox in the same transaction as the change.
+//  - AggregateType == project | task | answer;
+//  - EventType == created | updated | deleted | status_changed;
+//  - DispatchedAt == nil, until event was delivered to all subscribers;
+type DomainEvent struct {
+	ID            uuid.UUID          `db:"id" json:"id"`
+	CreatedAt     time.Time          `db:"created_at" json:"created_at"`
+	AggregateType string             `db:"aggregate_type" json:"aggregate_type"`
+	AggregateID   uuid.UUID          `db:"aggregate_id" json:"aggregate_id"`
+	ProjectID     uuid.UUID          `db:"project_id" json:"project_id"`
+	EventType     string             `db:"event_type" json:"event_type"`
+	Payload       DomainEventPayload `db:"payload" json:"payload"`
+	Attempts      int                `db:"attempts" json:"attempts"`
+	NextAttemptAt time.Time          `db:"next_attempt_at" json:"next_attempt_at"`
+	LockedAt      *time.Time         `db:"locked_at" json:"locked_at"`
+	DispatchedAt  *time.Time         `db:"dispatched_at" json:"dispatched_at"`
+	Error         string             `db:"error" json:"error"`
+}
+
+// DomainEventPayload struct to describe additional data of the domain event (like previous status).
+type DomainEventPayload map[string]interface{}
+
+// Value make the DomainEventPayload struct implement the driver.Valuer interface.
+// This method simply returns the JSON-encoded representation of the struct.
+func (p DomainEventPayload) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+// Scan make the DomainEventPayload struct implement the sql.Scanner interface.
+// This method simply decodes a JSON-encoded value into the struct fields.
+func (p *DomainEventPayload) Scan(value interface{}) error {
+	j, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(j, &p)
+}
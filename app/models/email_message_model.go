@@ -0,0 +1,85 @@
+package models
+
+import (
+	"Komentory/api/pkg/repository"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ---
+// Structures to describing email message model.
+// ---
+
+// EmailMessage struct to describe one queued outbound email.
+//  - Stream == transactional | marketing;
+//  - Status == pending | sending | sent | skipped | failed;
+type EmailMessage struct {
+	ID                uuid.UUID          `db:"id" json:"id"`
+	CreatedAt         time.Time          `db:"created_at" json:"created_at"`
+	UserID            uuid.UUID          `db:"user_id" json:"user_id"`
+	Stream            string             `db:"stream" json:"stream"`
+	Template          string             `db:"template" json:"template"`
+	TemplateModel     EmailTemplateModel `db:"template_model" json:"template_model"`
+	Status            string             `db:"status" json:"status"`
+	Attempts          int                `db:"attempts" json:"attempts"`
+	NextAttemptAt     time.Time          `db:"next_attempt_at" json:"next_attempt_at"`
+	LockedAt          *time.Time         `db:"locked_at" json:"locked_at"`
+	SentAt            *time.Time         `db:"sent_at" json:"sent_at"`
+	ProviderMessageID string             `db:"provider_message_id" json:"provider_message_id"`
+	Error             string             `db:"error" json:"error"`
+}
+
+// EmailTemplateModel struct to describe variables of the email template.
+type EmailTemplateModel map[string]interface{}
+
+// EmailRecipient struct to describe recipient of the email with his settings and deliverability state.
+type EmailRecipient struct {
+	ID            uuid.UUID    `db:"id" json:"id"`
+	Email         string       `db:"email" json:"email"`
+	Attrs         UserAttrs    `db:"user_attrs" json:"attrs"`
+	Settings      UserSettings `db:"user_settings" json:"settings"`
+	HardBouncedAt *time.Time   `db:"hard_bounced_at" json:"hard_bounced_at"`
+	ComplainedAt  *time.Time   `db:"complained_at" json:"complained_at"`
+}
+
+// ---
+// This methods checks, if recipient can get emails.
+// ---
+
+// IsSubscribed method for checking, if recipient is subscribed to the given email stream.
+func (r *EmailRecipient) IsSubscribed(stream string) bool {
+	switch stream {
+	case repository.EmailStreamTransactional:
+		return r.Settings.EmailSubscriptions.Transactional
+	case repository.EmailStreamMarketing:
+		return r.Settings.EmailSubscriptions.Marketing
+	default:
+		return false
+	}
+}
+
+// CanReceiveEmails method for checking, if email address of the recipient was not hard-bounced and he did not complain.
+func (r *EmailRecipient) CanReceiveEmails() bool {
+	return (&EmailDeliverability{HardBouncedAt: r.HardBouncedAt, ComplainedAt: r.ComplainedAt}).CanReceiveEmails()
+}
+
+// Value make the EmailTemplateModel struct implement the driver.Valuer interface.
+// This method simply returns the JSON-encoded representation of the struct.
+func (m EmailTemplateModel) Value() (driver.Value, error) {
+	return json.Marshal(m)
+}
+
+// Scan make the EmailTemplateModel struct implement the sql.Scanner interface.
+// This method simply decodes a JSON-encoded value into the struct fields.
+func (m *EmailTemplateModel) Scan(value interface{}) error {
+	j, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(j, &m)
+}
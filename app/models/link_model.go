@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// ---
+// Structures to describing link metadata model.
+// ---
+
+// LinkMetadata struct to describe fetched (unfurled) metadata of one link.
+type LinkMetadata struct {
+	URL         string    `json:"url"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Favicon     string    `json:"favicon"`
+	FetchedAt   time.Time `json:"fetched_at"`
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ---
+// Structures to describing moderation model.
+// ---
+
+// AnswerReport struct to describe report of the answer by user.
+type AnswerReport struct {
+	ID         uuid.UUID  `db:"id" json:"id" validate:"required,uuid"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+	ResolvedAt *time.Time `db:"resolved_at" json:"resolved_at"`
+	AnswerID   uuid.UUID  `db:"answer_id" json:"answer_id" validate:"required,uuid"`
+	UserID     uuid.UUID  `db:"user_id" json:"user_id" validate:"required,uuid"`
+	Reason     string     `db:"reason" json:"reason" validate:"required,oneof=spam abuse off_topic other"`
+	Comment    string     `db:"comment" json:"comment" validate:"lte=1024"`
+}
+
+// AuditLog struct to describe one record of the audit trail.
+//  - ActorID == nil for automatic (system) actions;
+type AuditLog struct {
+	ID           uuid.UUID  `db:"id" json:"id"`
+	CreatedAt    time.Time  `db:"created_at" json:"created_at"`
+	ActorID      *uuid.UUID `db:"actor_id" json:"actor_id"`
+	ObjectType   string     `db:"object_type" json:"object_type"`
+	ObjectID     uuid.UUID  `db:"object_id" json:"object_id"`
+	ObjectUserID uuid.UUID  `db:"object_user_id" json:"object_user_id"`
+	Action       string     `db:"action" json:"action"`
+	Reason       string     `db:"reason" json:"reason"`
+}
+
+// ---
+// Structures to creating a new report.
+// ---
+
+// CreateNewAnswerReport struct to describe create a new report for the answer process.
+//  - Reason == spam | abuse | off_topic | other;
+type CreateNewAnswerReport struct {
+	AnswerID uuid.UUID `json:"answer_id" validate:"required,uuid"`
+	Reason   string    `json:"reason" validate:"required,oneof=spam abuse off_topic other"`
+	Comment  string    `json:"comment" validate:"lte=1024"`
+}
+
+// ---
+// Structures to moderating one answer.
+// ---
+
+// ModerateAnswer struct to describe moderator action for the given answer.
+//  - Action == hide | restore | delete | warn;
+type ModerateAnswer struct {
+	ID     uuid.UUID `json:"id" validate:"required,uuid"`
+	Action string    `json:"action" validate:"required,oneof=hide restore delete warn"`
+	Reason string    `json:"reason" validate:"required,lte=1024"`
+}
+
+// ---
+// Structures to overriding any object by staff.
+// ---
+
+// OverrideStatus struct to describe update status of any project, task or answer by moderator or admin.
+type OverrideStatus struct {
+	ID     uuid.UUID `json:"id" validate:"required,uuid"`
+	Status int       `json:"status" validate:"int"`
+	Reason string    `json:"reason" validate:"required,lte=1024"`
+}
+
+// OverrideDelete struct to describe delete any project, task or answer by admin.
+type OverrideDelete struct {
+	ID     uuid.UUID `json:"id" validate:"required,uuid"`
+	Reason string    `json:"reason" validate:"required,lte=1024"`
+}
+
+// ---
+// Structures to getting moderation queue.
+// ---
+
+// GetModerationQueueAnswers struct to describe answers list object in moderation queue.
+type GetModerationQueueAnswers struct {
+	ID           uuid.UUID          `db:"id" json:"id"`
+	CreatedAt    time.Time          `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time          `db:"updated_at" json:"updated_at"`
+	HiddenAt     *time.Time         `db:"hidden_at" json:"hidden_at"`
+	ProjectID    uuid.UUID          `db:"project_id" json:"project_id"`
+	TaskID       uuid.UUID          `db:"task_id" json:"task_id"`
+	Status       int                `db:"answer_status" json:"status"`
+	Attrs        AnswerAttrs        `db:"answer_attrs" json:"attrs"`
+	ReportsCount int                `db:"reports_count" json:"reports_count"`
+	Reasons      resultDistribution `db:"reasons" json:"reasons"`
+
+	// Fields for JOIN tables:
+	Author AuthorAttrs `db:"author" json:"author"`
+}
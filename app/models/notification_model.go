@@ -0,0 +1,73 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ---
+// Structures to describing notification model.
+// ---
+
+// Notification struct to describe one in-app notification of the user.
+//  - Type == new_answer | answer_status_changed | project_invitation;
+//  - ActorID == nil for automatic (system) notifications;
+type Notification struct {
+	ID         uuid.UUID           `db:"id" json:"id"`
+	CreatedAt  time.Time           `db:"created_at" json:"created_at"`
+	ReadAt     *time.Time          `db:"read_at" json:"read_at"`
+	UserID     uuid.UUID           `db:"user_id" json:"user_id"`
+	ActorID    *uuid.UUID          `db:"actor_id" json:"actor_id"`
+	Type       string              `db:"notification_type" json:"type"`
+	ObjectType string              `db:"object_type" json:"object_type"`
+	ObjectID   uuid.UUID           `db:"object_id" json:"object_id"`
+	ProjectID  uuid.UUID           `db:"project_id" json:"project_id"`
+	Payload    NotificationPayload `db:"payload" json:"payload"`
+}
+
+// NotificationPayload struct to describe additional data of the notification (like project title).
+type NotificationPayload map[string]interface{}
+
+// NotificationPreferences struct to describe toggles of the notification types in the user settings.
+// Notification type without toggle is enabled.
+type NotificationPreferences map[string]bool
+
+// ---
+// Structures to marking one notification as read.
+// ---
+
+// MarkNotificationRead struct to describe marking the given notification as read.
+type MarkNotificationRead struct {
+	ID uuid.UUID `json:"id" validate:"required,uuid"`
+}
+
+// ---
+// This methods checks preferences of the notifications.
+// ---
+
+// Enabled method for checking, if notifications of the given type are enabled.
+func (p NotificationPreferences) Enabled(notificationType string) bool {
+	enabled, ok := p[notificationType]
+	return !ok || enabled
+}
+
+// Value make the NotificationPayload struct implement the driver.Valuer interface.
+// This method simply returns the JSON-encoded representation of the struct.
+func (p NotificationPayload) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+// Scan make the NotificationPayload struct implement the sql.Scanner interface.
+// This method simply decodes a JSON-encoded value into the struct fields.
+func (p *NotificationPayload) Scan(value interface{}) error {
+	j, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(j, &p)
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ---
+// Structures to describing personal access token model.
+// ---
+
+// PersonalAccessToken struct to describe long-lived token of the user for automation (like CI pipelines).
+//  - TokenHash == SHA-256 of the token (token itself is returned only after creation);
+//  - TokenPrefix == first characters of the token to recognize it in the list;
+//  - Credentials == subset of the user credentials, allowed for the token;
+type PersonalAccessToken struct {
+	ID          uuid.UUID                      `db:"id" json:"id"`
+	CreatedAt   time.Time                      `db:"created_at" json:"created_at"`
+	UserID      uuid.UUID                      `db:"user_id" json:"user_id"`
+	Name        string                         `db:"name" json:"name"`
+	TokenHash   string                         `db:"token_hash" json:"-"`
+	TokenPrefix string                         `db:"token_prefix" json:"token_prefix"`
+	Credentials PersonalAccessTokenCredentials `db:"credentials" json:"credentials"`
+	ExpiresAt   time.Time                      `db:"expires_at" json:"expires_at"`
+	LastUsedAt  *time.Time                     `db:"last_used_at" json:"last_used_at"`
+	RevokedAt   *time.Time                     `db:"revoked_at" json:"revoked_at"`
+}
+
+// PersonalAccessTokenCredentials struct to describe list of the credentials of the personal access token.
+type PersonalAccessTokenCredentials []string
+
+// ---
+// Structures to creating a new personal access token.
+// ---
+
+// CreateNewPersonalAccessToken struct to describe create a new personal access token process.
+type CreateNewPersonalAccessToken struct {
+	Name          string   `json:"name" validate:"required,lte=255"`
+	Credentials   []string `json:"credentials" validate:"required,min=1,dive,required,lte=255"`
+	ExpiresInDays int      `json:"expires_in_days" validate:"required,min=1,max=365"`
+}
+
+// ---
+// Structures to revoking one personal access token.
+// ---
+
+// RevokePersonalAccessToken struct to describe revoke process of the given personal access token.
+type RevokePersonalAccessToken struct {
+	ID uuid.UUID `json:"id" validate:"required,uuid"`
+}
+
+// Value make the PersonalAccessTokenCredentials struct implement the driver.Valuer interface.
+// This method simply returns the JSON-encoded representation of the struct.
+func (p PersonalAccessTokenCredentials) Value() (driver.Value, error) {
+	if p == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(p)
+}
+
+// Scan make the PersonalAccessTokenCredentials struct implement the sql.Scanner interface.
+// This method simply decodes a JSON-encoded value into the struct fields.
+func (p *PersonalAccessTokenCredentials) Scan(value interface{}) error {
+	j, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(j, &p)
+}
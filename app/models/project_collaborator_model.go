@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ---
+// Structures to describing project collaborator model.
+// ---
+
+// ProjectCollaborator struct to describe membership of the user in the project.
+// Creator of the project is not saved as collaborator, but always has the owner role.
+//  - Role == owner | editor | viewer;
+//  - InvitedBy == nil, if user, who invited the collaborator, was deleted;
+type ProjectCollaborator struct {
+	ProjectID uuid.UUID  `db:"project_id" json:"project_id"`
+	UserID    uuid.UUID  `db:"user_id" json:"user_id"`
+	CreatedAt time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at" json:"updated_at"`
+	Role      string     `db:"role" json:"role"`
+	InvitedBy *uuid.UUID `db:"invited_by" json:"invited_by"`
+}
+
+// ---
+// Structures to inviting a new project collaborator.
+// ---
+
+// CreateNewProjectCollaborator struct to describe invite process of the user to the given project.
+type CreateNewProjectCollaborator struct {
+	ProjectID uuid.UUID `json:"project_id" validate:"required,uuid"`
+	UserID    uuid.UUID `json:"user_id" validate:"required,uuid"`
+	Role      string    `json:"role" validate:"required,oneof=owner editor viewer"`
+}
+
+// ---
+// Structures to updating one project collaborator.
+// ---
+
+// UpdateProjectCollaborator struct to describe update process of the role of the given collaborator.
+type UpdateProjectCollaborator struct {
+	ProjectID uuid.UUID `json:"project_id" validate:"required,uuid"`
+	UserID    uuid.UUID `json:"user_id" validate:"required,uuid"`
+	Role      string    `json:"role" validate:"required,oneof=owner editor viewer"`
+}
+
+// ---
+// Structures to removing one project collaborator.
+// ---
+
+// DeleteProjectCollaborator struct to describe remove process of the given collaborator from the project.
+type DeleteProjectCollaborator struct {
+	ProjectID uuid.UUID `json:"project_id" validate:"required,uuid"`
+	UserID    uuid.UUID `json:"user_id" validate:"required,uuid"`
+}
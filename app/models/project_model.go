@@ -25,12 +25,13 @@ type Project struct {
 
 // ProjectAttrs struct to describe project attributes.
 type ProjectAttrs struct {
-	Title       string   `json:"title" validate:"required,lte=255"`
-	Description string   `json:"description" validate:"required"`
-	Category    string   `json:"category" validate:"required"`
-	WebsiteURL  string   `json:"website_url"`
-	Picture     string   `json:"picture"`
-	Tags        []string `json:"tags"`
+	Title         string         `json:"title" validate:"required,lte=255"`
+	Description   string         `json:"description" validate:"required"`
+	Category      string         `json:"category" validate:"required"`
+	WebsiteURL    string         `json:"website_url"`
+	Picture       string         `json:"picture"`
+	Tags          []string       `json:"tags"`
+	LinksMetadata []LinkMetadata `json:"links_metadata"`
 }
 
 // ---
@@ -75,10 +76,14 @@ type GetProject struct {
 	Status    int          `db:"project_status" json:"status"`
 	Attrs     ProjectAttrs `db:"project_attrs" json:"attrs"`
 
+	// Denormalized counters:
+	TasksCount        int `db:"tasks_count" json:"tasks_count"`
+	AnswersCount      int `db:"answers_count" json:"answers_count"`
+	ContributorsCount int `db:"contributors_count" json:"contributors_count"`
+
 	// Fields for JOIN tables:
-	Author     AuthorAttrs  `db:"author" json:"author"`
-	TasksCount int          `db:"tasks_count" json:"tasks_count"`
-	Tasks      projectTasks `db:"tasks" json:"tasks"`
+	Author AuthorAttrs  `db:"author" json:"author"`
+	Tasks  projectTasks `db:"tasks" json:"tasks"`
 }
 
 // ---
@@ -92,9 +97,13 @@ type GetProjects struct {
 	UpdatedAt time.Time    `db:"updated_at" json:"updated_at"`
 	Attrs     ProjectAttrs `db:"project_attrs" json:"attrs"`
 
+	// Denormalized counters:
+	TasksCount        int `db:"tasks_count" json:"tasks_count"`
+	AnswersCount      int `db:"answers_count" json:"answers_count"`
+	ContributorsCount int `db:"contributors_count" json:"contributors_count"`
+
 	// Fields for JOIN tables:
-	Author     AuthorAttrs `db:"author" json:"author"`
-	TasksCount int         `db:"tasks_count" json:"tasks_count"`
+	Author AuthorAttrs `db:"author" json:"author"`
 }
 
 // ---
@@ -113,6 +122,23 @@ type getProjectTasks struct {
 	StepsCount  int       `json:"steps_count"`
 }
 
+// ---
+// This methods converts Markdown descriptions with the given func (sanitize, render, etc).
+// ---
+
+// ConvertDescriptions method for converting description of the project attributes.
+func (p *ProjectAttrs) ConvertDescriptions(convert func(string) string) {
+	p.Description = convert(p.Description)
+}
+
+// ConvertDescriptions method for converting all descriptions of the project with tasks.
+func (p *GetProject) ConvertDescriptions(convert func(string) string) {
+	p.Attrs.ConvertDescriptions(convert)
+	for _, task := range p.Tasks {
+		task.Description = convert(task.Description)
+	}
+}
+
 // ---
 // This methods simply returns the JSON-encoded representation of the struct.
 // ---
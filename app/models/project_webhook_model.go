@@ -0,0 +1,150 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ---
+// Structures to describing project webhook model.
+// ---
+
+// ProjectWebhook struct to describe endpoint of the integrator, registered on the project.
+//  - Events == list of the subscribed events (empty for all events);
+//  - Secret == key of the HMAC-SHA256 signature (returned only after creation);
+type ProjectWebhook struct {
+	ID        uuid.UUID            `db:"id" json:"id"`
+	CreatedAt time.Time            `db:"created_at" json:"created_at"`
+	UpdatedAt *time.Time           `db:"updated_at" json:"updated_at"`
+	ProjectID uuid.UUID            `db:"project_id" json:"project_id"`
+	UserID    uuid.UUID            `db:"user_id" json:"user_id"`
+	URL       string               `db:"url" json:"url"`
+	Secret    string               `db:"secret" json:"-"`
+	Events    ProjectWebhookEvents `db:"events" json:"events"`
+	Active    bool                 `db:"active" json:"active"`
+}
+
+// ProjectWebhookEvents struct to describe list of the events, subscribed by the project webhook.
+type ProjectWebhookEvents []string
+
+// ProjectWebhookDelivery struct to describe one delivery of the event to the project webhook
+// (item of the outbound queue and record of the delivery log).
+//  - Status == pending | sending | delivered | failed;
+//  - ResponseStatus == HTTP status of the last response (0, if endpoint was not reached);
+type ProjectWebhookDelivery struct {
+	ID             uuid.UUID                     `db:"id" json:"id"`
+	CreatedAt      time.Time                     `db:"created_at" json:"created_at"`
+	WebhookID      *uuid.UUID                    `db:"webhook_id" json:"webhook_id"`
+	ProjectID      uuid.UUID                     `db:"project_id" json:"project_id"`
+	URL            string                        `db:"url" json:"url"`
+	Secret         string                        `db:"secret" json:"-"`
+	EventType      string                        `db:"event_type" json:"event_type"`
+	Payload        ProjectWebhookDeliveryPayload `db:"payload" json:"payload"`
+	Status         string                        `db:"status" json:"status"`
+	Attempts       int                           `db:"attempts" json:"attempts"`
+	NextAttemptAt  time.Time                     `db:"next_attempt_at" json:"next_attempt_at"`
+	LockedAt       *time.Time                    `db:"locked_at" json:"locked_at"`
+	DeliveredAt    *time.Time                    `db:"delivered_at" json:"delivered_at"`
+	ResponseStatus int                           `db:"response_status" json:"response_status"`
+	ResponseBody   string                        `db:"response_body" json:"response_body"`
+	DurationMs     int                           `db:"duration_ms" json:"duration_ms"`
+	Error          string                        `db:"error" json:"error"`
+}
+
+// ProjectWebhookDeliveryPayload struct to describe JSON body, which is sent to the project webhook.
+type ProjectWebhookDeliveryPayload map[string]interface{}
+
+// ---
+// Structures to creating a new project webhook.
+// ---
+
+// CreateNewProjectWebhook struct to describe create a new webhook of the given project process.
+type CreateNewProjectWebhook struct {
+	ProjectID uuid.UUID `json:"project_id" validate:"required,uuid"`
+	URL       string    `json:"url" validate:"required,url,lte=2048"`
+	Events    []string  `json:"events" validate:"dive,oneof=project.updated project.deleted task.created task.updated task.deleted answer.created answer.updated answer.deleted"`
+}
+
+// ---
+// Structures to updating one project webhook.
+// ---
+
+// UpdateProjectWebhook struct to describe update process of the given project webhook.
+type UpdateProjectWebhook struct {
+	ID     uuid.UUID `json:"id" validate:"required,uuid"`
+	URL    string    `json:"url" validate:"required,url,lte=2048"`
+	Events []string  `json:"events" validate:"dive,oneof=project.updated project.deleted task.created task.updated task.deleted answer.created answer.updated answer.deleted"`
+	Active bool      `json:"active"`
+}
+
+// ---
+// Structures to deleting or pinging one project webhook.
+// ---
+
+// DeleteProjectWebhook struct to describe delete process of the given project webhook.
+type DeleteProjectWebhook struct {
+	ID uuid.UUID `json:"id" validate:"required,uuid"`
+}
+
+// PingProjectWebhook struct to describe sending of the test event to the given project webhook.
+type PingProjectWebhook struct {
+	ID uuid.UUID `json:"id" validate:"required,uuid"`
+}
+
+// ---
+// This methods checks subscriptions of the project webhook.
+// ---
+
+// Subscribed method for checking, if project webhook is subscribed to the given event.
+func (w *ProjectWebhook) Subscribed(eventType string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, event := range w.Events {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Value make the ProjectWebhookEvents struct implement the driver.Valuer interface.
+// This method simply returns the JSON-encoded representation of the struct.
+func (e ProjectWebhookEvents) Value() (driver.Value, error) {
+	if e == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(e)
+}
+
+// Scan make the ProjectWebhookEvents struct implement the sql.Scanner interface.
+// This method simply decodes a JSON-encoded value into the struct fields.
+func (e *ProjectWebhookEvents) Scan(value interface{}) error {
+	j, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(j, &e)
+}
+
+// Value make the ProjectWebhookDeliveryPayload struct implement the driver.Valuer interface.
+// This method simply returns the JSON-encoded representation of the struct.
+func (p ProjectWebhookDeliveryPayload) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+// Scan make the ProjectWebhookDeliveryPayload struct implement the sql.Scanner interface.
+// This method simply decodes a JSON-encoded value into the struct fields.
+func (p *ProjectWebhookDeliveryPayload) Scan(value interface{}) error {
+	j, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(j, &p)
+}
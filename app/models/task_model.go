@@ -26,12 +26,30 @@ type Task struct {
 
 // TaskAttrs struct to describe task attributes.
 type TaskAttrs struct {
-	Name        string     `json:"name" validate:"required,lte=255"`
-	Description string     `json:"description" validate:"required"`
-	Steps       []taskStep `json:"steps" validate:"required,dive"`
-	Documents   []string   `json:"documents"`
-	Images      []string   `json:"images"`
-	Links       []string   `json:"links"`
+	Name          string         `json:"name" validate:"required,lte=255"`
+	Description   string         `json:"description" validate:"required"`
+	Steps         []taskStep     `json:"steps" validate:"required,dive"`
+	Questions     []TaskQuestion `json:"questions" validate:"dive"`
+	Documents     []string       `json:"documents"`
+	Images        []string       `json:"images"`
+	Links         []string       `json:"links"`
+	LinksMetadata []LinkMetadata `json:"links_metadata"`
+}
+
+// TaskQuestion struct to describe one structured question of the task.
+//  - Type == rating | single_choice | multiple_choice | yes_no | short_text;
+//  - Options are used only for single_choice and multiple_choice types;
+//  - ScaleMin, ScaleMax are used only for rating type (default: 1...5);
+//  - MaxLength is used only for short_text type (default: 255);
+type TaskQuestion struct {
+	ID        string   `json:"id" validate:"required,lte=64"`
+	Type      string   `json:"type" validate:"required,oneof=rating single_choice multiple_choice yes_no short_text"`
+	Title     string   `json:"title" validate:"required,lte=255"`
+	Required  bool     `json:"required"`
+	Options   []string `json:"options"`
+	ScaleMin  int      `json:"scale_min"`
+	ScaleMax  int      `json:"scale_max"`
+	MaxLength int      `json:"max_length"`
 }
 
 // ---
@@ -79,8 +97,9 @@ type GetTask struct {
 	Status    int       `db:"task_status" json:"status"`
 	Attrs     TaskAttrs `db:"task_attrs" json:"attrs"`
 
-	// Fields for JOIN tables:
-	AnswersCount int `db:"answers_count" json:"answers_count"`
+	// Denormalized counters:
+	AnswersCount      int `db:"answers_count" json:"answers_count"`
+	ContributorsCount int `db:"contributors_count" json:"contributors_count"`
 }
 
 // ---
@@ -94,20 +113,55 @@ type GetTasks struct {
 	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
 	Attrs     TaskAttrs `db:"task_attrs" json:"attrs"`
 
-	// Fields for JOIN tables:
-	AnswersCount int `db:"answers_count" json:"answers_count"`
+	// Denormalized counters:
+	AnswersCount      int `db:"answers_count" json:"answers_count"`
+	ContributorsCount int `db:"contributors_count" json:"contributors_count"`
+}
+
+// GetDraftTasks struct to describe getting list of not active tasks of the project (for collaborators).
+type GetDraftTasks struct {
+	GetTasks
+	TaskStatus int `db:"task_status" json:"task_status"`
+}
+
+// ---
+// Structures to getting aggregated results of the task questions.
+// ---
+
+// GetTaskQuestionResult struct to describe aggregated results for one task question.
+type GetTaskQuestionResult struct {
+	QuestionID     string             `db:"question_id" json:"question_id"`
+	QuestionType   string             `db:"question_type" json:"question_type"`
+	ResponsesCount int                `db:"responses_count" json:"responses_count"`
+	Average        *float64           `db:"average" json:"average"`
+	Distribution   resultDistribution `db:"distribution" json:"distribution"`
 }
 
 // ---
 // Private structures to building better model JSON output.
 // ---
 
+// resultDistribution (private) map to describe count of responses for each value.
+type resultDistribution map[string]int
+
 // taskStep (private) struct to describe step of given task.
 type taskStep struct {
 	Position    int    `json:"position" validate:"required,int"`
 	Description string `json:"description" validate:"required"`
 }
 
+// ---
+// This methods converts Markdown descriptions with the given func (sanitize, render, etc).
+// ---
+
+// ConvertDescriptions method for converting descriptions of the task attributes and steps.
+func (t *TaskAttrs) ConvertDescriptions(convert func(string) string) {
+	t.Description = convert(t.Description)
+	for i := range t.Steps {
+		t.Steps[i].Description = convert(t.Steps[i].Description)
+	}
+}
+
 // ---
 // This methods simply returns the JSON-encoded representation of the struct.
 // ---
@@ -129,3 +183,12 @@ func (t *TaskAttrs) Scan(value interface{}) error {
 	}
 	return json.Unmarshal(j, &t)
 }
+
+// Scan make the resultDistribution (private) map implement the sql.Scanner interface.
+func (r *resultDistribution) Scan(value interface{}) error {
+	j, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(j, &r)
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ---
+// Structures to describing token revocation model.
+// ---
+
+// RevokedToken struct to describe one revoked JWT (by "jti" claim), kept until the token is expired.
+type RevokedToken struct {
+	JTI       string    `db:"jti" json:"jti"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UserID    uuid.UUID `db:"user_id" json:"user_id"`
+	ExpiresAt time.Time `db:"expires_at" json:"expires_at"`
+}
+
+// UserTokenRevocation struct to describe revocation of all tokens of the user,
+// issued before the given time (JWT and personal access tokens).
+//  - RevokedBy == nil, if tokens were revoked by the user;
+type UserTokenRevocation struct {
+	UserID             uuid.UUID  `db:"user_id" json:"user_id"`
+	TokensIssuedBefore time.Time  `db:"tokens_issued_before" json:"tokens_issued_before"`
+	RevokedBy          *uuid.UUID `db:"revoked_by" json:"revoked_by"`
+}
+
+// ---
+// Structures to revoking all tokens of the user.
+// ---
+
+// RevokeUserTokens struct to describe revoke process of all tokens of the given user.
+type RevokeUserTokens struct {
+	UserID uuid.UUID `json:"user_id" validate:"required,uuid"`
+}
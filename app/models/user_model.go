@@ -1,9 +1,11 @@
 package models
 
 import (
+	"Komentory/api/pkg/repository"
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -28,7 +30,9 @@ type UserAttrs struct {
 
 // UserSettings struct to describe user settings.
 type UserSettings struct {
-	EmailSubscriptions EmailSubscriptions `json:"email_subscriptions"`
+	EmailSubscriptions       EmailSubscriptions                 `json:"email_subscriptions"`
+	EmailSubscriptionChanges map[string]EmailSubscriptionChange `json:"email_subscription_changes,omitempty"`
+	Notifications            NotificationPreferences            `json:"notifications,omitempty"`
 }
 
 // EmailSubscriptions struct to describe user email subscriptions.
@@ -37,6 +41,74 @@ type EmailSubscriptions struct {
 	Marketing     bool `json:"marketing"`     // like "invite friends and get X"
 }
 
+// EmailSubscriptionChange struct to describe the last change of the email stream subscription by email provider.
+type EmailSubscriptionChange struct {
+	ChangedAt         time.Time `json:"changed_at"`
+	SuppressionReason string    `json:"suppression_reason"`
+}
+
+// ---
+// Structures to updating profile of the current user.
+// ---
+
+// UpdateUserProfile struct to describe update process of the current user's profile.
+//  - nil value of the field means "keep as is";
+//  - Picture must be a file, uploaded to CDN by this user (or empty to remove it);
+type UpdateUserProfile struct {
+	FirstName *string   `json:"first_name" validate:"omitempty,min=1,lte=255"`
+	LastName  *string   `json:"last_name" validate:"omitempty,lte=255"`
+	Picture   *string   `json:"picture"`
+	Abilities *[]string `json:"abilities" validate:"omitempty,lte=20,dive,required,lte=64"`
+}
+
+// Attrs method for getting only given fields of the profile (for jsonb merge with user attributes).
+func (u *UpdateUserProfile) Attrs() map[string]interface{} {
+	attrs := map[string]interface{}{}
+	if u.FirstName != nil {
+		attrs["first_name"] = *u.FirstName
+	}
+	if u.LastName != nil {
+		attrs["last_name"] = *u.LastName
+	}
+	if u.Picture != nil {
+		attrs["picture"] = *u.Picture
+	}
+	if u.Abilities != nil {
+		attrs["abilities"] = *u.Abilities
+	}
+	return attrs
+}
+
+// ---
+// Structures to updating settings of the current user.
+// ---
+
+// UpdateUserSettings struct to describe update process of the current user's settings.
+//  - nil value of the subscription means "keep as is";
+//  - Notifications has toggles only for the changed notification types;
+type UpdateUserSettings struct {
+	EmailSubscriptions struct {
+		Transactional *bool `json:"transactional"`
+		Marketing     *bool `json:"marketing"`
+	} `json:"email_subscriptions"`
+	Notifications map[string]bool `json:"notifications" validate:"dive,keys,oneof=new_answer answer_status_changed project_invitation,endkeys"`
+}
+
+// ---
+// Structures to getting public profile of the user.
+// ---
+
+// GetUser struct to describe getting public profile of the user.
+type GetUser struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	Attrs     UserAttrs `db:"user_attrs" json:"attrs"`
+
+	// Fields for JOIN tables:
+	ProjectsCount int `db:"projects_count" json:"projects_count"`
+	AnswersCount  int `db:"answers_count" json:"answers_count"`
+}
+
 // ---
 // Public structures to building better model JSON output.
 // ---
@@ -49,6 +121,24 @@ type AuthorAttrs struct {
 	Picture   string    `json:"picture"`
 }
 
+// ---
+// This methods changes subscriptions of the given email stream.
+// ---
+
+// SetStream method for subscribe (or unsubscribe) to the given email stream (transactional, marketing).
+// Returns false, if stream is unknown.
+func (e *EmailSubscriptions) SetStream(stream string, subscribed bool) bool {
+	switch stream {
+	case repository.EmailStreamTransactional:
+		e.Transactional = subscribed
+	case repository.EmailStreamMarketing:
+		e.Marketing = subscribed
+	default:
+		return false
+	}
+	return true
+}
+
 // ---
 // This methods simply returns the JSON-encoded representation of the struct.
 // ---
@@ -58,6 +148,11 @@ func (u UserAttrs) Value() (driver.Value, error) {
 	return json.Marshal(u)
 }
 
+// Value make the UserSettings struct implement the driver.Valuer interface.
+func (u UserSettings) Value() (driver.Value, error) {
+	return json.Marshal(u)
+}
+
 // ---
 // This methods simply decodes a JSON-encoded value into the struct fields.
 // ---
@@ -72,6 +167,16 @@ func (u *UserAttrs) Scan(value interface{}) error {
 	return json.Unmarshal(j, &u)
 }
 
+// Scan make the UserSettings struct implement the sql.Scanner interface.
+func (u *UserSettings) Scan(value interface{}) error {
+	j, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(j, &u)
+}
+
 // Scan make the authorAttrs (private) struct implement the sql.Scanner interface.
 func (t *AuthorAttrs) Scan(value interface{}) error {
 	j, ok := value.([]byte)
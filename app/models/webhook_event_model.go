@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ---
+// Structures to describing webhook event model.
+// ---
+
+// WebhookEvent struct to describe one received (inbound) webhook event.
+//  - EventKey == vendor event ID or SHA-256 hash of the raw body (for duplicates detection);
+//  - Status == pending | processing | processed | failed;
+type WebhookEvent struct {
+	ID          uuid.UUID  `db:"id" json:"id"`
+	ReceivedAt  time.Time  `db:"received_at" json:"received_at"`
+	Vendor      string     `db:"vendor" json:"vendor"`
+	EventType   string     `db:"event_type" json:"event_type"`
+	EventKey    string     `db:"event_key" json:"event_key"`
+	RawBody     string     `db:"raw_body" json:"raw_body"`
+	Status      string     `db:"status" json:"status"`
+	Attempts    int        `db:"attempts" json:"attempts"`
+	LockedAt    *time.Time `db:"locked_at" json:"locked_at"`
+	ProcessedAt *time.Time `db:"processed_at" json:"processed_at"`
+	Error       string     `db:"error" json:"error"`
+}
+
+// ---
+// Structures to replaying one webhook event.
+// ---
+
+// ReplayWebhookEvent struct to describe replay process of the stored webhook event.
+type ReplayWebhookEvent struct {
+	ID uuid.UUID `json:"id" validate:"required,uuid"`
+}
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // ---
 // Structures to describing webhook model.
 // ---
@@ -7,8 +9,43 @@ package models
 // PostmarkSuppressSendingWebhook struct to describe Postmark suppress sending webhook object.
 //  - Recipient == subscriber email address;
 //  - SuppressSending == true (deactivate) | false (reactivate);
+//  - MessageStream == ID of the Postmark message stream (like "outbound", "broadcast");
+//  - SuppressionReason == HardBounce | SpamComplaint | ManualSuppression (empty for reactivation);
+//  - ChangedAt == time of the subscription change (used to ignore out-of-order events);
 // See: https://postmarkapp.com/developer/webhooks/subscription-change-webhook#subscription-change-webhook-data
 type PostmarkSuppressSendingWebhook struct {
-	Recipient       string `json:"Recipient" required:"required,email"`
-	SuppressSending bool   `json:"SuppressSending"`
+	Recipient         string    `json:"Recipient" validate:"required,email"`
+	SuppressSending   bool      `json:"SuppressSending"`
+	MessageStream     string    `json:"MessageStream" validate:"required"`
+	SuppressionReason string    `json:"SuppressionReason"`
+	ChangedAt         time.Time `json:"ChangedAt"`
+}
+
+// PostmarkBounceWebhook struct to describe Postmark bounce and spam complaint webhook object.
+//  - Type == HardBounce | SoftBounce | Transient | SpamComplaint | ... (see Postmark bounce types);
+//  - Inactive == true, if Postmark deactivated the email address;
+// See: https://postmarkapp.com/developer/webhooks/bounce-webhook
+// See: https://postmarkapp.com/developer/webhooks/spam-complaint-webhook
+type PostmarkBounceWebhook struct {
+	ID            int64     `json:"ID"`
+	RecordType    string    `json:"RecordType"`
+	Type          string    `json:"Type" validate:"required"`
+	Email         string    `json:"Email" validate:"required,email"`
+	MessageStream string    `json:"MessageStream"`
+	Inactive      bool      `json:"Inactive"`
+	BouncedAt     time.Time `json:"BouncedAt"`
+}
+
+// PostmarkDeliveryWebhook struct to describe Postmark delivery and open webhook object.
+//  - DeliveredAt is set for the delivery webhook;
+//  - ReceivedAt is set for the open webhook;
+// See: https://postmarkapp.com/developer/webhooks/delivery-webhook
+// See: https://postmarkapp.com/developer/webhooks/open-tracking-webhook
+type PostmarkDeliveryWebhook struct {
+	RecordType    string    `json:"RecordType"`
+	MessageID     string    `json:"MessageID"`
+	Recipient     string    `json:"Recipient" validate:"required,email"`
+	MessageStream string    `json:"MessageStream"`
+	DeliveredAt   time.Time `json:"DeliveredAt"`
+	ReceivedAt    time.Time `json:"ReceivedAt"`
 }
@@ -2,6 +2,7 @@ package queries
 
 import (
 	"Komentory/api/app/models"
+	"Komentory/api/pkg/repository"
 	"Komentory/api/platform/embed_files"
 	"database/sql"
 	"time"
@@ -27,7 +28,9 @@ func (q *AnswerQueries) FindAnswerByID(id uuid.UUID) (models.Answer, int, error)
 		id,
 		user_id,
 		project_id,
-		task_id
+		task_id,
+		answer_status,
+		hidden_at
 	FROM
 		answers
 	WHERE
@@ -52,8 +55,20 @@ func (q *AnswerQueries) FindAnswerByID(id uuid.UUID) (models.Answer, int, error)
 	}
 }
 
-// CreateAnswer method for creating answer by given Answer object.
+// CreateAnswer method for creating answer by given Answer object, updating counters of the task and project
+// and writing domain event to the outbox.
 func (q *AnswerQueries) CreateNewAnswer(a *models.Answer) error {
+	// Begin a new transaction.
+	tx, err := q.Beginx()
+	if err != nil {
+		return err
+	}
+
+	// Rollback transaction, if it was not committed.
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
 	// Define query string.
 	query := `
 	INSERT INTO answers 
@@ -65,25 +80,54 @@ func (q *AnswerQueries) CreateNewAnswer(a *models.Answer) error {
 	`
 
 	// Send query to database.
-	_, err := q.Exec(
+	if _, err := tx.Exec(
 		query,
 		a.ID, a.CreatedAt, a.UpdatedAt,
 		a.UserID, a.ProjectID, a.TaskID,
 		a.AnswerStatus, a.AnswerAttrs,
-	)
-	if err != nil {
+	); err != nil {
+		// Return only error.
+		return err
+	}
+
+	// Increment answers and contributors counters.
+	if err := updateAnswerCounters(tx, a, 1); err != nil {
 		// Return only error.
 		return err
 	}
 
-	// This query returns nothing.
-	return nil
+	// Write domain event to the outbox.
+	if err := createDomainEvent(
+		tx, repository.DomainAggregateAnswer, a.ID, a.ProjectID, repository.DomainEventCreated,
+		models.DomainEventPayload{"task_id": a.TaskID, "status": a.AnswerStatus},
+	); err != nil {
+		// Return only error.
+		return err
+	}
+
+	// Commit transaction.
+	return tx.Commit()
 }
 
-// UpdateAnswer method for updating answer by given Answer object.
+// UpdateAnswer method for updating answer by given Answer object and writing domain events
+// (updated and status changed, if status was changed) to the outbox.
 func (q *AnswerQueries) UpdateAnswer(answer_id uuid.UUID, a *models.UpdateAnswer) error {
+	// Begin a new transaction.
+	tx, err := q.Beginx()
+	if err != nil {
+		return err
+	}
+
+	// Rollback transaction, if it was not committed.
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
 	// Define query string.
 	query := `
+	WITH previous AS (
+		SELECT answer_status FROM answers WHERE id = $1::uuid FOR UPDATE
+	)
 	UPDATE
 		answers
 	SET
@@ -92,36 +136,141 @@ func (q *AnswerQueries) UpdateAnswer(answer_id uuid.UUID, a *models.UpdateAnswer
 		answer_attrs = $4::jsonb
 	WHERE
 		id = $1::uuid
+	RETURNING project_id, (SELECT answer_status FROM previous)
 	`
 
 	// Send query to database.
-	_, err := q.Exec(query, answer_id, time.Now(), a.AnswerStatus, a.AnswerAttrs)
-	if err != nil {
+	var projectID uuid.UUID
+	previousStatus := 0
+	if err := tx.QueryRowx(query, answer_id, time.Now(), a.AnswerStatus, a.AnswerAttrs).Scan(&projectID, &previousStatus); err != nil {
 		// Return only error.
 		return err
 	}
 
-	// This query returns nothing.
-	return nil
+	// Write domain events to the outbox.
+	if err := createStatusDomainEvents(
+		tx, repository.DomainAggregateAnswer, answer_id, projectID, previousStatus, a.AnswerStatus,
+	); err != nil {
+		// Return only error.
+		return err
+	}
+
+	// Commit transaction.
+	return tx.Commit()
 }
 
-// DeleteAnswer method for delete task by given ID.
+// UpdateAnswerTriage method for creating or updating triage of the answer by given ID
+// and writing domain event to the outbox, if workflow state of the answer was changed.
+// Returns previous workflow state of the answer (new, if answer was not triaged).
+func (q *AnswerQueries) UpdateAnswerTriage(project_id uuid.UUID, a *models.UpdateAnswerTriage) (string, error) {
+	// Begin a new transaction.
+	tx, err := q.Beginx()
+	if err != nil {
+		return "", err
+	}
+
+	// Rollback transaction, if it was not committed.
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// Define previous state variable.
+	previousState := ""
+
+	// Define query string.
+	query := `
+	WITH previous AS (
+		SELECT state FROM answer_triages WHERE answer_id = $1::uuid
+	)
+	INSERT INTO answer_triages (answer_id, project_id, updated_at, label, state, note)
+	VALUES (
+		$1::uuid, $2::uuid, $3::timestamp,
+		$4::varchar, $5::varchar, $6::text
+	)
+	ON CONFLICT (answer_id) DO UPDATE
+	SET
+		updated_at = EXCLUDED.updated_at,
+		label = EXCLUDED.label,
+		state = EXCLUDED.state,
+		note = EXCLUDED.note
+	RETURNING COALESCE((SELECT state FROM previous), $7::varchar)
+	`
+
+	// Send query to database.
+	if err := tx.Get(&previousState, query, a.ID, project_id, time.Now(), a.Label, a.State, a.Note, repository.AnswerStateNew); err != nil {
+		// Return only error.
+		return "", err
+	}
+
+	// Write domain event to the outbox, if workflow state was changed.
+	if previousState != a.State {
+		if err := createDomainEvent(
+			tx, repository.DomainAggregateAnswer, a.ID, project_id, repository.DomainEventStatusChanged,
+			models.DomainEventPayload{"previous_state": previousState, "state": a.State},
+		); err != nil {
+			// Return only error.
+			return "", err
+		}
+	}
+
+	// Commit transaction.
+	return previousState, tx.Commit()
+}
+
+// DeleteAnswer method for delete task by given ID, updating counters of the task and project
+// and writing domain event to the outbox.
 func (q *AnswerQueries) DeleteAnswer(answer_id uuid.UUID) error {
+	// Begin a new transaction.
+	tx, err := q.Beginx()
+	if err != nil {
+		return err
+	}
+
+	// Rollback transaction, if it was not committed.
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// Delete answer and decrement answers and contributors counters.
+	if err := deleteAnswer(tx, answer_id); err != nil {
+		// Return only error.
+		return err
+	}
+
+	// Commit transaction.
+	return tx.Commit()
+}
+
+// deleteAnswer (private) func for delete answer by given ID, updating counters and writing domain event
+// to the outbox in the given transaction.
+func deleteAnswer(tx *sqlx.Tx, answer_id uuid.UUID) error {
+	// Define answer variable.
+	answer := models.Answer{}
+
 	// Define query string.
 	query := `
 	DELETE FROM answers
 	WHERE id = $1::uuid
+	RETURNING id, user_id, project_id, task_id
 	`
 
 	// Send query to database.
-	_, err := q.Exec(query, answer_id)
-	if err != nil {
+	if err := tx.Get(&answer, query, answer_id); err != nil {
+		// Return only error.
+		return err
+	}
+
+	// Decrement answers and contributors counters.
+	if err := updateAnswerCounters(tx, &answer, -1); err != nil {
 		// Return only error.
 		return err
 	}
 
-	// This query returns nothing.
-	return nil
+	// Write domain event to the outbox.
+	return createDomainEvent(
+		tx, repository.DomainAggregateAnswer, answer.ID, answer.ProjectID, repository.DomainEventDeleted,
+		models.DomainEventPayload{"task_id": answer.TaskID},
+	)
 }
 
 // GetAnswerByID method for getting one answer by given ID.
@@ -198,3 +347,28 @@ func (q *AnswerQueries) GetAnswersByProjectID(project_id uuid.UUID) ([]models.Ge
 		return answers, fiber.StatusBadRequest, err
 	}
 }
+
+// GetAnswersByProjectIDWithTriage method for getting all answers with triage for given project.
+func (q *AnswerQueries) GetAnswersByProjectIDWithTriage(project_id uuid.UUID, label, state string) ([]models.GetAnswersWithTriage, int, error) {
+	// Define answers variable.
+	answers := []models.GetAnswersWithTriage{}
+
+	// Define query string.
+	query := embed_files.SQLQueryGetManyAnswersByProjectIDWithTriage
+
+	// Send query to database.
+	err := q.Select(&answers, query, project_id, label, state)
+
+	// Get query result.
+	switch err {
+	case nil:
+		// Return object and 200 OK.
+		return answers, fiber.StatusOK, nil
+	case sql.ErrNoRows:
+		// Return empty object and 404 error.
+		return answers, fiber.StatusNotFound, err
+	default:
+		// Return empty object and 400 error.
+		return answers, fiber.StatusBadRequest, err
+	}
+}
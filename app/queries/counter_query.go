@@ -0,0 +1,180 @@
+package queries
+
+import (
+	"Komentory/api/app/models"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// CounterQueries struct for queries of the denormalized counters (tasks_count, answers_count, contributors_count).
+type CounterQueries struct {
+	*sqlx.DB
+}
+
+// RepairCounters method for recomputing all counters of projects and tasks.
+// Returns count of projects and tasks with fixed counters.
+func (q *CounterQueries) RepairCounters() (int64, error) {
+	// Begin a new transaction.
+	tx, err := q.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	// Rollback transaction, if it was not committed.
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// Define query strings.
+	// Update only rows with wrong counters.
+	queries := []string{
+		`
+		UPDATE
+			tasks AS t
+		SET
+			answers_count = c.answers_count,
+			contributors_count = c.contributors_count
+		FROM (
+			SELECT
+				t.id,
+				COUNT(a.id) AS answers_count,
+				COUNT(DISTINCT a.user_id) AS contributors_count
+			FROM
+				tasks AS t
+				LEFT JOIN answers AS a ON a.task_id = t.id
+			GROUP BY
+				t.id
+		) AS c
+		WHERE
+			c.id = t.id
+			AND (t.answers_count <> c.answers_count OR t.contributors_count <> c.contributors_count)
+		`,
+		`
+		UPDATE
+			projects AS p
+		SET
+			tasks_count = c.tasks_count,
+			answers_count = c.answers_count,
+			contributors_count = c.contributors_count
+		FROM (
+			SELECT
+				p.id,
+				(SELECT COUNT(*) FROM tasks WHERE project_id = p.id) AS tasks_count,
+				(SELECT COUNT(*) FROM answers WHERE project_id = p.id) AS answers_count,
+				(SELECT COUNT(DISTINCT user_id) FROM answers WHERE project_id = p.id) AS contributors_count
+			FROM
+				projects AS p
+		) AS c
+		WHERE
+			c.id = p.id
+			AND (
+				p.tasks_count <> c.tasks_count
+				OR p.answers_count <> c.answers_count
+				OR p.contributors_count <> c.contributors_count
+			)
+		`,
+	}
+
+	// Define count of the repaired rows.
+	var repairedCount int64
+
+	for _, query := range queries {
+		// Send query to database.
+		result, err := tx.Exec(query)
+		if err != nil {
+			// Return only error.
+			return 0, err
+		}
+
+		// Count repaired rows.
+		rowsCount, err := result.RowsAffected()
+		if err != nil {
+			// Return only error.
+			return 0, err
+		}
+		repairedCount += rowsCount
+	}
+
+	// Commit transaction.
+	return repairedCount, tx.Commit()
+}
+
+// updateTaskCounters (private) func for changing tasks counter of the project in the given transaction.
+// After delete of the task with answers, answers and contributors counters of the project are fixed too.
+func updateTaskCounters(tx *sqlx.Tx, projectID uuid.UUID, delta, deletedAnswersCount int) error {
+	// Define query string.
+	query := `
+	UPDATE
+		projects
+	SET
+		tasks_count = tasks_count + $2::int,
+		answers_count = answers_count - $3::int,
+		contributors_count = CASE
+			WHEN $3::int > 0 THEN (SELECT COUNT(DISTINCT user_id) FROM answers WHERE project_id = $1::uuid)
+			ELSE contributors_count
+		END
+	WHERE
+		id = $1::uuid
+	`
+
+	// Send query to database.
+	_, err := tx.Exec(query, projectID, delta, deletedAnswersCount)
+
+	return err
+}
+
+// updateAnswerCounters (private) func for changing answers and contributors counters
+// of the task and project in the given transaction (after insert or delete of the answer).
+// Contributors counter is changed only if it is the first (or the last) answer of the user.
+// Concurrent answers of the same user in the project are serialized by advisory lock (until end
+// of the transaction), so each of them sees the other committed answers.
+func updateAnswerCounters(tx *sqlx.Tx, a *models.Answer, delta int) error {
+	// Lock answers of the user in the project.
+	if _, err := tx.Exec(
+		`SELECT pg_advisory_xact_lock(hashtext('answer_counters:' || $1::text || ':' || $2::text))`,
+		a.ProjectID, a.UserID,
+	); err != nil {
+		return err
+	}
+
+	// Define query strings.
+	queries := []string{
+		`
+		UPDATE
+			tasks
+		SET
+			answers_count = answers_count + $3::int,
+			contributors_count = contributors_count + CASE
+				WHEN EXISTS (
+					SELECT 1 FROM answers WHERE task_id = $1::uuid AND user_id = $2::uuid AND id <> $4::uuid
+				) THEN 0
+				ELSE $3::int
+			END
+		WHERE
+			id = $1::uuid
+		`,
+		`
+		UPDATE
+			projects
+		SET
+			answers_count = answers_count + $3::int,
+			contributors_count = contributors_count + CASE
+				WHEN EXISTS (
+					SELECT 1 FROM answers WHERE project_id = $1::uuid AND user_id = $2::uuid AND id <> $4::uuid
+				) THEN 0
+				ELSE $3::int
+			END
+		WHERE
+			id = $1::uuid
+		`,
+	}
+
+	// Send queries to database.
+	if _, err := tx.Exec(queries[0], a.TaskID, a.UserID, delta, a.ID); err != nil {
+		return err
+	}
+	_, err := tx.Exec(queries[1], a.ProjectID, a.UserID, delta, a.ID)
+
+	return err
+}
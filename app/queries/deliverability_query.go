@@ -0,0 +1,131 @@
+package queries
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/repository"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// DeliverabilityQueries struct for queries from EmailDeliverability model.
+type DeliverabilityQueries struct {
+	*sqlx.DB
+}
+
+// GetEmailDeliverabilityByUserID method for getting deliverability state of the user's email address.
+func (q *DeliverabilityQueries) GetEmailDeliverabilityByUserID(user_id uuid.UUID) (models.EmailDeliverability, int, error) {
+	// Define deliverability variable.
+	deliverability := models.EmailDeliverability{}
+
+	// Define query string.
+	query := `
+	SELECT
+		*
+	FROM
+		email_deliverabilities
+	WHERE
+		user_id = $1::uuid
+	LIMIT 1
+	`
+
+	// Send query to database.
+	err := q.Get(&deliverability, query, user_id)
+
+	// Get query result.
+	switch err {
+	case nil:
+		// Return object and 200 OK.
+		return deliverability, fiber.StatusOK, nil
+	case sql.ErrNoRows:
+		// Return empty object and 404 error.
+		return deliverability, fiber.StatusNotFound, err
+	default:
+		// Return empty object and 400 error.
+		return deliverability, fiber.StatusBadRequest, err
+	}
+}
+
+// UpdateEmailDeliverability method for saving email event (bounce, complaint, delivery, open) to
+// deliverability state of the user's email address.
+// Timestamps of the first bounce and complaint and of the last delivery and open are kept (for out-of-order events).
+func (q *DeliverabilityQueries) UpdateEmailDeliverability(user_id uuid.UUID, event, bounceType string, eventAt time.Time) error {
+	// Define column and its change for the given event.
+	var column, set string
+	switch event {
+	case repository.EmailEventHardBounce:
+		column = "hard_bounced_at"
+		set = "hard_bounced_at = COALESCE(d.hard_bounced_at, EXCLUDED.hard_bounced_at), bounce_type = EXCLUDED.bounce_type"
+	case repository.EmailEventSoftBounce:
+		// Soft bounce never downgrades type of the hard bounce.
+		set = "bounce_type = CASE WHEN d.hard_bounced_at IS NULL THEN EXCLUDED.bounce_type ELSE d.bounce_type END"
+	case repository.EmailEventSpamComplaint:
+		column = "complained_at"
+		set = "complained_at = COALESCE(d.complained_at, EXCLUDED.complained_at)"
+	case repository.EmailEventDelivery:
+		column = "last_delivered_at"
+		set = "last_delivered_at = GREATEST(d.last_delivered_at, EXCLUDED.last_delivered_at)"
+	case repository.EmailEventOpen:
+		column = "last_opened_at"
+		set = "last_opened_at = GREATEST(d.last_opened_at, EXCLUDED.last_opened_at)"
+	default:
+		return fmt.Errorf("wrong email event (%s)", event)
+	}
+
+	// Define extra column of the event for the insert.
+	columns, values := "user_id, updated_at, bounce_type", "$1::uuid, $2::timestamp, $3::varchar"
+	if column != "" {
+		columns, values = columns+", "+column, values+", $2::timestamp"
+	}
+
+	// Define query string.
+	query := fmt.Sprintf(`
+	INSERT INTO email_deliverabilities AS d (%s)
+	VALUES (%s)
+	ON CONFLICT (user_id) DO UPDATE
+	SET
+		updated_at = GREATEST(d.updated_at, EXCLUDED.updated_at),
+		%s
+	`, columns, values, set)
+
+	// Send query to database.
+	_, err := q.Exec(query, user_id, eventAt, bounceType)
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
+
+// ResetEmailDeliverability method for clearing hard bounce and spam complaint of the user's email address
+// (after reactivation by email provider). Bounce and complaint after the reactivation time are kept.
+func (q *DeliverabilityQueries) ResetEmailDeliverability(user_id uuid.UUID, reactivatedAt time.Time) error {
+	// Define query string.
+	query := `
+	UPDATE
+		email_deliverabilities
+	SET
+		updated_at = $2::timestamp,
+		bounce_type = CASE WHEN hard_bounced_at <= $3::timestamp THEN '' ELSE bounce_type END,
+		hard_bounced_at = CASE WHEN hard_bounced_at <= $3::timestamp THEN NULL ELSE hard_bounced_at END,
+		complained_at = CASE WHEN complained_at <= $3::timestamp THEN NULL ELSE complained_at END
+	WHERE
+		user_id = $1::uuid
+	`
+
+	// Send query to database.
+	_, err := q.Exec(query, user_id, time.Now(), reactivatedAt)
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
@@ -0,0 +1,54 @@
+package queries
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/platform/embed_files"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// DigestQueries struct for queries from WeeklyDigest model.
+type DigestQueries struct {
+	*sqlx.DB
+}
+
+// GetWeeklyDigestProjects method for getting projects with new answers for the weekly digests of their owners.
+// Period of the digest starts at the end of the previous one (or at firstPeriodStart) and ends at periodEnd.
+// Owners, whose previous digest period ended after lastDigestBefore, are skipped.
+func (q *DigestQueries) GetWeeklyDigestProjects(periodEnd, lastDigestBefore, firstPeriodStart time.Time) ([]models.WeeklyDigestProject, error) {
+	// Define projects variable.
+	projects := []models.WeeklyDigestProject{}
+
+	// Define query string.
+	query := embed_files.SQLQueryGetWeeklyDigestProjects
+
+	// Send query to database.
+	err := q.Select(&projects, query, periodEnd, lastDigestBefore, firstPeriodStart)
+
+	return projects, err
+}
+
+// UpdateWeeklyDigest method for saving end of the period of the last sent digest for the user.
+func (q *DigestQueries) UpdateWeeklyDigest(user_id uuid.UUID, periodEnd time.Time) error {
+	// Define query string.
+	query := `
+	INSERT INTO weekly_digests (user_id, sent_at, period_end)
+	VALUES ($1::uuid, $2::timestamp, $3::timestamp)
+	ON CONFLICT (user_id) DO UPDATE
+	SET
+		sent_at = EXCLUDED.sent_at,
+		period_end = EXCLUDED.period_end
+	`
+
+	// Send query to database.
+	_, err := q.Exec(query, user_id, time.Now(), periodEnd)
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
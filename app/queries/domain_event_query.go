@@ -0,0 +1,167 @@
+package queries
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// DomainEventQueries struct for queries from DomainEvent model.
+type DomainEventQueries struct {
+	*sqlx.DB
+}
+
+// ClaimPendingDomainEvents method for locking not dispatched events with due attempt (and events,
+// locked longer than the given timeout) for dispatching by the current API instance.
+// Events are claimed in order of their creation.
+func (q *DomainEventQueries) ClaimPendingDomainEvents(limit int, lockTimeout time.Duration) ([]models.DomainEvent, error) {
+	// Define events variable.
+	events := []models.DomainEvent{}
+
+	// Define query string.
+	query := `
+	WITH claimed AS (
+		UPDATE
+			domain_events
+		SET
+			attempts = attempts + 1,
+			locked_at = $1::timestamp
+		WHERE
+			id IN (
+				SELECT id
+				FROM domain_events
+				WHERE
+					dispatched_at IS NULL
+					AND next_attempt_at <= $1::timestamp
+					AND (locked_at IS NULL OR locked_at < $2::timestamp)
+				ORDER BY created_at
+				LIMIT $3::int
+				FOR UPDATE SKIP LOCKED
+			)
+		RETURNING *
+	)
+	SELECT * FROM claimed ORDER BY created_at
+	`
+
+	// Define current time.
+	now := time.Now()
+
+	// Send query to database.
+	err := q.Select(&events, query, now, now.Add(-lockTimeout), limit)
+
+	return events, err
+}
+
+// FinishDomainEvent method for marking event as dispatched to all subscribers.
+func (q *DomainEventQueries) FinishDomainEvent(id uuid.UUID) error {
+	// Define query string.
+	query := `
+	UPDATE
+		domain_events
+	SET
+		dispatched_at = $2::timestamp,
+		locked_at = NULL,
+		error = ''
+	WHERE
+		id = $1::uuid
+	`
+
+	// Send query to database.
+	_, err := q.Exec(query, id, time.Now())
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
+
+// RetryDomainEvent method for unlocking event with the next dispatching attempt at the given time.
+func (q *DomainEventQueries) RetryDomainEvent(id uuid.UUID, nextAttemptAt time.Time, dispatchError string) error {
+	// Define query string.
+	query := `
+	UPDATE
+		domain_events
+	SET
+		next_attempt_at = $2::timestamp,
+		locked_at = NULL,
+		error = $3::text
+	WHERE
+		id = $1::uuid
+	`
+
+	// Send query to database.
+	_, err := q.Exec(query, id, nextAttemptAt, dispatchError)
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
+
+// createDomainEvent (private) func for writing event of the changed aggregate to the outbox in the given
+// transaction. Outbox dispatchers are notified, when transaction is committed.
+func createDomainEvent(tx *sqlx.Tx, aggregateType string, aggregateID, projectID uuid.UUID, eventType string, payload models.DomainEventPayload) error {
+	return createDomainEventWithID(tx, uuid.New(), aggregateType, aggregateID, projectID, eventType, payload)
+}
+
+// createDomainEventWithID (private) func for writing event with the given ID to the outbox in the given transaction
+// (ID is needed, when other records of the event are written in the same transaction).
+func createDomainEventWithID(tx *sqlx.Tx, id uuid.UUID, aggregateType string, aggregateID, projectID uuid.UUID, eventType string, payload models.DomainEventPayload) error {
+	// Define current time.
+	now := time.Now()
+
+	// Define query string.
+	query := `
+	INSERT INTO domain_events (id, created_at, aggregate_type, aggregate_id, project_id, event_type, payload, next_attempt_at)
+	VALUES (
+		$1::uuid, $2::timestamp, $3::varchar,
+		$4::uuid, $5::uuid, $6::varchar,
+		$7::jsonb, $2::timestamp
+	)
+	`
+
+	// Define empty payload.
+	if payload == nil {
+		payload = models.DomainEventPayload{}
+	}
+
+	// Send query to database.
+	if _, err := tx.Exec(query, id, now, aggregateType, aggregateID, projectID, eventType, payload); err != nil {
+		return err
+	}
+
+	// Notify outbox dispatchers (notification is delivered only after commit).
+	_, err := tx.Exec(`SELECT pg_notify($1::text, '')`, repository.DomainEventsChannel)
+
+	return err
+}
+
+// createStatusDomainEvents (private) func for writing events of the updated aggregate to the outbox
+// in the given transaction: updated event and status changed event, if status was changed.
+func createStatusDomainEvents(tx *sqlx.Tx, aggregateType string, aggregateID, projectID uuid.UUID, previousStatus, status int) error {
+	// Write updated event.
+	if err := createDomainEvent(
+		tx, aggregateType, aggregateID, projectID, repository.DomainEventUpdated,
+		models.DomainEventPayload{"status": status},
+	); err != nil {
+		return err
+	}
+
+	// Check, if status was changed.
+	if previousStatus == status {
+		return nil
+	}
+
+	// Write status changed event.
+	return createDomainEvent(
+		tx, aggregateType, aggregateID, projectID, repository.DomainEventStatusChanged,
+		models.DomainEventPayload{"previous_status": previousStatus, "status": status},
+	)
+}
@@ -0,0 +1,177 @@
+package queries
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/repository"
+	"database/sql"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// EmailMessageQueries struct for queries from EmailMessage model.
+type EmailMessageQueries struct {
+	*sqlx.DB
+}
+
+// CreateEmailMessage method for adding a new email to the outbound queue.
+func (q *EmailMessageQueries) CreateEmailMessage(m *models.EmailMessage) error {
+	// Define query string.
+	query := `
+	INSERT INTO email_messages (id, created_at, user_id, stream, template, template_model, status, next_attempt_at)
+	VALUES (
+		$1::uuid, $2::timestamp, $3::uuid,
+		$4::varchar, $5::varchar, $6::jsonb,
+		$7::varchar, $8::timestamp
+	)
+	`
+
+	// Send query to database.
+	_, err := q.Exec(
+		query,
+		m.ID, m.CreatedAt, m.UserID,
+		m.Stream, m.Template, m.TemplateModel,
+		m.Status, m.NextAttemptAt,
+	)
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
+
+// ClaimDueEmailMessages method for locking pending emails with due sending attempt (and emails,
+// locked longer than the given timeout) for sending by the current API instance.
+func (q *EmailMessageQueries) ClaimDueEmailMessages(limit int, lockTimeout time.Duration) ([]models.EmailMessage, error) {
+	// Define messages variable.
+	messages := []models.EmailMessage{}
+
+	// Define query string.
+	query := `
+	UPDATE
+		email_messages
+	SET
+		status = $2::varchar,
+		attempts = attempts + 1,
+		locked_at = $3::timestamp
+	WHERE
+		id IN (
+			SELECT id
+			FROM email_messages
+			WHERE
+				(status = $1::varchar AND next_attempt_at <= $3::timestamp)
+				OR (status = $2::varchar AND locked_at < $4::timestamp)
+			ORDER BY next_attempt_at
+			LIMIT $5::int
+			FOR UPDATE SKIP LOCKED
+		)
+	RETURNING *
+	`
+
+	// Define current time.
+	now := time.Now()
+
+	// Send query to database.
+	err := q.Select(
+		&messages, query,
+		repository.EmailMessageStatusPending, repository.EmailMessageStatusSending,
+		now, now.Add(-lockTimeout), limit,
+	)
+
+	return messages, err
+}
+
+// FinishEmailMessage method for saving final outcome (sent, skipped or failed with error) of the email sending.
+func (q *EmailMessageQueries) FinishEmailMessage(id uuid.UUID, status, providerMessageID, sendingError string) error {
+	// Define query string.
+	query := `
+	UPDATE
+		email_messages
+	SET
+		status = $2::varchar,
+		provider_message_id = $3::varchar,
+		error = $4::text,
+		locked_at = NULL,
+		sent_at = CASE WHEN $2::varchar = $6::varchar THEN $5::timestamp ELSE NULL END
+	WHERE
+		id = $1::uuid
+	`
+
+	// Send query to database.
+	_, err := q.Exec(query, id, status, providerMessageID, sendingError, time.Now(), repository.EmailMessageStatusSent)
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
+
+// RetryEmailMessage method for returning email to the queue with the next sending attempt at the given time.
+func (q *EmailMessageQueries) RetryEmailMessage(id uuid.UUID, nextAttemptAt time.Time, sendingError string) error {
+	// Define query string.
+	query := `
+	UPDATE
+		email_messages
+	SET
+		status = $2::varchar,
+		next_attempt_at = $3::timestamp,
+		error = $4::text,
+		locked_at = NULL
+	WHERE
+		id = $1::uuid
+	`
+
+	// Send query to database.
+	_, err := q.Exec(query, id, repository.EmailMessageStatusPending, nextAttemptAt, sendingError)
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
+
+// GetEmailRecipientByID query for getting email address, settings and deliverability state of the user by given ID.
+func (q *EmailMessageQueries) GetEmailRecipientByID(user_id uuid.UUID) (models.EmailRecipient, int, error) {
+	// Define EmailRecipient variable.
+	recipient := models.EmailRecipient{}
+
+	// Define query string.
+	query := `
+	SELECT
+		u.id,
+		u.email,
+		COALESCE(u.user_attrs, '{}'::jsonb) AS user_attrs,
+		COALESCE(u.user_settings, '{}'::jsonb) AS user_settings,
+		d.hard_bounced_at,
+		d.complained_at
+	FROM
+		users AS u
+		LEFT JOIN email_deliverabilities AS d ON d.user_id = u.id
+	WHERE
+		u.id = $1::uuid
+	`
+
+	// Send query to database.
+	err := q.Get(&recipient, query, user_id)
+
+	// Get query result.
+	switch err {
+	case nil:
+		// Return object and 200 OK.
+		return recipient, fiber.StatusOK, nil
+	case sql.ErrNoRows:
+		// Return empty object and 404 error.
+		return recipient, fiber.StatusNotFound, err
+	default:
+		// Return empty object and 400 error.
+		return recipient, fiber.StatusBadRequest, err
+	}
+}
@@ -0,0 +1,66 @@
+package queries
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/repository"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// LinkQueries struct for queries from Link model.
+type LinkQueries struct {
+	*sqlx.DB
+}
+
+// UpdateLinksMetadata method for saving fetched link metadata to attributes of the given object.
+// Metadata is saved with hash of the fetched links and only if links of the object still have this hash
+// (metadata of the old links is not saved over links, which were changed while fetching).
+func (q *LinkQueries) UpdateLinksMetadata(objectType string, objectID uuid.UUID, linksHash string, metadata []models.LinkMetadata) error {
+	// Define query string for the given object type.
+	var query string
+	switch objectType {
+	case repository.LinkObjectProject:
+		query = `
+		UPDATE projects
+		SET project_attrs = jsonb_set(jsonb_set(project_attrs, '{links_metadata}', $2::jsonb), '{links_metadata_hash}', to_jsonb($3::text))
+		WHERE id = $1::uuid AND md5(COALESCE(project_attrs->>'website_url', '')) = $3::text
+		`
+	case repository.LinkObjectTask:
+		query = `
+		UPDATE tasks
+		SET task_attrs = jsonb_set(jsonb_set(task_attrs, '{links_metadata}', $2::jsonb), '{links_metadata_hash}', to_jsonb($3::text))
+		WHERE id = $1::uuid AND md5(array_to_string(ARRAY(
+			SELECT jsonb_array_elements_text(COALESCE(NULLIF(task_attrs->'links', 'null'::jsonb), '[]'::jsonb))
+		), E'\n')) = $3::text
+		`
+	case repository.LinkObjectAnswer:
+		query = `
+		UPDATE answers
+		SET answer_attrs = jsonb_set(jsonb_set(answer_attrs, '{links_metadata}', $2::jsonb), '{links_metadata_hash}', to_jsonb($3::text))
+		WHERE id = $1::uuid AND md5(array_to_string(ARRAY(
+			SELECT jsonb_array_elements_text(COALESCE(NULLIF(answer_attrs->'links', 'null'::jsonb), '[]'::jsonb))
+		), E'\n')) = $3::text
+		`
+	default:
+		return fmt.Errorf("wrong object type for links metadata (%s)", objectType)
+	}
+
+	// Marshal metadata to JSON.
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// Send query to database.
+	if _, err := q.Exec(query, objectID, string(metadataJSON), linksHash); err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
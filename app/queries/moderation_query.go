@@ -0,0 +1,229 @@
+package queries
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/repository"
+	"Komentory/api/platform/embed_files"
+	"database/sql"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// ModerationQueries struct for queries from Moderation model.
+type ModerationQueries struct {
+	*sqlx.DB
+}
+
+// CreateNewAnswerReport method for creating report for the answer and hiding answer,
+// if count of unresolved reports reached the given threshold.
+// Returns true, if answer was hidden by this report.
+func (q *ModerationQueries) CreateNewAnswerReport(r *models.AnswerReport, answerUserID uuid.UUID, threshold int) (bool, error) {
+	// Begin a new transaction.
+	tx, err := q.Beginx()
+	if err != nil {
+		return false, err
+	}
+
+	// Rollback transaction, if it was not committed.
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// Define query string.
+	// If user already reported this answer, reopen his report with new reason.
+	query := `
+	INSERT INTO answer_reports (id, created_at, answer_id, user_id, reason, comment)
+	VALUES (
+		$1::uuid, $2::timestamp, $3::uuid,
+		$4::uuid, $5::varchar, $6::text
+	)
+	ON CONFLICT (answer_id, user_id) DO UPDATE
+	SET
+		created_at = EXCLUDED.created_at,
+		resolved_at = NULL,
+		reason = EXCLUDED.reason,
+		comment = EXCLUDED.comment
+	`
+
+	// Send query to database.
+	if _, err := tx.Exec(query, r.ID, r.CreatedAt, r.AnswerID, r.UserID, r.Reason, r.Comment); err != nil {
+		// Return only error.
+		return false, err
+	}
+
+	// Define query string.
+	// Hide answer, if it is not hidden and has enough unresolved reports.
+	query = `
+	UPDATE
+		answers
+	SET
+		hidden_at = $2::timestamp
+	WHERE
+		id = $1::uuid
+		AND hidden_at IS NULL
+		AND (
+			SELECT COUNT(*)
+			FROM answer_reports
+			WHERE answer_id = $1::uuid AND resolved_at IS NULL
+		) >= $3::int
+	RETURNING id, project_id, task_id
+	`
+
+	// Send query to database.
+	hiddenAnswer := models.Answer{}
+	switch err := tx.Get(&hiddenAnswer, query, r.AnswerID, time.Now(), threshold); err {
+	case nil:
+	case sql.ErrNoRows:
+		// Answer was not hidden, commit only report.
+		return false, tx.Commit()
+	default:
+		// Return only error.
+		return false, err
+	}
+
+	// Write domain event to the outbox.
+	if err := createDomainEvent(
+		tx, repository.DomainAggregateAnswer, hiddenAnswer.ID, hiddenAnswer.ProjectID, repository.DomainEventUpdated,
+		models.DomainEventPayload{"task_id": hiddenAnswer.TaskID, "moderation_action": repository.ModerationActionHide},
+	); err != nil {
+		// Return only error.
+		return false, err
+	}
+
+	// Write automatic action to the audit log.
+	if err := createAuditLog(tx, &models.AuditLog{
+		ID:           uuid.New(),
+		CreatedAt:    time.Now(),
+		ObjectType:   repository.AuditObjectAnswer,
+		ObjectID:     r.AnswerID,
+		ObjectUserID: answerUserID,
+		Action:       repository.ModerationActionHide,
+		Reason:       "reports threshold reached",
+	}); err != nil {
+		// Return only error.
+		return false, err
+	}
+
+	// Commit transaction.
+	return true, tx.Commit()
+}
+
+// ModerateAnswer method for applying moderator action to the answer and writing it to the audit log
+// (and domain event to the outbox).
+func (q *ModerationQueries) ModerateAnswer(a *models.Answer, l *models.AuditLog) error {
+	// Begin a new transaction.
+	tx, err := q.Beginx()
+	if err != nil {
+		return err
+	}
+
+	// Rollback transaction, if it was not committed.
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// Apply moderator action to the answer (warn action changes nothing).
+	switch l.Action {
+	case repository.ModerationActionHide:
+		_, err = tx.Exec(`UPDATE answers SET hidden_at = COALESCE(hidden_at, $2::timestamp) WHERE id = $1::uuid`, a.ID, l.CreatedAt)
+	case repository.ModerationActionRestore:
+		_, err = tx.Exec(`UPDATE answers SET hidden_at = NULL WHERE id = $1::uuid`, a.ID)
+	case repository.ModerationActionDelete:
+		err = deleteAnswer(tx, a.ID)
+	}
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// Resolve all reports of the answer after hide or restore actions.
+	if l.Action == repository.ModerationActionHide || l.Action == repository.ModerationActionRestore {
+		// Define query string.
+		query := `
+		UPDATE
+			answer_reports
+		SET
+			resolved_at = $2::timestamp
+		WHERE
+			answer_id = $1::uuid
+			AND resolved_at IS NULL
+		`
+
+		// Send query to database.
+		if _, err := tx.Exec(query, a.ID, l.CreatedAt); err != nil {
+			// Return only error.
+			return err
+		}
+	}
+
+	// Write domain event to the outbox after hide or restore actions (delete action writes its own event).
+	if l.Action == repository.ModerationActionHide || l.Action == repository.ModerationActionRestore {
+		if err := createDomainEvent(
+			tx, repository.DomainAggregateAnswer, a.ID, a.ProjectID, repository.DomainEventUpdated,
+			models.DomainEventPayload{"task_id": a.TaskID, "moderation_action": l.Action},
+		); err != nil {
+			// Return only error.
+			return err
+		}
+	}
+
+	// Write moderator action to the audit log.
+	if err := createAuditLog(tx, l); err != nil {
+		// Return only error.
+		return err
+	}
+
+	// Commit transaction.
+	return tx.Commit()
+}
+
+// GetModerationQueueAnswers method for getting all answers with unresolved reports.
+func (q *ModerationQueries) GetModerationQueueAnswers() ([]models.GetModerationQueueAnswers, int, error) {
+	// Define answers variable.
+	answers := []models.GetModerationQueueAnswers{}
+
+	// Define query string.
+	query := embed_files.SQLQueryGetModerationQueueAnswers
+
+	// Send query to database.
+	err := q.Select(&answers, query)
+
+	// Get query result.
+	switch err {
+	case nil:
+		// Return object and 200 OK.
+		return answers, fiber.StatusOK, nil
+	case sql.ErrNoRows:
+		// Return empty object and 404 error.
+		return answers, fiber.StatusNotFound, err
+	default:
+		// Return empty object and 400 error.
+		return answers, fiber.StatusBadRequest, err
+	}
+}
+
+// createAuditLog (private) func for writing one record to the audit log in the given transaction.
+func createAuditLog(tx *sqlx.Tx, l *models.AuditLog) error {
+	// Define query string.
+	query := `
+	INSERT INTO audit_logs
+	VALUES (
+		$1::uuid, $2::timestamp, $3::uuid,
+		$4::varchar, $5::uuid, $6::uuid,
+		$7::varchar, $8::text
+	)
+	`
+
+	// Send query to database.
+	_, err := tx.Exec(
+		query,
+		l.ID, l.CreatedAt, l.ActorID,
+		l.ObjectType, l.ObjectID, l.ObjectUserID,
+		l.Action, l.Reason,
+	)
+
+	return err
+}
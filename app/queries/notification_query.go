@@ -0,0 +1,173 @@
+package queries
+
+import (
+	"Komentory/api/app/models"
+	"database/sql"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// NotificationQueries struct for queries from Notification model.
+type NotificationQueries struct {
+	*sqlx.DB
+}
+
+// CreateNotification method for creating a new notification for the user.
+// Returns false, if notifications of this type are disabled in the user settings.
+func (q *NotificationQueries) CreateNotification(n *models.Notification) (bool, error) {
+	// Define query string.
+	// Notification type without toggle in the user settings is enabled.
+	query := `
+	INSERT INTO notifications (id, created_at, user_id, actor_id, notification_type, object_type, object_id, project_id, payload)
+	SELECT
+		$1::uuid, $2::timestamp, $3::uuid,
+		$4::uuid, $5::varchar, $6::varchar,
+		$7::uuid, $8::uuid, $9::jsonb
+	FROM
+		users
+	WHERE
+		id = $3::uuid
+		AND COALESCE((user_settings->'notifications'->>$5::varchar)::boolean, true)
+	`
+
+	// Send query to database.
+	result, err := q.Exec(
+		query,
+		n.ID, n.CreatedAt, n.UserID,
+		n.ActorID, n.Type, n.ObjectType,
+		n.ObjectID, n.ProjectID, n.Payload,
+	)
+	if err != nil {
+		// Return only error.
+		return false, err
+	}
+
+	// Check, if notification was created.
+	createdCount, err := result.RowsAffected()
+	if err != nil {
+		// Return only error.
+		return false, err
+	}
+
+	return createdCount > 0, nil
+}
+
+// GetNotificationsByUserID method for getting notifications of the user (newest first).
+func (q *NotificationQueries) GetNotificationsByUserID(user_id uuid.UUID, unreadOnly bool, limit, offset int) ([]models.Notification, int, error) {
+	// Define notifications variable.
+	notifications := []models.Notification{}
+
+	// Define query string.
+	query := `
+	SELECT
+		*
+	FROM
+		notifications
+	WHERE
+		user_id = $1::uuid
+		AND (NOT $2::boolean OR read_at IS NULL)
+	ORDER BY
+		created_at DESC
+	LIMIT $3::int
+	OFFSET $4::int
+	`
+
+	// Send query to database.
+	err := q.Select(&notifications, query, user_id, unreadOnly, limit, offset)
+
+	// Get query result.
+	switch err {
+	case nil:
+		// Return object and 200 OK.
+		return notifications, fiber.StatusOK, nil
+	case sql.ErrNoRows:
+		// Return empty object and 404 error.
+		return notifications, fiber.StatusNotFound, err
+	default:
+		// Return empty object and 400 error.
+		return notifications, fiber.StatusBadRequest, err
+	}
+}
+
+// CountUnreadNotifications method for getting count of the unread notifications of the user.
+func (q *NotificationQueries) CountUnreadNotifications(user_id uuid.UUID) (int, error) {
+	// Define count variable.
+	unreadCount := 0
+
+	// Define query string.
+	query := `
+	SELECT
+		COUNT(*)
+	FROM
+		notifications
+	WHERE
+		user_id = $1::uuid
+		AND read_at IS NULL
+	`
+
+	// Send query to database.
+	err := q.Get(&unreadCount, query, user_id)
+
+	return unreadCount, err
+}
+
+// MarkNotificationRead method for marking the given notification of the user as read.
+func (q *NotificationQueries) MarkNotificationRead(user_id, id uuid.UUID) (int, error) {
+	// Define query string.
+	query := `
+	UPDATE
+		notifications
+	SET
+		read_at = COALESCE(read_at, $3::timestamp)
+	WHERE
+		id = $2::uuid
+		AND user_id = $1::uuid
+	`
+
+	// Send query to database.
+	result, err := q.Exec(query, user_id, id, time.Now())
+	if err != nil {
+		// Return 400 error.
+		return fiber.StatusBadRequest, err
+	}
+
+	// Check, if notification of the user was found.
+	markedCount, err := result.RowsAffected()
+	if err != nil {
+		// Return 400 error.
+		return fiber.StatusBadRequest, err
+	}
+	if markedCount == 0 {
+		// Return 404 error.
+		return fiber.StatusNotFound, sql.ErrNoRows
+	}
+
+	return fiber.StatusOK, nil
+}
+
+// MarkAllNotificationsRead method for marking all unread notifications of the user as read.
+// Returns count of the marked notifications.
+func (q *NotificationQueries) MarkAllNotificationsRead(user_id uuid.UUID) (int64, error) {
+	// Define query string.
+	query := `
+	UPDATE
+		notifications
+	SET
+		read_at = $2::timestamp
+	WHERE
+		user_id = $1::uuid
+		AND read_at IS NULL
+	`
+
+	// Send query to database.
+	result, err := q.Exec(query, user_id, time.Now())
+	if err != nil {
+		// Return only error.
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
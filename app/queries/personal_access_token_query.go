@@ -0,0 +1,172 @@
+package queries
+
+import (
+	"Komentory/api/app/models"
+	"database/sql"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// PersonalAccessTokenQueries struct for queries from PersonalAccessToken model.
+type PersonalAccessTokenQueries struct {
+	*sqlx.DB
+}
+
+// GetPersonalAccessTokensByUserID method for getting all personal access tokens of the user (newest first).
+func (q *PersonalAccessTokenQueries) GetPersonalAccessTokensByUserID(user_id uuid.UUID) ([]models.PersonalAccessToken, int, error) {
+	// Define tokens variable.
+	tokens := []models.PersonalAccessToken{}
+
+	// Define query string.
+	query := `
+	SELECT *
+	FROM personal_access_tokens
+	WHERE user_id = $1::uuid
+	ORDER BY created_at DESC
+	`
+
+	// Send query to database.
+	err := q.Select(&tokens, query, user_id)
+
+	// Get query result.
+	switch err {
+	case nil:
+		// Return object and 200 OK.
+		return tokens, fiber.StatusOK, nil
+	case sql.ErrNoRows:
+		// Return empty object and 404 error.
+		return tokens, fiber.StatusNotFound, err
+	default:
+		// Return empty object and 400 error.
+		return tokens, fiber.StatusBadRequest, err
+	}
+}
+
+// FindPersonalAccessTokenByID method for find personal access token by given ID.
+func (q *PersonalAccessTokenQueries) FindPersonalAccessTokenByID(id uuid.UUID) (models.PersonalAccessToken, int, error) {
+	// Define PersonalAccessToken variable.
+	token := models.PersonalAccessToken{}
+
+	// Define query string.
+	query := `SELECT * FROM personal_access_tokens WHERE id = $1::uuid LIMIT 1`
+
+	// Send query to database.
+	err := q.Get(&token, query, id)
+
+	// Get query result.
+	switch err {
+	case nil:
+		// Return object and 200 OK.
+		return token, fiber.StatusOK, nil
+	case sql.ErrNoRows:
+		// Return empty object and 404 error.
+		return token, fiber.StatusNotFound, err
+	default:
+		// Return empty object and 400 error.
+		return token, fiber.StatusBadRequest, err
+	}
+}
+
+// FindActivePersonalAccessTokenByHash method for find not revoked and not expired personal access token
+// by SHA-256 hash of the token.
+func (q *PersonalAccessTokenQueries) FindActivePersonalAccessTokenByHash(hash string) (models.PersonalAccessToken, error) {
+	// Define PersonalAccessToken variable.
+	token := models.PersonalAccessToken{}
+
+	// Define query string.
+	query := `
+	SELECT *
+	FROM personal_access_tokens
+	WHERE
+		token_hash = $1::varchar
+		AND revoked_at IS NULL
+		AND expires_at > $2::timestamp
+	LIMIT 1
+	`
+
+	// Send query to database.
+	err := q.Get(&token, query, hash, time.Now())
+
+	return token, err
+}
+
+// CreateNewPersonalAccessToken method for creating a new personal access token of the user.
+func (q *PersonalAccessTokenQueries) CreateNewPersonalAccessToken(t *models.PersonalAccessToken) error {
+	// Define query string.
+	query := `
+	INSERT INTO personal_access_tokens (id, created_at, user_id, name, token_hash, token_prefix, credentials, expires_at)
+	VALUES (
+		$1::uuid, $2::timestamp, $3::uuid,
+		$4::varchar, $5::varchar, $6::varchar,
+		$7::jsonb, $8::timestamp
+	)
+	`
+
+	// Send query to database.
+	_, err := q.Exec(
+		query,
+		t.ID, t.CreatedAt, t.UserID,
+		t.Name, t.TokenHash, t.TokenPrefix,
+		t.Credentials, t.ExpiresAt,
+	)
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
+
+// RevokePersonalAccessToken method for revoking personal access token by given ID.
+// Revoked token is kept in the list of the user tokens.
+func (q *PersonalAccessTokenQueries) RevokePersonalAccessToken(id uuid.UUID) error {
+	// Define query string.
+	query := `
+	UPDATE
+		personal_access_tokens
+	SET
+		revoked_at = $2::timestamp
+	WHERE
+		id = $1::uuid
+		AND revoked_at IS NULL
+	`
+
+	// Send query to database.
+	_, err := q.Exec(query, id, time.Now())
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
+
+// TouchPersonalAccessToken method for saving time of the last usage of the personal access token.
+// Time is saved not more than once a minute to not write to database on each request.
+func (q *PersonalAccessTokenQueries) TouchPersonalAccessToken(id uuid.UUID, usedAt time.Time) error {
+	// Define query string.
+	query := `
+	UPDATE
+		personal_access_tokens
+	SET
+		last_used_at = $2::timestamp
+	WHERE
+		id = $1::uuid
+		AND (last_used_at IS NULL OR last_used_at < $2::timestamp - INTERVAL '1 minute')
+	`
+
+	// Send query to database.
+	_, err := q.Exec(query, id, usedAt)
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
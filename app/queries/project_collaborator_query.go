@@ -0,0 +1,157 @@
+package queries
+
+import (
+	"Komentory/api/app/models"
+	"database/sql"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// ProjectCollaboratorQueries struct for queries from ProjectCollaborator model.
+type ProjectCollaboratorQueries struct {
+	*sqlx.DB
+}
+
+// GetProjectCollaboratorsByProjectID method for getting all collaborators of the given project.
+func (q *ProjectCollaboratorQueries) GetProjectCollaboratorsByProjectID(project_id uuid.UUID) ([]models.ProjectCollaborator, int, error) {
+	// Define collaborators variable.
+	collaborators := []models.ProjectCollaborator{}
+
+	// Define query string.
+	query := `
+	SELECT *
+	FROM project_collaborators
+	WHERE project_id = $1::uuid
+	ORDER BY created_at
+	`
+
+	// Send query to database.
+	err := q.Select(&collaborators, query, project_id)
+
+	// Get query result.
+	switch err {
+	case nil:
+		// Return object and 200 OK.
+		return collaborators, fiber.StatusOK, nil
+	case sql.ErrNoRows:
+		// Return empty object and 404 error.
+		return collaborators, fiber.StatusNotFound, err
+	default:
+		// Return empty object and 400 error.
+		return collaborators, fiber.StatusBadRequest, err
+	}
+}
+
+// FindProjectCollaborator method for find collaborator of the project by given user ID.
+func (q *ProjectCollaboratorQueries) FindProjectCollaborator(project_id, user_id uuid.UUID) (models.ProjectCollaborator, int, error) {
+	// Define ProjectCollaborator variable.
+	collaborator := models.ProjectCollaborator{}
+
+	// Define query string.
+	query := `SELECT * FROM project_collaborators WHERE project_id = $1::uuid AND user_id = $2::uuid LIMIT 1`
+
+	// Send query to database.
+	err := q.Get(&collaborator, query, project_id, user_id)
+
+	// Get query result.
+	switch err {
+	case nil:
+		// Return object and 200 OK.
+		return collaborator, fiber.StatusOK, nil
+	case sql.ErrNoRows:
+		// Return empty object and 404 error.
+		return collaborator, fiber.StatusNotFound, err
+	default:
+		// Return empty object and 400 error.
+		return collaborator, fiber.StatusBadRequest, err
+	}
+}
+
+// GetProjectRole method for getting role of the user in the given project.
+// Creator of the project is the owner, empty role is returned for users, who are not collaborators.
+func (q *ProjectCollaboratorQueries) GetProjectRole(project_id, user_id uuid.UUID) (string, error) {
+	// Define role variable.
+	role := ""
+
+	// Define query string.
+	query := `
+	SELECT
+		CASE WHEN p.user_id = $2::uuid THEN 'owner' ELSE COALESCE(c.role, '') END
+	FROM
+		projects AS p
+		LEFT JOIN project_collaborators AS c ON c.project_id = p.id AND c.user_id = $2::uuid
+	WHERE
+		p.id = $1::uuid
+	`
+
+	// Send query to database.
+	err := q.Get(&role, query, project_id, user_id)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+
+	return role, err
+}
+
+// CreateNewProjectCollaborator method for adding a new collaborator to the project.
+func (q *ProjectCollaboratorQueries) CreateNewProjectCollaborator(c *models.ProjectCollaborator) error {
+	// Define query string.
+	query := `
+	INSERT INTO project_collaborators (project_id, user_id, created_at, role, invited_by)
+	VALUES ($1::uuid, $2::uuid, $3::timestamp, $4::varchar, $5::uuid)
+	`
+
+	// Send query to database.
+	_, err := q.Exec(query, c.ProjectID, c.UserID, c.CreatedAt, c.Role, c.InvitedBy)
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
+
+// UpdateProjectCollaborator method for updating role of the collaborator of the project.
+func (q *ProjectCollaboratorQueries) UpdateProjectCollaborator(project_id, user_id uuid.UUID, role string) error {
+	// Define query string.
+	query := `
+	UPDATE
+		project_collaborators
+	SET
+		updated_at = $3::timestamp,
+		role = $4::varchar
+	WHERE
+		project_id = $1::uuid
+		AND user_id = $2::uuid
+	`
+
+	// Send query to database.
+	_, err := q.Exec(query, project_id, user_id, time.Now(), role)
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
+
+// DeleteProjectCollaborator method for removing collaborator from the project.
+func (q *ProjectCollaboratorQueries) DeleteProjectCollaborator(project_id, user_id uuid.UUID) error {
+	// Define query string.
+	query := `DELETE FROM project_collaborators WHERE project_id = $1::uuid AND user_id = $2::uuid`
+
+	// Send query to database.
+	_, err := q.Exec(query, project_id, user_id)
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
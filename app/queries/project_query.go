@@ -2,6 +2,7 @@ package queries
 
 import (
 	"Komentory/api/app/models"
+	"Komentory/api/pkg/repository"
 	"Komentory/api/platform/embed_files"
 	"database/sql"
 	"time"
@@ -50,8 +51,19 @@ func (q *ProjectQueries) FindProjectByID(project_id uuid.UUID) (models.Project,
 	}
 }
 
-// CreateProject method for creating project by given Project object.
+// CreateProject method for creating project by given Project object and writing domain event to the outbox.
 func (q *ProjectQueries) CreateNewProject(p *models.Project) error {
+	// Begin a new transaction.
+	tx, err := q.Beginx()
+	if err != nil {
+		return err
+	}
+
+	// Rollback transaction, if it was not committed.
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
 	// Define query string.
 	query := `
 	INSERT INTO projects
@@ -62,24 +74,47 @@ func (q *ProjectQueries) CreateNewProject(p *models.Project) error {
 	`
 
 	// Send query to database.
-	_, err := q.Exec(
+	if _, err := tx.Exec(
 		query,
 		p.ID, time.Now(), p.UpdatedAt,
 		p.UserID, p.ProjectStatus, p.ProjectAttrs,
-	)
-	if err != nil {
+	); err != nil {
+		// Return only error.
+		return err
+	}
+
+	// Write domain event to the outbox.
+	if err := createDomainEvent(
+		tx, repository.DomainAggregateProject, p.ID, p.ID, repository.DomainEventCreated,
+		models.DomainEventPayload{"status": p.ProjectStatus},
+	); err != nil {
 		// Return only error.
 		return err
 	}
 
-	// This query returns nothing.
-	return nil
+	// Commit transaction.
+	return tx.Commit()
 }
 
-// UpdateProject method for updating project by given Project object.
+// UpdateProject method for updating project by given Project object and writing domain events
+// (updated and status changed, if status was changed) to the outbox.
 func (q *ProjectQueries) UpdateProject(id uuid.UUID, p *models.UpdateProject) error {
+	// Begin a new transaction.
+	tx, err := q.Beginx()
+	if err != nil {
+		return err
+	}
+
+	// Rollback transaction, if it was not committed.
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
 	// Define query string.
 	query := `
+	WITH previous AS (
+		SELECT project_status FROM projects WHERE id = $1::uuid FOR UPDATE
+	)
 	UPDATE
 		projects
 	SET
@@ -88,21 +123,95 @@ func (q *ProjectQueries) UpdateProject(id uuid.UUID, p *models.UpdateProject) er
 		project_attrs = $4::jsonb
 	WHERE
 		id = $1::uuid
+	RETURNING (SELECT project_status FROM previous)
 	`
 
 	// Send query to database.
-	_, err := q.Exec(query, id, time.Now(), p.ProjectStatus, p.ProjectAttrs)
-	if err != nil {
+	previousStatus := 0
+	if err := tx.Get(&previousStatus, query, id, time.Now(), p.ProjectStatus, p.ProjectAttrs); err != nil {
+		// Return only error.
+		return err
+	}
+
+	// Write domain events to the outbox.
+	if err := createStatusDomainEvents(
+		tx, repository.DomainAggregateProject, id, id, previousStatus, p.ProjectStatus,
+	); err != nil {
 		// Return only error.
 		return err
 	}
 
-	// This query returns nothing.
-	return nil
+	// Commit transaction.
+	return tx.Commit()
 }
 
-// DeleteProject method for delete project by given ID.
+// DeleteProject method for delete project by given ID and writing domain event to the outbox.
 func (q *ProjectQueries) DeleteProject(id uuid.UUID) error {
+	// Begin a new transaction.
+	tx, err := q.Beginx()
+	if err != nil {
+		return err
+	}
+
+	// Rollback transaction, if it was not committed.
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// Delete project and write domain event.
+	if err := deleteProject(tx, id); err != nil {
+		// Return only error.
+		return err
+	}
+
+	// Commit transaction.
+	return tx.Commit()
+}
+
+// deleteProject (private) func for delete project by given ID and writing domain event to the outbox
+// in the given transaction. Active webhooks of the project are deleted with the project, so deliveries
+// of the "project.deleted" event are queued in the same transaction (before webhooks are deleted).
+func deleteProject(tx *sqlx.Tx, id uuid.UUID) error {
+	// Define ID of the domain event and current time.
+	eventID, now := uuid.New(), time.Now()
+
+	// Get active webhooks of the project.
+	projectWebhooks := []models.ProjectWebhook{}
+	if err := tx.Select(&projectWebhooks, `SELECT * FROM project_webhooks WHERE project_id = $1::uuid AND active = TRUE`, id); err != nil {
+		// Return only error.
+		return err
+	}
+
+	// Queue deliveries of the event to the subscribed webhooks (with ID of the domain event for deduplication).
+	webhookIDs := []uuid.UUID{}
+	for i, w := range projectWebhooks {
+		if !w.Subscribed(repository.ProjectEventDeleted) {
+			continue
+		}
+		if err := createProjectWebhookDelivery(tx, &models.ProjectWebhookDelivery{
+			ID:        uuid.NewSHA1(eventID, w.ID[:]),
+			CreatedAt: now,
+			WebhookID: &projectWebhooks[i].ID,
+			ProjectID: id,
+			URL:       w.URL,
+			Secret:    w.Secret,
+			EventType: repository.ProjectEventDeleted,
+			Payload: models.ProjectWebhookDeliveryPayload{
+				"id":         eventID,
+				"type":       repository.ProjectEventDeleted,
+				"created_at": now,
+				"project_id": id,
+				"data":       map[string]interface{}{"id": id},
+			},
+			Status:        repository.ProjectWebhookDeliveryStatusPending,
+			NextAttemptAt: now,
+		}); err != nil {
+			// Return only error.
+			return err
+		}
+		webhookIDs = append(webhookIDs, w.ID)
+	}
+
 	// Define query string.
 	query := `
 	DELETE FROM projects
@@ -110,14 +219,16 @@ func (q *ProjectQueries) DeleteProject(id uuid.UUID) error {
 	`
 
 	// Send query to database.
-	_, err := q.Exec(query, id)
-	if err != nil {
+	if _, err := tx.Exec(query, id); err != nil {
 		// Return only error.
 		return err
 	}
 
-	// This query returns nothing.
-	return nil
+	// Write domain event to the outbox (only with IDs of the webhooks, which got the event).
+	return createDomainEventWithID(
+		tx, eventID, repository.DomainAggregateProject, id, id, repository.DomainEventDeleted,
+		models.DomainEventPayload{"webhook_ids": webhookIDs},
+	)
 }
 
 // GetProjectByAlias method for getting one project by given alias.
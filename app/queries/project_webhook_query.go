@@ -0,0 +1,301 @@
+package queries
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/repository"
+	"database/sql"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// ProjectWebhookQueries struct for queries from ProjectWebhook model.
+type ProjectWebhookQueries struct {
+	*sqlx.DB
+}
+
+// GetProjectWebhooksByProjectID method for getting all webhooks of the given project.
+func (q *ProjectWebhookQueries) GetProjectWebhooksByProjectID(project_id uuid.UUID) ([]models.ProjectWebhook, int, error) {
+	// Define webhooks variable.
+	webhooks := []models.ProjectWebhook{}
+
+	// Define query string.
+	query := `
+	SELECT *
+	FROM project_webhooks
+	WHERE project_id = $1::uuid
+	ORDER BY created_at
+	`
+
+	// Send query to database.
+	err := q.Select(&webhooks, query, project_id)
+
+	// Get query result.
+	switch err {
+	case nil:
+		// Return object and 200 OK.
+		return webhooks, fiber.StatusOK, nil
+	case sql.ErrNoRows:
+		// Return empty object and 404 error.
+		return webhooks, fiber.StatusNotFound, err
+	default:
+		// Return empty object and 400 error.
+		return webhooks, fiber.StatusBadRequest, err
+	}
+}
+
+// FindProjectWebhookByID method for find webhook by given ID.
+func (q *ProjectWebhookQueries) FindProjectWebhookByID(id uuid.UUID) (models.ProjectWebhook, int, error) {
+	// Define ProjectWebhook variable.
+	webhook := models.ProjectWebhook{}
+
+	// Define query string.
+	query := `SELECT * FROM project_webhooks WHERE id = $1::uuid LIMIT 1`
+
+	// Send query to database.
+	err := q.Get(&webhook, query, id)
+
+	// Get query result.
+	switch err {
+	case nil:
+		// Return object and 200 OK.
+		return webhook, fiber.StatusOK, nil
+	case sql.ErrNoRows:
+		// Return empty object and 404 error.
+		return webhook, fiber.StatusNotFound, err
+	default:
+		// Return empty object and 400 error.
+		return webhook, fiber.StatusBadRequest, err
+	}
+}
+
+// CreateNewProjectWebhook method for creating a new webhook of the project.
+func (q *ProjectWebhookQueries) CreateNewProjectWebhook(w *models.ProjectWebhook) error {
+	// Define query string.
+	query := `
+	INSERT INTO project_webhooks (id, created_at, project_id, user_id, url, secret, events, active)
+	VALUES (
+		$1::uuid, $2::timestamp, $3::uuid,
+		$4::uuid, $5::varchar, $6::varchar,
+		$7::jsonb, $8::boolean
+	)
+	`
+
+	// Send query to database.
+	_, err := q.Exec(
+		query,
+		w.ID, w.CreatedAt, w.ProjectID,
+		w.UserID, w.URL, w.Secret,
+		w.Events, w.Active,
+	)
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
+
+// UpdateProjectWebhook method for updating URL, events and state of the webhook by given ID.
+func (q *ProjectWebhookQueries) UpdateProjectWebhook(id uuid.UUID, w *models.UpdateProjectWebhook) error {
+	// Define query string.
+	query := `
+	UPDATE
+		project_webhooks
+	SET
+		updated_at = $2::timestamp,
+		url = $3::varchar,
+		events = $4::jsonb,
+		active = $5::boolean
+	WHERE
+		id = $1::uuid
+	`
+
+	// Send query to database.
+	_, err := q.Exec(query, id, time.Now(), w.URL, models.ProjectWebhookEvents(w.Events), w.Active)
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
+
+// DeleteProjectWebhook method for delete webhook by given ID.
+// Delivery log of the webhook is kept (with empty webhook ID).
+func (q *ProjectWebhookQueries) DeleteProjectWebhook(id uuid.UUID) error {
+	// Define query string.
+	query := `DELETE FROM project_webhooks WHERE id = $1::uuid`
+
+	// Send query to database.
+	_, err := q.Exec(query, id)
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
+
+// GetActiveProjectWebhooks method for getting all active webhooks of the given project.
+func (q *ProjectWebhookQueries) GetActiveProjectWebhooks(project_id uuid.UUID) ([]models.ProjectWebhook, error) {
+	// Define webhooks variable.
+	webhooks := []models.ProjectWebhook{}
+
+	// Define query string.
+	query := `SELECT * FROM project_webhooks WHERE project_id = $1::uuid AND active = TRUE`
+
+	// Send query to database.
+	err := q.Select(&webhooks, query, project_id)
+
+	return webhooks, err
+}
+
+// CreateProjectWebhookDelivery method for adding a new delivery to the outbound queue.
+// Delivery with the same ID (of the event emitted again) is not added.
+func (q *ProjectWebhookQueries) CreateProjectWebhookDelivery(d *models.ProjectWebhookDelivery) error {
+	return createProjectWebhookDelivery(q, d)
+}
+
+// createProjectWebhookDelivery (private) func for saving a new delivery to the outbound queue
+// by the given database connection or transaction.
+func createProjectWebhookDelivery(e sqlx.Execer, d *models.ProjectWebhookDelivery) error {
+	// Define query string.
+	query := `
+	INSERT INTO project_webhook_deliveries (
+		id, created_at, webhook_id, project_id, url, secret,
+		event_type, payload, status, attempts, next_attempt_at, locked_at
+	)
+	VALUES (
+		$1::uuid, $2::timestamp, $3::uuid, $4::uuid, $5::varchar, $6::varchar,
+		$7::varchar, $8::jsonb, $9::varchar, $10::int, $11::timestamp, $12::timestamp
+	)
+	ON CONFLICT (id) DO NOTHING
+	`
+
+	// Send query to database.
+	_, err := e.Exec(
+		query,
+		d.ID, d.CreatedAt, d.WebhookID, d.ProjectID, d.URL, d.Secret,
+		d.EventType, d.Payload, d.Status, d.Attempts, d.NextAttemptAt, d.LockedAt,
+	)
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
+
+// ClaimDueProjectWebhookDeliveries method for locking pending deliveries with due attempt (and deliveries,
+// locked longer than the given timeout) for sending by the current API instance.
+func (q *ProjectWebhookQueries) ClaimDueProjectWebhookDeliveries(limit int, lockTimeout time.Duration) ([]models.ProjectWebhookDelivery, error) {
+	// Define deliveries variable.
+	deliveries := []models.ProjectWebhookDelivery{}
+
+	// Define query string.
+	query := `
+	UPDATE
+		project_webhook_deliveries
+	SET
+		status = $2::varchar,
+		attempts = attempts + 1,
+		locked_at = $3::timestamp
+	WHERE
+		id IN (
+			SELECT id
+			FROM project_webhook_deliveries
+			WHERE
+				(status = $1::varchar AND next_attempt_at <= $3::timestamp)
+				OR (status = $2::varchar AND locked_at < $4::timestamp)
+			ORDER BY next_attempt_at
+			LIMIT $5::int
+			FOR UPDATE SKIP LOCKED
+		)
+	RETURNING *
+	`
+
+	// Define current time.
+	now := time.Now()
+
+	// Send query to database.
+	err := q.Select(
+		&deliveries, query,
+		repository.ProjectWebhookDeliveryStatusPending, repository.ProjectWebhookDeliveryStatusSending,
+		now, now.Add(-lockTimeout), limit,
+	)
+
+	return deliveries, err
+}
+
+// UpdateProjectWebhookDelivery method for saving outcome of the delivery attempt
+// (status, next attempt, response and error).
+func (q *ProjectWebhookQueries) UpdateProjectWebhookDelivery(d *models.ProjectWebhookDelivery) error {
+	// Define query string.
+	query := `
+	UPDATE
+		project_webhook_deliveries
+	SET
+		status = $2::varchar,
+		next_attempt_at = $3::timestamp,
+		locked_at = NULL,
+		delivered_at = $4::timestamp,
+		response_status = $5::int,
+		response_body = $6::text,
+		duration_ms = $7::int,
+		error = $8::text
+	WHERE
+		id = $1::uuid
+	`
+
+	// Send query to database.
+	_, err := q.Exec(
+		query,
+		d.ID, d.Status, d.NextAttemptAt, d.DeliveredAt,
+		d.ResponseStatus, d.ResponseBody, d.DurationMs, d.Error,
+	)
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
+
+// GetProjectWebhookDeliveriesByWebhookID method for getting delivery log of the webhook (newest first).
+func (q *ProjectWebhookQueries) GetProjectWebhookDeliveriesByWebhookID(webhook_id uuid.UUID, limit, offset int) ([]models.ProjectWebhookDelivery, int, error) {
+	// Define deliveries variable.
+	deliveries := []models.ProjectWebhookDelivery{}
+
+	// Define query string.
+	query := `
+	SELECT *
+	FROM project_webhook_deliveries
+	WHERE webhook_id = $1::uuid
+	ORDER BY created_at DESC
+	LIMIT $2::int OFFSET $3::int
+	`
+
+	// Send query to database.
+	err := q.Select(&deliveries, query, webhook_id, limit, offset)
+
+	// Get query result.
+	switch err {
+	case nil:
+		// Return object and 200 OK.
+		return deliveries, fiber.StatusOK, nil
+	case sql.ErrNoRows:
+		// Return empty object and 404 error.
+		return deliveries, fiber.StatusNotFound, err
+	default:
+		// Return empty object and 400 error.
+		return deliveries, fiber.StatusBadRequest, err
+	}
+}
@@ -0,0 +1,49 @@
+package queries
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// RateLimitQueries struct for queries of the rate limiter counters.
+type RateLimitQueries struct {
+	*sqlx.DB
+}
+
+// IncrementRateLimitCounter method for incrementing counter by the given key in one statement.
+// Counter is started from 1 with a new window, if it is not found or its window was reset.
+// Expired counters of the other keys are removed at the same time (by small batches).
+// Returns count of requests in the current window and time of the window reset.
+func (q *RateLimitQueries) IncrementRateLimitCounter(key string, window time.Duration) (int, time.Time, error) {
+	// Define counter variable.
+	counter := struct {
+		Count   int       `db:"count"`
+		ResetAt time.Time `db:"reset_at"`
+	}{}
+
+	// Define current time.
+	now := time.Now()
+
+	// Define query string.
+	query := `
+	WITH cleanup AS (
+		DELETE FROM rate_limit_counters WHERE key IN (
+			SELECT key FROM rate_limit_counters
+			WHERE reset_at < $2::timestamp AND key <> $1::varchar
+			LIMIT 100 FOR UPDATE SKIP LOCKED
+		)
+	)
+	INSERT INTO rate_limit_counters AS c (key, count, reset_at)
+	VALUES ($1::varchar, 1, $3::timestamp)
+	ON CONFLICT (key) DO UPDATE SET
+		count = CASE WHEN c.reset_at <= $2::timestamp THEN 1 ELSE c.count + 1 END,
+		reset_at = CASE WHEN c.reset_at <= $2::timestamp THEN EXCLUDED.reset_at ELSE c.reset_at END
+	RETURNING count, reset_at
+	`
+
+	// Send query to database.
+	err := q.Get(&counter, query, key, now, now.Add(window))
+
+	return counter.Count, counter.ResetAt, err
+}
@@ -0,0 +1,184 @@
+package queries
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/repository"
+	"database/sql"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// StaffQueries struct for queries of the staff (moderators and admins) overrides and AuditLog model.
+type StaffQueries struct {
+	*sqlx.DB
+}
+
+// overrideStatusQueries (private) queries for updating status of any object by its type in the audit log.
+// Each query returns project ID of the object and previous status.
+var overrideStatusQueries = map[string]string{
+	repository.AuditObjectProject: `
+	WITH previous AS (
+		SELECT project_status FROM projects WHERE id = $1::uuid FOR UPDATE
+	)
+	UPDATE
+		projects
+	SET
+		updated_at = $2::timestamp,
+		project_status = $3::int
+	WHERE
+		id = $1::uuid
+	RETURNING id, (SELECT project_status FROM previous)
+	`,
+	repository.AuditObjectTask: `
+	WITH previous AS (
+		SELECT task_status FROM tasks WHERE id = $1::uuid FOR UPDATE
+	)
+	UPDATE
+		tasks
+	SET
+		updated_at = $2::timestamp,
+		task_status = $3::int
+	WHERE
+		id = $1::uuid
+	RETURNING project_id, (SELECT task_status FROM previous)
+	`,
+	repository.AuditObjectAnswer: `
+	WITH previous AS (
+		SELECT answer_status FROM answers WHERE id = $1::uuid FOR UPDATE
+	)
+	UPDATE
+		answers
+	SET
+		updated_at = $2::timestamp,
+		answer_status = $3::int
+	WHERE
+		id = $1::uuid
+	RETURNING project_id, (SELECT answer_status FROM previous)
+	`,
+}
+
+// OverrideStatus method for updating status of any project, task or answer by staff
+// and writing this action to the audit log (and domain events to the outbox).
+// Returns previous status of the object.
+func (q *StaffQueries) OverrideStatus(l *models.AuditLog, status int) (int, error) {
+	// Checking, if object type is allowed.
+	query, ok := overrideStatusQueries[l.ObjectType]
+	if !ok {
+		return 0, fmt.Errorf("wrong object type %s", l.ObjectType)
+	}
+
+	// Begin a new transaction.
+	tx, err := q.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	// Rollback transaction, if it was not committed.
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// Send query to database.
+	var projectID uuid.UUID
+	previousStatus := 0
+	if err := tx.QueryRowx(query, l.ObjectID, l.CreatedAt, status).Scan(&projectID, &previousStatus); err != nil {
+		// Return only error.
+		return 0, err
+	}
+
+	// Write domain events to the outbox (types of the audit objects are the same as domain aggregates).
+	if err := createStatusDomainEvents(tx, l.ObjectType, l.ObjectID, projectID, previousStatus, status); err != nil {
+		// Return only error.
+		return 0, err
+	}
+
+	// Write staff action to the audit log.
+	if err := createAuditLog(tx, l); err != nil {
+		// Return only error.
+		return 0, err
+	}
+
+	// Commit transaction.
+	return previousStatus, tx.Commit()
+}
+
+// OverrideDelete method for deleting any project, task or answer by staff
+// and writing this action to the audit log (and domain event to the outbox).
+func (q *StaffQueries) OverrideDelete(l *models.AuditLog) error {
+	// Begin a new transaction.
+	tx, err := q.Beginx()
+	if err != nil {
+		return err
+	}
+
+	// Rollback transaction, if it was not committed.
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// Delete object by its type.
+	switch l.ObjectType {
+	case repository.AuditObjectProject:
+		err = deleteProject(tx, l.ObjectID)
+	case repository.AuditObjectTask:
+		err = deleteTask(tx, l.ObjectID)
+	case repository.AuditObjectAnswer:
+		err = deleteAnswer(tx, l.ObjectID)
+	default:
+		err = fmt.Errorf("wrong object type %s", l.ObjectType)
+	}
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// Write staff action to the audit log.
+	if err := createAuditLog(tx, l); err != nil {
+		// Return only error.
+		return err
+	}
+
+	// Commit transaction.
+	return tx.Commit()
+}
+
+// GetAuditLogs method for getting records of the audit log (newest first),
+// filtered by object type and object ID (if not empty).
+func (q *StaffQueries) GetAuditLogs(objectType string, objectID uuid.UUID, limit, offset int) ([]models.AuditLog, int, error) {
+	// Define audit logs variable.
+	logs := []models.AuditLog{}
+
+	// Define query string.
+	query := `
+	SELECT
+		*
+	FROM
+		audit_logs
+	WHERE
+		($1::varchar = '' OR object_type = $1::varchar)
+		AND ($2::uuid = $3::uuid OR object_id = $2::uuid)
+	ORDER BY
+		created_at DESC
+	LIMIT $4::int
+	OFFSET $5::int
+	`
+
+	// Send query to database.
+	err := q.Select(&logs, query, objectType, objectID, uuid.Nil, limit, offset)
+
+	// Get query result.
+	switch err {
+	case nil:
+		// Return object and 200 OK.
+		return logs, fiber.StatusOK, nil
+	case sql.ErrNoRows:
+		// Return empty object and 404 error.
+		return logs, fiber.StatusNotFound, err
+	default:
+		// Return empty object and 400 error.
+		return logs, fiber.StatusBadRequest, err
+	}
+}
@@ -2,6 +2,7 @@ package queries
 
 import (
 	"Komentory/api/app/models"
+	"Komentory/api/pkg/repository"
 	"Komentory/api/platform/embed_files"
 	"database/sql"
 	"time"
@@ -51,8 +52,20 @@ func (q *TaskQueries) FindTaskByID(task_id uuid.UUID) (models.Task, int, error)
 	}
 }
 
-// CreateNewTask method for creating a new task.
+// CreateNewTask method for creating a new task, updating tasks counter of the project
+// and writing domain event to the outbox.
 func (q *TaskQueries) CreateNewTask(t *models.Task) error {
+	// Begin a new transaction.
+	tx, err := q.Beginx()
+	if err != nil {
+		return err
+	}
+
+	// Rollback transaction, if it was not committed.
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
 	// Define query string.
 	query := `
 	INSERT INTO tasks
@@ -64,25 +77,54 @@ func (q *TaskQueries) CreateNewTask(t *models.Task) error {
 	`
 
 	// Send query to database.
-	_, err := q.Exec(
+	if _, err := tx.Exec(
 		query,
 		t.ID, t.CreatedAt, t.UpdatedAt,
 		t.UserID, t.ProjectID, t.TaskStatus,
 		t.TaskAttrs,
-	)
-	if err != nil {
+	); err != nil {
+		// Return only error.
+		return err
+	}
+
+	// Increment tasks counter of the project.
+	if err := updateTaskCounters(tx, t.ProjectID, 1, 0); err != nil {
 		// Return only error.
 		return err
 	}
 
-	// This query returns nothing.
-	return nil
+	// Write domain event to the outbox.
+	if err := createDomainEvent(
+		tx, repository.DomainAggregateTask, t.ID, t.ProjectID, repository.DomainEventCreated,
+		models.DomainEventPayload{"status": t.TaskStatus},
+	); err != nil {
+		// Return only error.
+		return err
+	}
+
+	// Commit transaction.
+	return tx.Commit()
 }
 
-// UpdateTask method for updating task by given Task object.
+// UpdateTask method for updating task by given Task object and writing domain events
+// (updated and status changed, if status was changed) to the outbox.
 func (q *TaskQueries) UpdateTask(id uuid.UUID, t *models.UpdateTask) error {
+	// Begin a new transaction.
+	tx, err := q.Beginx()
+	if err != nil {
+		return err
+	}
+
+	// Rollback transaction, if it was not committed.
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
 	// Define query string.
 	query := `
+	WITH previous AS (
+		SELECT task_status FROM tasks WHERE id = $1::uuid FOR UPDATE
+	)
 	UPDATE
 		tasks
 	SET
@@ -91,36 +133,85 @@ func (q *TaskQueries) UpdateTask(id uuid.UUID, t *models.UpdateTask) error {
 		task_attrs = $4::jsonb
 	WHERE
 		id = $1::uuid
+	RETURNING project_id, (SELECT task_status FROM previous)
 	`
 
 	// Send query to database.
-	_, err := q.Exec(query, id, time.Now(), t.TaskStatus, t.TaskAttrs)
-	if err != nil {
+	var projectID uuid.UUID
+	previousStatus := 0
+	if err := tx.QueryRowx(query, id, time.Now(), t.TaskStatus, t.TaskAttrs).Scan(&projectID, &previousStatus); err != nil {
+		// Return only error.
+		return err
+	}
+
+	// Write domain events to the outbox.
+	if err := createStatusDomainEvents(
+		tx, repository.DomainAggregateTask, id, projectID, previousStatus, t.TaskStatus,
+	); err != nil {
 		// Return only error.
 		return err
 	}
 
-	// This query returns nothing.
-	return nil
+	// Commit transaction.
+	return tx.Commit()
 }
 
-// DeleteTask method for delete task by given ID.
+// DeleteTask method for delete task by given ID, updating counters of the project
+// and writing domain event to the outbox.
 func (q *TaskQueries) DeleteTask(id uuid.UUID) error {
+	// Begin a new transaction.
+	tx, err := q.Beginx()
+	if err != nil {
+		return err
+	}
+
+	// Rollback transaction, if it was not committed.
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// Delete task, update counters and write domain event.
+	if err := deleteTask(tx, id); err != nil {
+		// Return only error.
+		return err
+	}
+
+	// Commit transaction.
+	return tx.Commit()
+}
+
+// deleteTask (private) func for delete task by given ID, updating counters of the project
+// and writing domain event to the outbox in the given transaction.
+func deleteTask(tx *sqlx.Tx, id uuid.UUID) error {
+	// Define count of the task answers, which will be deleted with the task.
+	deletedAnswersCount := 0
+	if err := tx.Get(&deletedAnswersCount, `SELECT COUNT(*) FROM answers WHERE task_id = $1::uuid`, id); err != nil {
+		// Return only error.
+		return err
+	}
+
 	// Define query string.
 	query := `
 	DELETE FROM tasks
 	WHERE id = $1::uuid
+	RETURNING project_id
 	`
 
 	// Send query to database.
-	_, err := q.Exec(query, id)
-	if err != nil {
+	var projectID uuid.UUID
+	if err := tx.Get(&projectID, query, id); err != nil {
+		// Return only error.
+		return err
+	}
+
+	// Decrement tasks counter and fix answers counters of the project.
+	if err := updateTaskCounters(tx, projectID, -1, deletedAnswersCount); err != nil {
 		// Return only error.
 		return err
 	}
 
-	// This query returns nothing.
-	return nil
+	// Write domain event to the outbox.
+	return createDomainEvent(tx, repository.DomainAggregateTask, id, projectID, repository.DomainEventDeleted, nil)
 }
 
 // GetTaskByID method for getting one project by given ID.
@@ -172,3 +263,53 @@ func (q *TaskQueries) GetTasksByProjectID(project_id uuid.UUID) ([]models.GetTas
 		return tasks, fiber.StatusBadRequest, err
 	}
 }
+
+// GetDraftTasksByProjectID method for getting not active (draft and unpublished) tasks of the given project.
+func (q *TaskQueries) GetDraftTasksByProjectID(project_id uuid.UUID) ([]models.GetDraftTasks, int, error) {
+	// Define tasks variable.
+	tasks := []models.GetDraftTasks{}
+
+	// Define query string.
+	query := embed_files.SQLQueryGetDraftTasksByProjectID
+
+	// Send query to database.
+	err := q.Select(&tasks, query, project_id)
+
+	// Get query result.
+	switch err {
+	case nil:
+		// Return object and 200 OK.
+		return tasks, fiber.StatusOK, nil
+	case sql.ErrNoRows:
+		// Return empty object and 404 error.
+		return tasks, fiber.StatusNotFound, err
+	default:
+		// Return empty object and 400 error.
+		return tasks, fiber.StatusBadRequest, err
+	}
+}
+
+// GetTaskResultsByTaskID method for getting aggregated results of the questions for given task.
+func (q *TaskQueries) GetTaskResultsByTaskID(task_id uuid.UUID) ([]models.GetTaskQuestionResult, int, error) {
+	// Define results variable.
+	results := []models.GetTaskQuestionResult{}
+
+	// Define query string.
+	query := embed_files.SQLQueryGetResultsByTaskID
+
+	// Send query to database.
+	err := q.Select(&results, query, task_id)
+
+	// Get query result.
+	switch err {
+	case nil:
+		// Return object and 200 OK.
+		return results, fiber.StatusOK, nil
+	case sql.ErrNoRows:
+		// Return empty object and 404 error.
+		return results, fiber.StatusNotFound, err
+	default:
+		// Return empty object and 400 error.
+		return results, fiber.StatusBadRequest, err
+	}
+}
@@ -0,0 +1,94 @@
+package queries
+
+import (
+	"Komentory/api/app/models"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// TokenRevocationQueries struct for queries from RevokedToken and UserTokenRevocation models.
+type TokenRevocationQueries struct {
+	*sqlx.DB
+}
+
+// IsTokenRevoked method for checking, if JWT with the given "jti" claim is revoked.
+func (q *TokenRevocationQueries) IsTokenRevoked(jti string) (bool, error) {
+	// Define revoked variable.
+	revoked := false
+
+	// Define query string.
+	query := `SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = $1::varchar)`
+
+	// Send query to database.
+	err := q.Get(&revoked, query, jti)
+
+	return revoked, err
+}
+
+// GetUserTokensIssuedBefore method for getting time, before which all tokens of the user are revoked.
+// Returns zero time, if tokens of the user were never revoked.
+func (q *TokenRevocationQueries) GetUserTokensIssuedBefore(user_id uuid.UUID) (time.Time, error) {
+	// Define time variable.
+	tokensIssuedBefore := time.Time{}
+
+	// Define query string.
+	query := `SELECT tokens_issued_before FROM user_token_revocations WHERE user_id = $1::uuid`
+
+	// Send query to database.
+	err := q.Get(&tokensIssuedBefore, query, user_id)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+
+	return tokensIssuedBefore, err
+}
+
+// RevokeToken method for revoking one JWT by "jti" claim.
+// Revoked tokens, which are already expired, are removed at the same time.
+func (q *TokenRevocationQueries) RevokeToken(t *models.RevokedToken) error {
+	// Define query string.
+	query := `
+	WITH cleanup AS (
+		DELETE FROM revoked_tokens WHERE expires_at < $2::timestamp
+	)
+	INSERT INTO revoked_tokens (jti, created_at, user_id, expires_at)
+	VALUES ($1::varchar, $2::timestamp, $3::uuid, $4::timestamp)
+	ON CONFLICT (jti) DO NOTHING
+	`
+
+	// Send query to database.
+	_, err := q.Exec(query, t.JTI, t.CreatedAt, t.UserID, t.ExpiresAt)
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
+
+// RevokeUserTokens method for revoking all tokens of the user, issued before the given time.
+func (q *TokenRevocationQueries) RevokeUserTokens(r *models.UserTokenRevocation) error {
+	// Define query string.
+	query := `
+	INSERT INTO user_token_revocations (user_id, tokens_issued_before, revoked_by)
+	VALUES ($1::uuid, $2::timestamp, $3::uuid)
+	ON CONFLICT (user_id) DO UPDATE
+	SET
+		tokens_issued_before = GREATEST(user_token_revocations.tokens_issued_before, EXCLUDED.tokens_issued_before),
+		revoked_by = EXCLUDED.revoked_by
+	`
+
+	// Send query to database.
+	_, err := q.Exec(query, r.UserID, r.TokensIssuedBefore, r.RevokedBy)
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
@@ -2,7 +2,9 @@ package queries
 
 import (
 	"Komentory/api/app/models"
+	"Komentory/api/platform/embed_files"
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -48,21 +50,55 @@ func (q *UserQueries) GetUserByEmail(email string) (models.User, int, error) {
 	}
 }
 
-// UpdateUserSettings method for updating user settings by given user ID.
-func (q *UserQueries) UpdateUserSettings(id uuid.UUID, u *models.UserSettings) error {
+// GetUserByID query for getting public profile of the user by given ID.
+func (q *UserQueries) GetUserByID(user_id uuid.UUID) (models.GetUser, int, error) {
+	// Define User variable.
+	user := models.GetUser{}
+
+	// Define query string.
+	query := embed_files.SQLQueryGetOneUserByID
+
+	// Send query to database.
+	err := q.Get(&user, query, user_id)
+
+	// Get query result.
+	switch err {
+	case nil:
+		// Return object and 200 OK.
+		return user, fiber.StatusOK, nil
+	case sql.ErrNoRows:
+		// Return empty object and 404 error.
+		return user, fiber.StatusNotFound, err
+	default:
+		// Return empty object and 400 error.
+		return user, fiber.StatusBadRequest, err
+	}
+}
+
+// UpdateUserProfile method for updating only given profile attributes of the user by given ID.
+// Other user attributes are kept.
+func (q *UserQueries) UpdateUserProfile(id uuid.UUID, u *models.UpdateUserProfile) error {
 	// Define query string.
 	query := `
 	UPDATE
 		users
 	SET
 		updated_at = $2::timestamp,
-		user_settings = $3::jsonb
+		user_attrs = COALESCE(user_attrs, '{}'::jsonb) || $3::jsonb
 	WHERE
 		id = $1::uuid
 	`
 
 	// Send query to database.
-	_, err := q.Exec(query, id, time.Now(), u)
+	// Marshal given attributes to JSON.
+	attrsJSON, err := json.Marshal(u.Attrs())
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// Send query to database.
+	_, err = q.Exec(query, id, time.Now(), string(attrsJSON))
 	if err != nil {
 		// Return only error.
 		return err
@@ -71,3 +107,144 @@ func (q *UserQueries) UpdateUserSettings(id uuid.UUID, u *models.UserSettings) e
 	// This query returns nothing.
 	return nil
 }
+
+// GetUserSettingsByID query for getting settings of the user by given ID.
+func (q *UserQueries) GetUserSettingsByID(id uuid.UUID) (models.UserSettings, int, error) {
+	// Define UserSettings variable.
+	settings := models.UserSettings{}
+
+	// Define query string.
+	query := `
+	SELECT
+		COALESCE(user_settings, '{}'::jsonb)
+	FROM
+		users
+	WHERE
+		id = $1::uuid
+	`
+
+	// Send query to database.
+	err := q.Get(&settings, query, id)
+
+	// Get query result.
+	switch err {
+	case nil:
+		// Return object and 200 OK.
+		return settings, fiber.StatusOK, nil
+	case sql.ErrNoRows:
+		// Return empty object and 404 error.
+		return settings, fiber.StatusNotFound, err
+	default:
+		// Return empty object and 400 error.
+		return settings, fiber.StatusBadRequest, err
+	}
+}
+
+// UpdateUserSettings method for merging changed email subscriptions and notification toggles
+// to the settings of the user by given ID. Time of the change is saved for each changed email stream,
+// so older events of the email provider don't override choice of the user. Returns updated settings.
+func (q *UserQueries) UpdateUserSettings(id uuid.UUID, emailSubscriptions, notifications map[string]bool) (models.UserSettings, error) {
+	// Define UserSettings variable.
+	settings := models.UserSettings{}
+
+	// Define query string.
+	query := `
+	UPDATE
+		users
+	SET
+		updated_at = $2::timestamp,
+		user_settings = COALESCE(user_settings, '{}'::jsonb) || jsonb_build_object(
+			'email_subscriptions',
+			COALESCE(user_settings->'email_subscriptions', '{}'::jsonb) || $3::jsonb,
+			'email_subscription_changes',
+			COALESCE(user_settings->'email_subscription_changes', '{}'::jsonb) || $5::jsonb,
+			'notifications',
+			COALESCE(user_settings->'notifications', '{}'::jsonb) || $4::jsonb
+		)
+	WHERE
+		id = $1::uuid
+	RETURNING
+		user_settings
+	`
+
+	// Marshal changes to JSON (empty object for no changes).
+	changesJSON := make([]string, 2)
+	for i, changes := range []map[string]bool{emailSubscriptions, notifications} {
+		if changes == nil {
+			changes = map[string]bool{}
+		}
+		encoded, err := json.Marshal(changes)
+		if err != nil {
+			// Return only error.
+			return settings, err
+		}
+		changesJSON[i] = string(encoded)
+	}
+
+	// Define time of the changes for each changed email stream.
+	now := time.Now()
+	subscriptionChanges := map[string]models.EmailSubscriptionChange{}
+	for stream := range emailSubscriptions {
+		subscriptionChanges[stream] = models.EmailSubscriptionChange{ChangedAt: now}
+	}
+	subscriptionChangesJSON, err := json.Marshal(subscriptionChanges)
+	if err != nil {
+		// Return only error.
+		return settings, err
+	}
+
+	// Send query to database.
+	err = q.Get(&settings, query, id, now, changesJSON[0], changesJSON[1], string(subscriptionChangesJSON))
+
+	return settings, err
+}
+
+// UpdateUserEmailSubscription method for subscribe (or unsubscribe) user to the given email stream
+// by email provider's event. Other user settings are kept (jsonb merge).
+// Returns false, if user has a newer change of this stream (out-of-order event was ignored).
+func (q *UserQueries) UpdateUserEmailSubscription(
+	id uuid.UUID, stream string, subscribed bool, change *models.EmailSubscriptionChange,
+) (bool, error) {
+	// Define query string.
+	query := `
+	UPDATE
+		users
+	SET
+		updated_at = $5::timestamp,
+		user_settings = COALESCE(user_settings, '{}'::jsonb) || jsonb_build_object(
+			'email_subscriptions',
+			COALESCE(user_settings->'email_subscriptions', '{}'::jsonb) || jsonb_build_object($2::text, $3::boolean),
+			'email_subscription_changes',
+			COALESCE(user_settings->'email_subscription_changes', '{}'::jsonb) || jsonb_build_object($2::text, $4::jsonb)
+		)
+	WHERE
+		id = $1::uuid
+		AND (
+			user_settings->'email_subscription_changes'->$2::text->>'changed_at' IS NULL
+			OR (user_settings->'email_subscription_changes'->$2::text->>'changed_at')::timestamptz < $6::timestamptz
+		)
+	`
+
+	// Marshal change to JSON.
+	changeJSON, err := json.Marshal(change)
+	if err != nil {
+		// Return only error.
+		return false, err
+	}
+
+	// Send query to database.
+	result, err := q.Exec(query, id, stream, subscribed, string(changeJSON), time.Now(), change.ChangedAt)
+	if err != nil {
+		// Return only error.
+		return false, err
+	}
+
+	// Check, if subscription was changed.
+	updatedCount, err := result.RowsAffected()
+	if err != nil {
+		// Return only error.
+		return false, err
+	}
+
+	return updatedCount > 0, nil
+}
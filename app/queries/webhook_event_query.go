@@ -0,0 +1,194 @@
+package queries
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/repository"
+	"database/sql"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// WebhookEventQueries struct for queries from WebhookEvent model.
+type WebhookEventQueries struct {
+	*sqlx.DB
+}
+
+// CreateWebhookEvent method for saving a new received webhook event.
+// Returns false, if event with the same vendor and key was already received (duplicate).
+func (q *WebhookEventQueries) CreateWebhookEvent(e *models.WebhookEvent) (bool, error) {
+	// Define query string.
+	query := `
+	INSERT INTO webhook_events (id, received_at, vendor, event_type, event_key, raw_body, status)
+	VALUES (
+		$1::uuid, $2::timestamp, $3::varchar,
+		$4::varchar, $5::varchar, $6::text,
+		$7::varchar
+	)
+	ON CONFLICT (vendor, event_key) DO NOTHING
+	`
+
+	// Send query to database.
+	result, err := q.Exec(
+		query,
+		e.ID, e.ReceivedAt, e.Vendor,
+		e.EventType, e.EventKey, e.RawBody,
+		e.Status,
+	)
+	if err != nil {
+		// Return only error.
+		return false, err
+	}
+
+	// Check, if event was saved.
+	createdCount, err := result.RowsAffected()
+	if err != nil {
+		// Return only error.
+		return false, err
+	}
+
+	return createdCount > 0, nil
+}
+
+// ClaimPendingWebhookEvents method for locking pending events (and events, locked longer than
+// the given timeout) for processing by the current API instance.
+func (q *WebhookEventQueries) ClaimPendingWebhookEvents(limit int, lockTimeout time.Duration) ([]models.WebhookEvent, error) {
+	// Define events variable.
+	events := []models.WebhookEvent{}
+
+	// Define query string.
+	query := `
+	UPDATE
+		webhook_events
+	SET
+		status = $2::varchar,
+		attempts = attempts + 1,
+		locked_at = $3::timestamp
+	WHERE
+		id IN (
+			SELECT id
+			FROM webhook_events
+			WHERE
+				status = $1::varchar
+				OR (status = $2::varchar AND locked_at < $4::timestamp)
+			ORDER BY received_at
+			LIMIT $5::int
+			FOR UPDATE SKIP LOCKED
+		)
+	RETURNING *
+	`
+
+	// Define current time.
+	now := time.Now()
+
+	// Send query to database.
+	err := q.Select(
+		&events, query,
+		repository.WebhookEventStatusPending, repository.WebhookEventStatusProcessing,
+		now, now.Add(-lockTimeout), limit,
+	)
+
+	return events, err
+}
+
+// FinishWebhookEvent method for saving outcome (processed or failed with error) of the event processing.
+func (q *WebhookEventQueries) FinishWebhookEvent(id uuid.UUID, status, processingError string) error {
+	// Define query string.
+	query := `
+	UPDATE
+		webhook_events
+	SET
+		status = $2::varchar,
+		error = $3::text,
+		locked_at = NULL,
+		processed_at = $4::timestamp
+	WHERE
+		id = $1::uuid
+	`
+
+	// Send query to database.
+	_, err := q.Exec(query, id, status, processingError, time.Now())
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
+
+// ReplayWebhookEvent method for returning stored event (not processing now) to the processing queue.
+func (q *WebhookEventQueries) ReplayWebhookEvent(id uuid.UUID) (int, error) {
+	// Define query string.
+	query := `
+	UPDATE
+		webhook_events
+	SET
+		status = $2::varchar,
+		error = '',
+		locked_at = NULL
+	WHERE
+		id = $1::uuid
+		AND status <> $3::varchar
+	`
+
+	// Send query to database.
+	result, err := q.Exec(query, id, repository.WebhookEventStatusPending, repository.WebhookEventStatusProcessing)
+	if err != nil {
+		// Return 400 error.
+		return fiber.StatusBadRequest, err
+	}
+
+	// Check, if event was found.
+	replayedCount, err := result.RowsAffected()
+	if err != nil {
+		// Return 400 error.
+		return fiber.StatusBadRequest, err
+	}
+	if replayedCount == 0 {
+		// Return 404 error.
+		return fiber.StatusNotFound, sql.ErrNoRows
+	}
+
+	return fiber.StatusOK, nil
+}
+
+// GetWebhookEvents method for getting stored webhook events (newest first).
+// Empty vendor or status means "any".
+func (q *WebhookEventQueries) GetWebhookEvents(vendor, status string, limit, offset int) ([]models.WebhookEvent, int, error) {
+	// Define events variable.
+	events := []models.WebhookEvent{}
+
+	// Define query string.
+	query := `
+	SELECT
+		*
+	FROM
+		webhook_events
+	WHERE
+		($1::varchar = '' OR vendor = $1::varchar)
+		AND ($2::varchar = '' OR status = $2::varchar)
+	ORDER BY
+		received_at DESC
+	LIMIT $3::int
+	OFFSET $4::int
+	`
+
+	// Send query to database.
+	err := q.Select(&events, query, vendor, status, limit, offset)
+
+	// Get query result.
+	switch err {
+	case nil:
+		// Return object and 200 OK.
+		return events, fiber.StatusOK, nil
+	case sql.ErrNoRows:
+		// Return empty object and 404 error.
+		return events, fiber.StatusNotFound, err
+	default:
+		// Return empty object and 400 error.
+		return events, fiber.StatusBadRequest, err
+	}
+}
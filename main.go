@@ -1,10 +1,19 @@
 package main
 
 import (
+	"Komentory/api/pkg/commands"
 	"Komentory/api/pkg/configs"
 	"Komentory/api/pkg/middleware"
 	"Komentory/api/pkg/routes"
+	"Komentory/api/platform/mail"
+	"Komentory/api/platform/outbox"
+	"Komentory/api/platform/stream"
+	"Komentory/api/platform/unfurl"
+	"Komentory/api/platform/webhooks"
+	"context"
+	"log"
 	"os"
+	"time"
 
 	"github.com/Komentory/utilities"
 	"github.com/gofiber/fiber/v2"
@@ -13,6 +22,14 @@ import (
 )
 
 func main() {
+	// Run maintenance command, if it was given (like `./api repair-counters`).
+	if len(os.Args) > 1 {
+		if err := commands.Run(os.Args[1], os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	// Define Fiber config.
 	config := configs.FiberConfig()
 
@@ -20,14 +37,31 @@ func main() {
 	app := fiber.New(config)
 
 	// Middlewares.
-	middleware.FiberMiddleware(app) // Register Fiber's middleware for app.
+	middleware.FiberMiddleware(app)    // Register Fiber's middleware for app.
+	middleware.SetupRateLimitStorage() // Set storage of the rate limiter counters.
 
 	// Routes.
 	routes.PublicRoutes(app)  // Register public routes for app.
+	routes.AdminRoutes(app)   // Register admin routes for app (before private routes).
 	routes.PrivateRoutes(app) // Register private routes for app.
 	routes.WebhookRoutes(app) // Register webhook routes for app.
 	routes.NotFoundRoute(app) // Register a route for 404 Error.
 
+	// Workers.
+	unfurl.StartDefaultWorker(context.Background())                       // Start link unfurl worker (if enabled).
+	webhooks.DefaultProcessor.Start(context.Background(), time.Minute)    // Start processor of the received webhook events.
+	webhooks.StartDefaultDispatcher(context.Background())                 // Start sender of the project events to the webhooks of integrators.
+	if err := mail.StartDefaultMailer(context.Background()); err != nil { // Start sender of the queued emails (if enabled).
+		log.Fatal(err)
+	}
+	if err := stream.StartDefaultBroadcaster(context.Background()); err != nil { // Start broadcaster of the answer events.
+		log.Fatal(err)
+	}
+	outbox.SubscribeDefaults()                                                  // Subscribe answer streams and project webhooks to the domain events.
+	if err := outbox.StartDefaultDispatcher(context.Background()); err != nil { // Start dispatcher of the domain events from the outbox.
+		log.Fatal(err)
+	}
+
 	// Start server (with or without graceful shutdown).
 	if os.Getenv("STAGE_STATUS") == "dev" {
 		utilities.StartServer(app)
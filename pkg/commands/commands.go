@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"Komentory/api/platform/database"
+	"Komentory/api/platform/digest"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Run func for running the given maintenance command instead of starting the server.
+// Usage: ./api <command> [arguments]
+//  - repair-counters, recompute tasks_count, answers_count and contributors_count of projects and tasks;
+//  - send-weekly-digest [--dry-run] [--dir <folder>], send weekly digests to project owners (run daily by cron);
+func Run(command string, args []string) error {
+	// Switch commands.
+	switch command {
+	case "repair-counters":
+		return RepairCounters()
+	case "send-weekly-digest":
+		return SendWeeklyDigest(args)
+	default:
+		return fmt.Errorf("unknown command (%s)", command)
+	}
+}
+
+// RepairCounters func for recomputing denormalized counters of projects and tasks.
+func RepairCounters() error {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return err
+	}
+
+	// Recompute counters.
+	repairedCount, err := db.RepairCounters()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("repair-counters: %d projects and tasks were repaired", repairedCount)
+
+	return nil
+}
+
+// SendWeeklyDigest func for sending weekly digests to the project owners with new answers.
+// With --dry-run flag, digests are written to the folder (--dir) instead of the emails queue.
+func SendWeeklyDigest(args []string) error {
+	// Parse arguments of the command.
+	flags := flag.NewFlagSet("send-weekly-digest", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "write rendered digests to the folder instead of sending")
+	dir := flags.String("dir", "./tmp/digests", "folder for rendered digests in dry run")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	// Send digests.
+	sentCount, err := digest.NewDefaultJob(*dryRun, *dir).Run(context.Background(), time.Now())
+	log.Printf("send-weekly-digest: %d digests were sent (dry run: %t)", sentCount, *dryRun)
+
+	return err
+}
@@ -3,6 +3,7 @@ package configs
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -35,11 +36,23 @@ func FiberConfig() fiber.Config {
 		startupMessage = true
 	}
 
+	// Define trusted proxies (like load balancer), which set IP address of the client to the proxy header.
+	// Header is used only for requests from trusted proxies (no one is trusted, if list is empty).
+	trustedProxies := []string{}
+	for _, proxy := range strings.Split(os.Getenv("SERVER_TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			trustedProxies = append(trustedProxies, proxy)
+		}
+	}
+
 	// Return Fiber configuration.
 	return fiber.Config{
-		DisableStartupMessage: startupMessage,
-		ReadTimeout:           time.Duration(readTimeoutSecondsCount) * time.Second,
-		WriteTimeout:          time.Duration(writeTimeoutSecondsCount) * time.Second,
-		IdleTimeout:           time.Duration(idleTimeoutSecondsCount) * time.Second,
+		DisableStartupMessage:   startupMessage,
+		ReadTimeout:             time.Duration(readTimeoutSecondsCount) * time.Second,
+		WriteTimeout:            time.Duration(writeTimeoutSecondsCount) * time.Second,
+		IdleTimeout:             time.Duration(idleTimeoutSecondsCount) * time.Second,
+		ProxyHeader:             os.Getenv("SERVER_PROXY_HEADER"),
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          trustedProxies,
 	}
 }
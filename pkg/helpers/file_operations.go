@@ -125,3 +125,28 @@ func GetUserIDFromCDNFileKey(key string) (string, error) {
 
 	return splitKey[1], nil
 }
+
+// ValidateUserCDNFileURL func for checking, if the given URL is a file from the user's upload folder on CDN.
+// Valid URL: <CDN_PUBLIC_URL>/<DO_SPACES_UPLOADS_FOLDER_NAME>/<user ID>/<file name>
+func ValidateUserCDNFileURL(fileURL string, userID uuid.UUID) error {
+	// Check, if URL is safe.
+	if err := ValidateURL(fileURL); err != nil {
+		return err
+	}
+
+	// Define prefix of the user's upload folder on CDN.
+	prefix := fmt.Sprintf(
+		"%v/%v/%v/",
+		strings.TrimSuffix(os.Getenv("CDN_PUBLIC_URL"), "/"),
+		os.Getenv("DO_SPACES_UPLOADS_FOLDER_NAME"),
+		userID.String(),
+	)
+
+	// Check, if URL is a file in the user's upload folder (without sub-folders and query).
+	fileName := strings.TrimPrefix(fileURL, prefix)
+	if fileName == fileURL || fileName == "" || strings.ContainsAny(fileName, "/?#\\") || strings.HasPrefix(fileName, ".") {
+		return fmt.Errorf("file is not uploaded to CDN by this user (%s)", fileURL)
+	}
+
+	return nil
+}
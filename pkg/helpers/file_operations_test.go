@@ -0,0 +1,41 @@
+package helpers
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateUserCDNFileURL(t *testing.T) {
+	// Set CDN settings.
+	os.Setenv("CDN_PUBLIC_URL", "https://cdn.example.com/")
+	os.Setenv("DO_SPACES_UPLOADS_FOLDER_NAME", "uploads")
+
+	// Define user IDs.
+	userID, otherUserID := uuid.New(), uuid.New()
+
+	// Define a structure for specifying input and output data of a single test case.
+	tests := []struct {
+		description   string
+		fileURL       string
+		expectedError bool
+	}{
+		// Successful test cases:
+		{"success: file of the user", "https://cdn.example.com/uploads/" + userID.String() + "/a1b2c3.png", false},
+
+		// Failed test cases:
+		{"fail: file of the other user", "https://cdn.example.com/uploads/" + otherUserID.String() + "/a1b2c3.png", true},
+		{"fail: file from other host", "https://example.com/uploads/" + userID.String() + "/a1b2c3.png", true},
+		{"fail: user folder without file", "https://cdn.example.com/uploads/" + userID.String() + "/", true},
+		{"fail: path traversal", "https://cdn.example.com/uploads/" + userID.String() + "/../x.png", true},
+		{"fail: file with query", "https://cdn.example.com/uploads/" + userID.String() + "/a.png?x=1", true},
+	}
+
+	// Iterate through test single test cases.
+	for _, test := range tests {
+		err := ValidateUserCDNFileURL(test.fileURL, userID)
+		assert.Equalf(t, test.expectedError, err != nil, test.description)
+	}
+}
@@ -0,0 +1,133 @@
+package helpers
+
+import (
+	"Komentory/api/pkg/repository"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+	"unicode"
+
+	"github.com/Komentory/utilities"
+)
+
+// ValidateURL func for checking, if the given URL is safe to store and show.
+// Allows only absolute URLs with http/https schemes and without credentials.
+func ValidateURL(link string) error {
+	// Check length of the URL.
+	if link == "" || len(link) > repository.LinkMaxLength {
+		return fmt.Errorf("url is empty or longer than %d characters", repository.LinkMaxLength)
+	}
+
+	// Check, if URL has no spaces or control characters.
+	if strings.IndexFunc(link, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) >= 0 {
+		return fmt.Errorf("url contains spaces or control characters (%s)", link)
+	}
+
+	// Parse URL.
+	parsedURL, err := url.Parse(link)
+	if err != nil {
+		return fmt.Errorf("url is not valid (%s)", link)
+	}
+
+	// Check URL scheme, host and user info.
+	if !utilities.SearchStringInArray(strings.ToLower(parsedURL.Scheme), repository.LinkSafeSchemes) {
+		return fmt.Errorf("url scheme is not allowed (%s)", link)
+	}
+	if parsedURL.Host == "" || parsedURL.User != nil {
+		return fmt.Errorf("url has no host or contains credentials (%s)", link)
+	}
+
+	return nil
+}
+
+// ValidateLinks func for checking count and URLs of the links list.
+func ValidateLinks(links []string) error {
+	// Check count of the links.
+	if len(links) > repository.LinksMaxCount {
+		return fmt.Errorf("too many links (max %d)", repository.LinksMaxCount)
+	}
+
+	// Check each link.
+	for _, link := range links {
+		if err := ValidateURL(link); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LinksHash func for getting MD5 hash (in hex) of the links list joined by new line.
+// The same hash is calculated by PostgreSQL to check, if links of the object were not changed.
+func LinksHash(links []string) string {
+	hash := md5.Sum([]byte(strings.Join(links, "\n")))
+	return hex.EncodeToString(hash[:])
+}
+
+// PublicNetworkTransport func for creating HTTP transport, which denies connections
+// to loopback and private IP addresses (for requests to user-defined URLs).
+// HTTP(S)_PROXY environment variables are ignored by this transport.
+func PublicNetworkTransport(timeout time.Duration) *http.Transport {
+	// Define dialer, which checks IP address before each connection.
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || IsPrivateIP(ip) {
+				return fmt.Errorf("url points to private network (%s)", host)
+			}
+			return nil
+		},
+	}
+
+	// Proxy is not used, because dialer could check only IP address of the proxy.
+	return &http.Transport{
+		Proxy:               nil,
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: timeout,
+	}
+}
+
+// IsPrivateIP func for checking, if the given IP address is not public.
+func IsPrivateIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return true
+	}
+
+	// Check special networks, which are not covered by the net package.
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nonPublicNetworks (private) networks of the shared address space (carrier-grade NAT)
+// and "this network" (0.0.0.0/8 is routed to the local host by some systems).
+var nonPublicNetworks = parseNetworks("100.64.0.0/10", "0.0.0.0/8")
+
+// parseNetworks (private) func for parsing the given CIDR networks.
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+
+	return networks
+}
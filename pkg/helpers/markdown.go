@@ -0,0 +1,171 @@
+package helpers
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/Komentory/utilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/russross/blackfriday/v2"
+)
+
+var (
+	// markdownRawHTMLRegexp (private) regexp for raw HTML tags and comments in Markdown text.
+	markdownRawHTMLRegexp = regexp.MustCompile(`<!--[\s\S]*?-->|</?[a-zA-Z][a-zA-Z0-9-]*(\s[^<>]*)?/?>`)
+
+	// markdownLinkSchemeRegexp (private) regexp for link destinations, reference definitions
+	// (like "[id]: javascript:...") and autolinks with URL scheme. Scheme and colon can be written
+	// with HTML entities or percent-escapes (like "java&#115;cript&colon;"), which are decoded by clients.
+	markdownLinkSchemeRegexp = regexp.MustCompile(
+		`(\]\(\s*<?|(?m:^ {0,3}\[[^\]\n]+\]:\s*<?)|<)([^\s()<>\[\]]*?)(:|&colon;|&#0*58;|&#[xX]0*3[aA];|%3[aA])`,
+	)
+
+	// markdownSchemeRegexp (private) regexp for decoded URL scheme.
+	markdownSchemeRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.\-]*$`)
+
+	// markdownSafeSchemes (private) list of URL schemes allowed in Markdown links.
+	markdownSafeSchemes = []string{"http", "https", "mailto"}
+)
+
+// SanitizeMarkdown func for sanitizing Markdown text before saving to database.
+// Removes control characters, raw HTML tags and comments (outside of code) and
+// links with unsafe URL schemes (like javascript:).
+func SanitizeMarkdown(source string) string {
+	// Normalize line endings and remove control characters.
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	source = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\n' && r != '\t' {
+			return -1
+		}
+		return r
+	}, source)
+
+	// Define builders for result and current text (non-code) chunk.
+	var result, chunk strings.Builder
+
+	// Define fence of the current code block (empty, if outside of code block).
+	fence := ""
+
+	for _, line := range strings.SplitAfter(source, "\n") {
+		// Check, if line opens or closes the fenced code block.
+		trimmedLine := strings.TrimSpace(line)
+		switch {
+		case fence == "" && (strings.HasPrefix(trimmedLine, "```") || strings.HasPrefix(trimmedLine, "~~~")):
+			result.WriteString(sanitizeMarkdownText(chunk.String()))
+			chunk.Reset()
+			fence = trimmedLine[:3]
+			result.WriteString(line)
+		case fence != "":
+			if strings.HasPrefix(trimmedLine, fence) {
+				fence = ""
+			}
+			result.WriteString(line)
+		default:
+			chunk.WriteString(line)
+		}
+	}
+	result.WriteString(sanitizeMarkdownText(chunk.String()))
+
+	return strings.TrimSpace(result.String())
+}
+
+// RenderMarkdown func for rendering Markdown text to safe HTML.
+// Raw HTML is skipped and links with untrusted URL schemes are not rendered.
+func RenderMarkdown(source string) string {
+	// Create a new HTML renderer with safe flags.
+	renderer := blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{
+		Flags: blackfriday.CommonHTMLFlags |
+			blackfriday.SkipHTML |
+			blackfriday.Safelink |
+			blackfriday.NofollowLinks |
+			blackfriday.NoreferrerLinks |
+			blackfriday.NoopenerLinks |
+			blackfriday.HrefTargetBlank,
+	})
+
+	// Render Markdown to HTML.
+	return string(blackfriday.Run(
+		[]byte(source),
+		blackfriday.WithRenderer(renderer),
+		blackfriday.WithExtensions(blackfriday.CommonExtensions),
+	))
+}
+
+// TruncateText func for truncating text (like Markdown description in emails) to the given count of characters.
+// Truncated text ends with ellipsis.
+func TruncateText(text string, maxLength int) string {
+	// Check, if text is short enough.
+	runes := []rune(strings.TrimSpace(text))
+	if len(runes) <= maxLength {
+		return string(runes)
+	}
+
+	return strings.TrimSpace(string(runes[:maxLength-1])) + "…"
+}
+
+// GetDescriptionFormat func for getting format of descriptions from URL query (?format=markdown|html).
+// Returns RenderMarkdown func for html format or nil for markdown (source) format.
+func GetDescriptionFormat(c *fiber.Ctx) (func(string) string, error) {
+	// Switch formats.
+	switch format := c.Query("format", "markdown"); format {
+	case "markdown":
+		return nil, nil
+	case "html":
+		return RenderMarkdown, nil
+	default:
+		return nil, fmt.Errorf("wrong or unsupported format (%s)", format)
+	}
+}
+
+// sanitizeMarkdownText (private) func for sanitizing Markdown text without code blocks.
+func sanitizeMarkdownText(text string) string {
+	// Split text by inline code spans (odd parts are code).
+	parts := strings.Split(text, "`")
+	for i := range parts {
+		// Skip code spans, but not the text after unclosed backtick.
+		if i%2 == 1 && i != len(parts)-1 {
+			continue
+		}
+
+		// Remove raw HTML tags and comments (repeat for nested tags like <scr<b>ipt>).
+		for markdownRawHTMLRegexp.MatchString(parts[i]) {
+			parts[i] = markdownRawHTMLRegexp.ReplaceAllString(parts[i], "")
+		}
+
+		// Replace unsafe URL schemes in links.
+		parts[i] = markdownLinkSchemeRegexp.ReplaceAllStringFunc(parts[i], func(match string) string {
+			groups := markdownLinkSchemeRegexp.FindStringSubmatch(match)
+			scheme := decodeMarkdownScheme(groups[2])
+			if !markdownSchemeRegexp.MatchString(scheme) || utilities.SearchStringInArray(strings.ToLower(scheme), markdownSafeSchemes) {
+				return match
+			}
+			return groups[1] + "#"
+		})
+	}
+
+	return strings.Join(parts, "`")
+}
+
+// decodeMarkdownScheme (private) func for decoding URL scheme like clients do: HTML entities and
+// percent-escapes are decoded, tabs and new lines (removed by browsers from URLs) are skipped.
+func decodeMarkdownScheme(scheme string) string {
+	// Decode HTML entities.
+	scheme = html.UnescapeString(scheme)
+
+	// Decode percent-escapes (scheme with wrong escapes is kept as is).
+	if unescaped, err := url.PathUnescape(scheme); err == nil {
+		scheme = unescaped
+	}
+
+	// Remove spaces and control characters.
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, scheme)
+}
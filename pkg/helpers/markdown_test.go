@@ -0,0 +1,73 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeMarkdown(t *testing.T) {
+	// Define a structure for specifying input and output data of a single test case.
+	tests := []struct {
+		description string
+		source      string
+		expected    string
+	}{
+		{
+			"plain markdown is not changed",
+			"# Title\n\n**bold** and [link](https://komentory.com)",
+			"# Title\n\n**bold** and [link](https://komentory.com)",
+		},
+		{
+			"raw HTML tags are removed",
+			"Hello <script>alert(1)</script> world",
+			"Hello alert(1) world",
+		},
+		{
+			"nested HTML tags are removed",
+			"<scr<b>ipt>alert(1)",
+			"alert(1)",
+		},
+		{
+			"unsafe link schemes are replaced",
+			"[click](javascript:alert(1)) <data:text/html,x>",
+			"[click](#alert(1)) <#text/html,x>",
+		},
+		{
+			"unsafe schemes in reference definitions are replaced",
+			"[click][x] and [y]\n\n[x]: javascript:alert(1)\n  [y]:\n<vbscript:msgbox>\n[ok]: https://komentory.com",
+			"[click][x] and [y]\n\n[x]: #alert(1)\n  [y]:\n<#msgbox>\n[ok]: https://komentory.com",
+		},
+		{
+			"unsafe schemes with HTML entities and percent-escapes are replaced",
+			"[a](java&#115;cript:alert(1)) [b](javascript&colon;alert(1)) [c](java&Tab;script&#x3A;x) [d](%6Aavascript:x)",
+			"[a](#alert(1)) [b](#alert(1)) [c](#x) [d](#x)",
+		},
+		{
+			"links without scheme and safe schemes with entities are kept",
+			"[a](/path?time=10:30) [b](http&#115;://komentory.com) [c](#top)",
+			"[a](/path?time=10:30) [b](http&#115;://komentory.com) [c](#top)",
+		},
+		{
+			"HTML in code blocks is kept",
+			"`<b>` and\n```\n<div></div>\n```",
+			"`<b>` and\n```\n<div></div>\n```",
+		},
+		{
+			"control characters are removed",
+			"line\r\nnext\x00",
+			"line\nnext",
+		},
+	}
+
+	// Iterate through test single test cases.
+	for _, test := range tests {
+		assert.Equalf(t, test.expected, SanitizeMarkdown(test.source), test.description)
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	assert.Equal(t, "short text", TruncateText(" short text ", 10))
+	assert.Equal(t, "long…", TruncateText("long text", 5))
+	assert.Equal(t, "привет…", TruncateText("привет мир", 7))
+}
@@ -0,0 +1,41 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetPagination func for getting limit and offset of the list from URL query (?limit=&offset=).
+// Returns the given default limit, if limit is not set.
+func GetPagination(c *fiber.Ctx, defaultLimit, maxLimit int) (int, int, error) {
+	return parsePagination(c.Query("limit"), c.Query("offset"), defaultLimit, maxLimit)
+}
+
+// parsePagination (private) func for parsing and checking limit and offset values.
+func parsePagination(limit, offset string, defaultLimit, maxLimit int) (int, int, error) {
+	// Define default pagination.
+	limitNumber, offsetNumber := defaultLimit, 0
+
+	// Parse limit, if it was set.
+	if limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value < 1 || value > maxLimit {
+			return 0, 0, fmt.Errorf("limit must be a number between 1 and %d", maxLimit)
+		}
+		limitNumber = value
+	}
+
+	// Parse offset, if it was set.
+	if offset != "" {
+		value, err := strconv.Atoi(offset)
+		if err != nil || value < 0 {
+			return 0, 0, errors.New("offset must be zero or a positive number")
+		}
+		offsetNumber = value
+	}
+
+	return limitNumber, offsetNumber, nil
+}
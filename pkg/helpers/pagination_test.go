@@ -0,0 +1,38 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePagination(t *testing.T) {
+	// Define a structure for specifying input and output data of a single test case.
+	tests := []struct {
+		description    string
+		limit          string
+		offset         string
+		expectedLimit  int
+		expectedOffset int
+		expectedError  bool
+	}{
+		// Successful test cases:
+		{"success: default pagination", "", "", 20, 0, false},
+		{"success: given pagination", "50", "100", 50, 100, false},
+		{"success: max limit", "100", "0", 100, 0, false},
+
+		// Failed test cases:
+		{"fail: zero limit", "0", "", 0, 0, true},
+		{"fail: too big limit", "101", "", 0, 0, true},
+		{"fail: limit is not a number", "ten", "", 0, 0, true},
+		{"fail: negative offset", "", "-1", 0, 0, true},
+	}
+
+	// Iterate through test single test cases.
+	for _, test := range tests {
+		limit, offset, err := parsePagination(test.limit, test.offset, 20, 100)
+		assert.Equalf(t, test.expectedError, err != nil, test.description)
+		assert.Equalf(t, test.expectedLimit, limit, test.description)
+		assert.Equalf(t, test.expectedOffset, offset, test.description)
+	}
+}
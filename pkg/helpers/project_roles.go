@@ -0,0 +1,19 @@
+package helpers
+
+import "Komentory/api/pkg/repository"
+
+// HasProjectRole func for checking, if the given role of the user in the project
+// is the same or higher than the required role (owner > editor > viewer).
+// Empty role (user is not a collaborator) has no access.
+func HasProjectRole(role, requiredRole string) bool {
+	for _, r := range repository.ProjectRoles {
+		if r == role {
+			return true
+		}
+		if r == requiredRole {
+			return false
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,34 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasProjectRole(t *testing.T) {
+	// Define a structure for specifying input and output data of a single test case.
+	tests := []struct {
+		description  string
+		role         string
+		requiredRole string
+		expected     bool
+	}{
+		// Successful test cases:
+		{"success: owner is editor", "owner", "editor", true},
+		{"success: editor is editor", "editor", "editor", true},
+		{"success: editor is viewer", "editor", "viewer", true},
+		{"success: viewer is viewer", "viewer", "viewer", true},
+
+		// Failed test cases:
+		{"fail: editor is not owner", "editor", "owner", false},
+		{"fail: viewer is not editor", "viewer", "editor", false},
+		{"fail: not a collaborator", "", "viewer", false},
+		{"fail: unknown role", "admin", "viewer", false},
+	}
+
+	// Iterate through test single test cases.
+	for _, test := range tests {
+		assert.Equalf(t, test.expected, HasProjectRole(test.role, test.requiredRole), test.description)
+	}
+}
@@ -0,0 +1,169 @@
+package helpers
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/repository"
+	"fmt"
+	"math"
+	"unicode/utf8"
+
+	"github.com/Komentory/utilities"
+)
+
+// ValidateTaskQuestions func for checking the schema of the task questions.
+func ValidateTaskQuestions(questions []models.TaskQuestion) error {
+	// Define map for checking unique question IDs.
+	questionIDs := make(map[string]bool, len(questions))
+
+	for _, question := range questions {
+		// Check, if question ID is unique for this task.
+		if questionIDs[question.ID] {
+			return fmt.Errorf("question id is not unique (%s)", question.ID)
+		}
+		questionIDs[question.ID] = true
+
+		// Switch question types.
+		switch question.Type {
+		case repository.QuestionTypeSingleChoice, repository.QuestionTypeMultipleChoice:
+			// Check, if choice question has enough options.
+			if len(question.Options) < 2 {
+				return fmt.Errorf("question needs at least two options (%s)", question.ID)
+			}
+
+			// Check, if options are unique and not empty.
+			options := make(map[string]bool, len(question.Options))
+			for _, option := range question.Options {
+				if option == "" || options[option] {
+					return fmt.Errorf("question has empty or duplicated option (%s)", question.ID)
+				}
+				options[option] = true
+			}
+		case repository.QuestionTypeRating:
+			// Check, if rating scale is correct.
+			scaleMin, scaleMax := questionScale(question)
+			if scaleMin >= scaleMax {
+				return fmt.Errorf("question has wrong rating scale (%s)", question.ID)
+			}
+		case repository.QuestionTypeShortText:
+			// Check, if max length is not negative.
+			if question.MaxLength < 0 {
+				return fmt.Errorf("question has wrong max length (%s)", question.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateAnswerResponses func for checking the answer responses against the task questions.
+func ValidateAnswerResponses(questions []models.TaskQuestion, responses []models.AnswerResponse) error {
+	// Define map of the task questions by ID.
+	questionsByID := make(map[string]models.TaskQuestion, len(questions))
+	for _, question := range questions {
+		questionsByID[question.ID] = question
+	}
+
+	// Define map for checking unique responses.
+	answered := make(map[string]bool, len(responses))
+
+	for _, response := range responses {
+		// Check, if question with given ID is exists in the task.
+		question, ok := questionsByID[response.QuestionID]
+		if !ok {
+			return fmt.Errorf("question is not found in task (%s)", response.QuestionID)
+		}
+
+		// Check, if question has only one response.
+		if answered[response.QuestionID] {
+			return fmt.Errorf("question has more than one response (%s)", response.QuestionID)
+		}
+		answered[response.QuestionID] = true
+
+		// Check response value by the question type.
+		if err := validateResponseValue(question, response.Value); err != nil {
+			return fmt.Errorf("%s (%s)", err.Error(), response.QuestionID)
+		}
+	}
+
+	// Check, if all required questions have responses.
+	for _, question := range questions {
+		if question.Required && !answered[question.ID] {
+			return fmt.Errorf("question is required (%s)", question.ID)
+		}
+	}
+
+	return nil
+}
+
+// validateResponseValue (private) func for checking one response value by the question type.
+func validateResponseValue(question models.TaskQuestion, value interface{}) error {
+	// Switch question types.
+	switch question.Type {
+	case repository.QuestionTypeRating:
+		// Rating value must be an integer number in the scale.
+		number, ok := value.(float64)
+		if !ok || number != math.Trunc(number) {
+			return fmt.Errorf("rating must be an integer number")
+		}
+		scaleMin, scaleMax := questionScale(question)
+		if int(number) < scaleMin || int(number) > scaleMax {
+			return fmt.Errorf("rating must be between %d and %d", scaleMin, scaleMax)
+		}
+	case repository.QuestionTypeSingleChoice:
+		// Single choice value must be one of the options.
+		choice, ok := value.(string)
+		if !ok || !utilities.SearchStringInArray(choice, question.Options) {
+			return fmt.Errorf("choice must be one of the question options")
+		}
+	case repository.QuestionTypeMultipleChoice:
+		// Multiple choice value must be a non-empty list of unique options.
+		choices, ok := value.([]interface{})
+		if !ok || len(choices) == 0 {
+			return fmt.Errorf("choices must be a non-empty list")
+		}
+		selected := make(map[string]bool, len(choices))
+		for _, item := range choices {
+			choice, ok := item.(string)
+			if !ok || !utilities.SearchStringInArray(choice, question.Options) || selected[choice] {
+				return fmt.Errorf("choices must be unique question options")
+			}
+			selected[choice] = true
+		}
+	case repository.QuestionTypeYesNo:
+		// Yes/no value must be a boolean.
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("value must be true or false")
+		}
+	case repository.QuestionTypeShortText:
+		// Short text value must be a non-empty string with limited length.
+		text, ok := value.(string)
+		if !ok || text == "" {
+			return fmt.Errorf("value must be a non-empty string")
+		}
+		maxLength := question.MaxLength
+		if maxLength == 0 {
+			maxLength = repository.QuestionDefaultMaxLength
+		}
+		if utf8.RuneCountInString(text) > maxLength {
+			return fmt.Errorf("value must be no longer than %d characters", maxLength)
+		}
+	default:
+		// Throw error, if question type is not supported.
+		return fmt.Errorf("wrong or unsupported question type (%s)", question.Type)
+	}
+
+	return nil
+}
+
+// questionScale (private) func for getting the rating scale of the question with defaults.
+func questionScale(question models.TaskQuestion) (int, int) {
+	// Define default scale.
+	scaleMin, scaleMax := repository.QuestionDefaultScaleMin, repository.QuestionDefaultScaleMax
+
+	// Redefine scale, if it set in the question.
+	if question.ScaleMin != 0 || question.ScaleMax != 0 {
+		scaleMin, scaleMax = question.ScaleMin, question.ScaleMax
+	}
+
+	return scaleMin, scaleMax
+}
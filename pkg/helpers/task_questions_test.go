@@ -0,0 +1,142 @@
+package helpers
+
+import (
+	"Komentory/api/app/models"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAnswerResponses(t *testing.T) {
+	// Define test task questions.
+	questions := []models.TaskQuestion{
+		{ID: "rate", Type: "rating", Title: "Rate it", Required: true},
+		{ID: "color", Type: "single_choice", Title: "Color", Options: []string{"red", "green"}},
+		{ID: "tags", Type: "multiple_choice", Title: "Tags", Options: []string{"ui", "ux", "api"}},
+		{ID: "like", Type: "yes_no", Title: "Do you like it?"},
+		{ID: "note", Type: "short_text", Title: "Note", MaxLength: 5},
+	}
+
+	// Define a structure for specifying input and output data of a single test case.
+	tests := []struct {
+		description   string
+		responses     []models.AnswerResponse
+		expectedError bool
+	}{
+		// Successful test cases:
+		{
+			"success: all questions answered",
+			[]models.AnswerResponse{
+				{QuestionID: "rate", Value: float64(4)},
+				{QuestionID: "color", Value: "red"},
+				{QuestionID: "tags", Value: []interface{}{"ui", "api"}},
+				{QuestionID: "like", Value: true},
+				{QuestionID: "note", Value: "good"},
+			},
+			false,
+		},
+		{
+			"success: only required question answered",
+			[]models.AnswerResponse{{QuestionID: "rate", Value: float64(1)}},
+			false,
+		},
+		// Failed test cases:
+		{
+			"fail: required question is not answered",
+			[]models.AnswerResponse{{QuestionID: "like", Value: false}},
+			true,
+		},
+		{
+			"fail: rating is out of scale",
+			[]models.AnswerResponse{{QuestionID: "rate", Value: float64(6)}},
+			true,
+		},
+		{
+			"fail: rating is not an integer",
+			[]models.AnswerResponse{{QuestionID: "rate", Value: 2.5}},
+			true,
+		},
+		{
+			"fail: choice is not in options",
+			[]models.AnswerResponse{{QuestionID: "rate", Value: float64(3)}, {QuestionID: "color", Value: "blue"}},
+			true,
+		},
+		{
+			"fail: duplicated multiple choices",
+			[]models.AnswerResponse{{QuestionID: "rate", Value: float64(3)}, {QuestionID: "tags", Value: []interface{}{"ui", "ui"}}},
+			true,
+		},
+		{
+			"fail: short text is too long",
+			[]models.AnswerResponse{{QuestionID: "rate", Value: float64(3)}, {QuestionID: "note", Value: "too long"}},
+			true,
+		},
+		{
+			"fail: unknown question",
+			[]models.AnswerResponse{{QuestionID: "rate", Value: float64(3)}, {QuestionID: "unknown", Value: true}},
+			true,
+		},
+		{
+			"fail: question answered twice",
+			[]models.AnswerResponse{{QuestionID: "rate", Value: float64(3)}, {QuestionID: "rate", Value: float64(2)}},
+			true,
+		},
+	}
+
+	// Iterate through test single test cases.
+	for index, test := range tests {
+		// Validate responses from the test case.
+		err := ValidateAnswerResponses(questions, test.responses)
+
+		// Checking, if error is expected.
+		assert.Equalf(t, test.expectedError, err != nil, fmt.Sprintf("[%d] need to %s", index+1, test.description))
+	}
+}
+
+func TestValidateTaskQuestions(t *testing.T) {
+	// Define a structure for specifying input and output data of a single test case.
+	tests := []struct {
+		description   string
+		questions     []models.TaskQuestion
+		expectedError bool
+	}{
+		// Successful test cases:
+		{
+			"success: valid questions",
+			[]models.TaskQuestion{
+				{ID: "rate", Type: "rating", Title: "Rate it", ScaleMin: 0, ScaleMax: 10},
+				{ID: "color", Type: "single_choice", Title: "Color", Options: []string{"red", "green"}},
+			},
+			false,
+		},
+		// Failed test cases:
+		{
+			"fail: duplicated question ID",
+			[]models.TaskQuestion{
+				{ID: "rate", Type: "rating", Title: "Rate it"},
+				{ID: "rate", Type: "yes_no", Title: "Yes?"},
+			},
+			true,
+		},
+		{
+			"fail: choice question with one option",
+			[]models.TaskQuestion{{ID: "color", Type: "single_choice", Title: "Color", Options: []string{"red"}}},
+			true,
+		},
+		{
+			"fail: wrong rating scale",
+			[]models.TaskQuestion{{ID: "rate", Type: "rating", Title: "Rate it", ScaleMin: 5, ScaleMax: 1}},
+			true,
+		},
+	}
+
+	// Iterate through test single test cases.
+	for index, test := range tests {
+		// Validate questions from the test case.
+		err := ValidateTaskQuestions(test.questions)
+
+		// Checking, if error is expected.
+		assert.Equalf(t, test.expectedError, err != nil, fmt.Sprintf("[%d] need to %s", index+1, test.description))
+	}
+}
@@ -0,0 +1,81 @@
+package helpers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// GenerateUnsubscribeToken func for generating signed token to unsubscribe the user
+// from the given email stream without login (for links in email footers).
+// Token format: <base64url(user ID:stream)>.<base64url(HMAC-SHA256 signature)>
+func GenerateUnsubscribeToken(userID uuid.UUID, stream string) (string, error) {
+	// Encode payload of the token.
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", userID.String(), stream)))
+
+	// Sign payload of the token.
+	signature, err := signUnsubscribePayload(payload)
+	if err != nil {
+		return "", err
+	}
+
+	return payload + "." + signature, nil
+}
+
+// ParseUnsubscribeToken func for checking signature of the unsubscribe token.
+// Returns user ID and email stream from the token.
+func ParseUnsubscribeToken(token string) (uuid.UUID, string, error) {
+	// Split token to payload and signature.
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return uuid.Nil, "", errors.New("wrong unsubscribe token format")
+	}
+
+	// Check signature of the payload (in constant time).
+	signature, err := signUnsubscribePayload(parts[0])
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+	if !hmac.Equal([]byte(parts[1]), []byte(signature)) {
+		return uuid.Nil, "", errors.New("wrong unsubscribe token signature")
+	}
+
+	// Decode payload of the token.
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return uuid.Nil, "", errors.New("wrong unsubscribe token payload")
+	}
+	values := strings.SplitN(string(payload), ":", 2)
+	if len(values) != 2 {
+		return uuid.Nil, "", errors.New("wrong unsubscribe token payload")
+	}
+
+	// Parse user ID.
+	userID, err := uuid.Parse(values[0])
+	if err != nil {
+		return uuid.Nil, "", errors.New("wrong user ID in unsubscribe token")
+	}
+
+	return userID, values[1], nil
+}
+
+// signUnsubscribePayload (private) func for signing payload of the token with UNSUBSCRIBE_SECRET_KEY.
+// Tokens are never signed or checked with empty key (anyone could sign them).
+func signUnsubscribePayload(payload string) (string, error) {
+	// Get secret key from .env file.
+	secretKey := os.Getenv("UNSUBSCRIBE_SECRET_KEY")
+	if secretKey == "" {
+		return "", errors.New("UNSUBSCRIBE_SECRET_KEY is not set")
+	}
+
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write([]byte(payload))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,49 @@
+package helpers
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnsubscribeToken(t *testing.T) {
+	// Set secret key for signing tokens.
+	os.Setenv("UNSUBSCRIBE_SECRET_KEY", "secret")
+
+	// Generate a new token.
+	userID := uuid.New()
+	token, err := GenerateUnsubscribeToken(userID, "marketing")
+	assert.NoError(t, err)
+
+	// Check, if valid token is parsed.
+	parsedUserID, stream, err := ParseUnsubscribeToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, userID, parsedUserID)
+	assert.Equal(t, "marketing", stream)
+
+	// Check, if token with changed payload is rejected.
+	otherToken, _ := GenerateUnsubscribeToken(uuid.New(), "marketing")
+	_, _, err = ParseUnsubscribeToken(otherToken[:len(otherToken)-43] + token[len(token)-43:])
+	assert.Error(t, err)
+
+	// Check, if token signed by other key is rejected.
+	os.Setenv("UNSUBSCRIBE_SECRET_KEY", "other secret")
+	_, _, err = ParseUnsubscribeToken(token)
+	assert.Error(t, err)
+
+	// Check, if tokens are not signed and checked without key.
+	os.Setenv("UNSUBSCRIBE_SECRET_KEY", "")
+	_, err = GenerateUnsubscribeToken(userID, "marketing")
+	assert.Error(t, err)
+	_, _, err = ParseUnsubscribeToken(token)
+	assert.Error(t, err)
+	os.Setenv("UNSUBSCRIBE_SECRET_KEY", "secret")
+
+	// Check wrong formats.
+	for _, wrongToken := range []string{"", "abc", "a.b.c"} {
+		_, _, err = ParseUnsubscribeToken(wrongToken)
+		assert.Error(t, err)
+	}
+}
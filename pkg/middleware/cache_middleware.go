@@ -23,6 +23,9 @@ func Cached() func(*fiber.Ctx) error {
 		Next: func(c *fiber.Ctx) bool {
 			return c.Query("no-cache") == "true" // if route has query ?no-cache=true, skip caching
 		},
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return c.Path() + "?format=" + c.Query("format") // cache each format of descriptions separately
+		},
 		Expiration:   time.Minute * time.Duration(cacheExpirationMinutesCount),
 		CacheControl: true,
 	}
@@ -24,8 +24,11 @@ func FiberMiddleware(a *fiber.App) {
 			AllowOrigins:     os.Getenv("ALLOW_ORIGINS"),
 			AllowCredentials: true,
 		}),
-		// Add compression.
+		// Add compression (except streams of the Server-Sent Events).
 		compress.New(compress.Config{
+			Next: func(c *fiber.Ctx) bool {
+				return c.Get(fiber.HeaderAccept) == "text/event-stream"
+			},
 			Level: compress.LevelBestSpeed, // 1
 		}),
 		// Add encrypt cookies.
@@ -1,24 +1,34 @@
 package middleware
 
 import (
-	"os"
+	"Komentory/api/platform/auth"
+	"errors"
 
 	"github.com/gofiber/fiber/v2"
-
-	jwtMiddleware "github.com/gofiber/jwt/v2"
 )
 
 // JWTProtected func for specify routes group with JWT authentication.
-// See: https://github.com/gofiber/jwt
+// Tokens are verified by HMAC secret (HS256) or by public keys from JWKS (RS256, ES256),
+// see auth.NewVerifierFromEnv for configuration. Personal access tokens are accepted too.
 func JWTProtected() func(*fiber.Ctx) error {
-	// Create config for JWT authentication middleware.
-	config := jwtMiddleware.Config{
-		SigningKey:   []byte(os.Getenv("JWT_SECRET_KEY")),
-		ContextKey:   "jwt", // used in private routes
-		ErrorHandler: jwtError,
-	}
+	return func(c *fiber.Ctx) error {
+		// Get token from Authorization header.
+		tokenString := auth.ExtractToken(c)
+		if tokenString == "" {
+			return jwtError(c, errors.New("Missing or malformed JWT"))
+		}
+
+		// Parse and verify token.
+		token, err := auth.Authenticate(tokenString)
+		if err != nil {
+			return jwtError(c, err)
+		}
 
-	return jwtMiddleware.New(config)
+		// Save token to locals (used in private routes).
+		c.Locals(auth.ContextKey, token)
+
+		return c.Next()
+	}
 }
 
 func jwtError(c *fiber.Ctx, err error) error {
@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"Komentory/api/platform/auth"
+	"Komentory/api/platform/cache"
+	"Komentory/api/platform/database"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Komentory/utilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// RateLimitCounter interface to describe storage backend for rate limiter counters.
+// Incr must increment counter by the key atomically (concurrent requests of the same user
+// in one window must get different counts) and return count and time of the window reset.
+type RateLimitCounter interface {
+	Incr(key string, window time.Duration) (int, time.Time, error)
+}
+
+// RateLimitStorage storage backend for rate limiter counters.
+// By default, counters are stored in memory of the current API instance.
+// Set RATE_LIMIT_STORAGE to "postgres" in .env file to share counters across multiple API instances.
+var RateLimitStorage RateLimitCounter = cache.NewMemoryStorage()
+
+// SetupRateLimitStorage func for setting storage backend of the rate limiter from .env file.
+func SetupRateLimitStorage() {
+	if os.Getenv("RATE_LIMIT_STORAGE") == "postgres" {
+		RateLimitStorage = PostgresRateLimitCounter{}
+	}
+}
+
+// PostgresRateLimitCounter struct to describe storage of the rate limiter counters in PostgreSQL.
+type PostgresRateLimitCounter struct{}
+
+// Incr method for incrementing counter by the given key in one SQL statement.
+func (PostgresRateLimitCounter) Incr(key string, window time.Duration) (int, time.Time, error) {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return 0, time.Now(), err
+	}
+
+	return db.IncrementRateLimitCounter(key, window)
+}
+
+// RateLimited func for specify route with rate limiting by user ID from JWT (or by IP address).
+// Budget for the route is configured by environment variables:
+//  - RATE_LIMIT_<ROUTE_NAME>_MAX, max count of requests in the window;
+//  - RATE_LIMIT_<ROUTE_NAME>_WINDOW_SECONDS, duration of the window in seconds;
+// If they are not set, RATE_LIMIT_DEFAULT_MAX and RATE_LIMIT_DEFAULT_WINDOW_SECONDS are used.
+// See: https://datatracker.ietf.org/doc/draft-ietf-httpapi-ratelimit-headers
+func RateLimited(routeName string) func(*fiber.Ctx) error {
+	// Define budget for the route.
+	maxRequests, window := rateLimitBudget(routeName)
+
+	return func(c *fiber.Ctx) error {
+		// Define key of the counter for current user.
+		key := fmt.Sprintf("rate_limit:%s:%s", routeName, rateLimitKey(c))
+
+		// Increment counter of the requests in the current window.
+		count, resetAt, err := RateLimitStorage.Incr(key, window)
+		if err != nil {
+			return utilities.CheckForErrorWithStatusCode(c, err, 500, "rate limit storage", err.Error())
+		}
+
+		// Define count of remaining requests and seconds to reset the window.
+		remaining := maxRequests - count
+		if remaining < 0 {
+			remaining = 0
+		}
+		resetSeconds := int(math.Ceil(time.Until(resetAt).Seconds()))
+
+		// Set RateLimit-* headers to response.
+		c.Set("RateLimit-Limit", strconv.Itoa(maxRequests))
+		c.Set("RateLimit-Remaining", strconv.Itoa(remaining))
+		c.Set("RateLimit-Reset", strconv.Itoa(resetSeconds))
+
+		// Check, if budget for the route is exhausted.
+		if count > maxRequests {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(resetSeconds))
+
+			// Return status 429 and too many requests error.
+			return utilities.ThrowJSONErrorWithStatusCode(
+				c, fiber.StatusTooManyRequests, routeName, fmt.Sprintf("try again in %d seconds", resetSeconds),
+			)
+		}
+
+		return c.Next()
+	}
+}
+
+// rateLimitBudget (private) func for getting max count of requests and window for the route from env.
+func rateLimitBudget(routeName string) (int, time.Duration) {
+	// Define default budget.
+	maxRequests, windowSeconds := 60, 60
+
+	// Redefine budget from environment variables (route specific first).
+	for _, prefix := range []string{"RATE_LIMIT_DEFAULT", "RATE_LIMIT_" + strings.ToUpper(routeName)} {
+		if value, err := strconv.Atoi(os.Getenv(prefix + "_MAX")); err == nil && value > 0 {
+			maxRequests = value
+		}
+		if value, err := strconv.Atoi(os.Getenv(prefix + "_WINDOW_SECONDS")); err == nil && value > 0 {
+			windowSeconds = value
+		}
+	}
+
+	return maxRequests, time.Duration(windowSeconds) * time.Second
+}
+
+// rateLimitKey (private) func for getting user ID from JWT or IP address, if JWT is not set.
+func rateLimitKey(c *fiber.Ctx) string {
+	// Check, if JWT was verified by JWTProtected middleware.
+	if token, ok := c.Locals(auth.ContextKey).(*jwt.Token); ok {
+		if claims, ok := token.Claims.(jwt.MapClaims); ok {
+			if userID, ok := claims["id"].(string); ok && userID != "" {
+				return "user:" + userID
+			}
+		}
+	}
+
+	return "ip:" + clientIP(c)
+}
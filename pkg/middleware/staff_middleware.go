@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"Komentory/api/pkg/policy"
+	"Komentory/api/pkg/repository"
+	"Komentory/api/platform/auth"
+
+	"github.com/Komentory/utilities"
+	"github.com/gofiber/fiber/v2"
+)
+
+// StaffProtected func for specify routes only for staff (moderators and admins).
+// Must be used after JWTProtected middleware. Personal access tokens are not accepted.
+func StaffProtected() func(*fiber.Ctx) error {
+	return staffRoleProtected("staff", repository.StaffRoleModerator, repository.StaffRoleAdmin)
+}
+
+// AdminProtected func for specify routes only for admins.
+// Must be used after JWTProtected middleware. Personal access tokens are not accepted.
+func AdminProtected() func(*fiber.Ctx) error {
+	return staffRoleProtected("admin", repository.StaffRoleAdmin)
+}
+
+// staffRoleProtected (private) func for specify routes only for the given staff roles.
+func staffRoleProtected(object string, roles ...string) func(*fiber.Ctx) error {
+	return func(c *fiber.Ctx) error {
+		// Get claims from JWT.
+		claims, err := auth.TokenValidateExpireTime(c)
+		if err != nil {
+			return utilities.CheckForErrorWithStatusCode(c, err, 401, "jwt", err.Error())
+		}
+
+		// Personal access token can't be used for staff actions.
+		if auth.IsPersonalAccessToken(c) {
+			return utilities.ThrowJSONErrorWithStatusCode(c, 403, "personal access token", "you have no permissions")
+		}
+
+		// Only the given staff roles are allowed.
+		if role := policy.StaffRole(claims); role == "" || !utilities.SearchStringInArray(role, roles) {
+			return utilities.ThrowJSONErrorWithStatusCode(c, 403, object, "you have no permissions")
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"Komentory/api/platform/webhooks"
+	"log"
+	"strings"
+
+	"github.com/Komentory/utilities"
+	"github.com/gofiber/fiber/v2"
+)
+
+// WebhookAuthProtected func for specify webhook routes of the given vendor with authentication
+// from the registry (BasicAuth, HMAC signature or shared token with IP allow-list),
+// see webhooks.VendorAuthFromEnv for configuration.
+func WebhookAuthProtected(vendor string) func(*fiber.Ctx) error {
+	return func(c *fiber.Ctx) error {
+		// Check IP and credentials of the request.
+		if err := webhooks.DefaultAuthRegistry.Verify(vendor, &webhooks.InboundRequest{
+			IP:     clientIP(c),
+			Header: func(key string) string { return c.Get(key) },
+			Body:   c.Body(),
+		}); err != nil {
+			log.Printf("webhook auth: %s: %v", vendor, err)
+			return utilities.ThrowJSONErrorWithStatusCode(c, 403, "webhook auth", "you have no permissions")
+		}
+
+		return c.Next()
+	}
+}
+
+// clientIP (private) func for getting IP address of the client. Behind trusted proxy (see SERVER_PROXY_HEADER
+// and SERVER_TRUSTED_PROXIES), header can have list of addresses ("client, proxy1, ..."), where only the last
+// address is added by the trusted proxy (others could be sent by the client).
+func clientIP(c *fiber.Ctx) string {
+	ips := strings.Split(c.IP(), ",")
+	return strings.TrimSpace(ips[len(ips)-1])
+}
@@ -0,0 +1,135 @@
+package policy
+
+import (
+	"Komentory/api/pkg/helpers"
+	"Komentory/api/pkg/repository"
+
+	"github.com/Komentory/utilities"
+	"github.com/google/uuid"
+)
+
+// Action type to describe action, which user performs on the resource.
+type Action string
+
+const (
+	ProjectUpdate              Action = "project:update"
+	ProjectDelete              Action = "project:delete"
+	ProjectViewCollaborators   Action = "project:view_collaborators"
+	ProjectManageCollaborators Action = "project:manage_collaborators"
+	ProjectRemoveCollaborator  Action = "project:remove_collaborator"
+	ProjectManageWebhooks      Action = "project:manage_webhooks"
+	TaskCreate                 Action = "task:create"
+	TaskUpdate                 Action = "task:update"
+	TaskDelete                 Action = "task:delete"
+	TaskViewDrafts             Action = "task:view_drafts"
+	TaskViewResults            Action = "task:view_results"
+	AnswerUpdate               Action = "answer:update"
+	AnswerDelete               Action = "answer:delete"
+	AnswerViewTriage           Action = "answer:view_triage"
+	AnswerTriage               Action = "answer:triage"
+	FileRemove                 Action = "file:remove"
+	PersonalAccessTokenRevoke  Action = "personal_access_token:revoke"
+	ProjectOverrideStatus      Action = "project:override_status"
+	ProjectOverrideDelete      Action = "project:override_delete"
+	TaskOverrideStatus         Action = "task:override_status"
+	TaskOverrideDelete         Action = "task:override_delete"
+	AnswerOverrideStatus       Action = "answer:override_status"
+	AnswerOverrideDelete       Action = "answer:override_delete"
+)
+
+// Rule struct to describe, who may perform the action:
+//  - ProjectRole == lowest role in the project of the resource, "" if project role gives no access;
+//  - Author == true, if author of the resource may perform the action;
+//  - Override == credential for any object (moderators, admins), "" if action can't be overridden;
+type Rule struct {
+	ProjectRole string
+	Author      bool
+	Override    string
+}
+
+// Rules contains rules for all actions. Action without rule is always denied.
+// Override actions are performed only in the admin routes, which write them to the audit log.
+var Rules = map[Action]Rule{
+	ProjectUpdate:              {ProjectRole: repository.ProjectRoleEditor},
+	ProjectDelete:              {ProjectRole: repository.ProjectRoleOwner},
+	ProjectViewCollaborators:   {ProjectRole: repository.ProjectRoleViewer},
+	ProjectManageCollaborators: {ProjectRole: repository.ProjectRoleOwner},
+	ProjectRemoveCollaborator:  {ProjectRole: repository.ProjectRoleOwner, Author: true},
+	ProjectManageWebhooks:      {ProjectRole: repository.ProjectRoleOwner},
+	TaskCreate:                 {ProjectRole: repository.ProjectRoleEditor},
+	TaskUpdate:                 {ProjectRole: repository.ProjectRoleEditor},
+	TaskDelete:                 {ProjectRole: repository.ProjectRoleEditor},
+	TaskViewDrafts:             {ProjectRole: repository.ProjectRoleViewer},
+	TaskViewResults:            {ProjectRole: repository.ProjectRoleViewer},
+	AnswerUpdate:               {Author: true},
+	AnswerDelete:               {Author: true},
+	AnswerViewTriage:           {ProjectRole: repository.ProjectRoleViewer},
+	AnswerTriage:               {ProjectRole: repository.ProjectRoleEditor},
+	FileRemove:                 {Author: true},
+	PersonalAccessTokenRevoke:  {Author: true},
+	ProjectOverrideStatus:      {Override: utilities.GenerateCredential("projects", "update", false)},
+	ProjectOverrideDelete:      {Override: utilities.GenerateCredential("projects", "delete", false)},
+	TaskOverrideStatus:         {Override: utilities.GenerateCredential("tasks", "update", false)},
+	TaskOverrideDelete:         {Override: utilities.GenerateCredential("tasks", "delete", false)},
+	AnswerOverrideStatus:       {Override: utilities.GenerateCredential("answers", "update", false)},
+	AnswerOverrideDelete:       {Override: utilities.GenerateCredential("answers", "delete", false)},
+}
+
+// Resource struct to describe resource, on which action is performed:
+//  - AuthorID == user, who created the resource (answer, file, token) or collaborator to remove;
+//  - ProjectRole == role of the current user in the project of the resource, "" if not a collaborator;
+type Resource struct {
+	AuthorID    uuid.UUID
+	ProjectRole string
+}
+
+// Decision struct to describe result of the authorization:
+//  - Override == true, if action is allowed only by credential for any object;
+type Decision struct {
+	Allowed  bool
+	Override bool
+}
+
+// Decide func for making decision, if user with the given claims may perform the action on the resource.
+func Decide(claims *utilities.TokenMetaData, action Action, resource Resource) Decision {
+	// Checking, if rule for the action is exists.
+	rule, ok := Rules[action]
+	if !ok || claims == nil {
+		return Decision{}
+	}
+
+	// Author of the resource.
+	if rule.Author && resource.AuthorID != uuid.Nil && resource.AuthorID == claims.UserID {
+		return Decision{Allowed: true}
+	}
+
+	// Collaborator of the project with enough role.
+	if rule.ProjectRole != "" && helpers.HasProjectRole(resource.ProjectRole, rule.ProjectRole) {
+		return Decision{Allowed: true}
+	}
+
+	// Moderator or admin with credential for any object.
+	if rule.Override != "" && utilities.SearchStringInArray(rule.Override, claims.Credentials) {
+		return Decision{Allowed: true, Override: true}
+	}
+
+	return Decision{}
+}
+
+// Can func for checking, if user with the given claims may perform the action on the resource.
+func Can(claims *utilities.TokenMetaData, action Action, resource Resource) bool {
+	return Decide(claims, action, resource).Allowed
+}
+
+// StaffRole func for recognising staff role by credentials for any object in the given claims:
+// admin can delete any project, moderator can update status of any project. Empty role for users.
+func StaffRole(claims *utilities.TokenMetaData) string {
+	switch {
+	case Can(claims, ProjectOverrideDelete, Resource{}):
+		return repository.StaffRoleAdmin
+	case Can(claims, ProjectOverrideStatus, Resource{}):
+		return repository.StaffRoleModerator
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,100 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/Komentory/utilities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecide(t *testing.T) {
+	// Define users with credentials of their roles.
+	userCredentials, _ := utilities.GenerateCredentialsByRole(utilities.RoleNameUser)
+	moderatorCredentials, _ := utilities.GenerateCredentialsByRole(utilities.RoleNameModerator)
+	adminCredentials, _ := utilities.GenerateCredentialsByRole(utilities.RoleNameAdmin)
+	user := &utilities.TokenMetaData{UserID: uuid.New(), Credentials: userCredentials}
+	moderator := &utilities.TokenMetaData{UserID: uuid.New(), Credentials: moderatorCredentials}
+	admin := &utilities.TokenMetaData{UserID: uuid.New(), Credentials: adminCredentials}
+	someone := uuid.New()
+
+	// Define a structure for specifying input and output data of a single test case.
+	tests := []struct {
+		description string
+		claims      *utilities.TokenMetaData
+		action      Action
+		resource    Resource
+		expected    Decision
+	}{
+		// Project owners and collaborators:
+		{"owner updates project", user, ProjectUpdate, Resource{ProjectRole: "owner"}, Decision{Allowed: true}},
+		{"editor updates project", user, ProjectUpdate, Resource{ProjectRole: "editor"}, Decision{Allowed: true}},
+		{"viewer can't update project", user, ProjectUpdate, Resource{ProjectRole: "viewer"}, Decision{}},
+		{"editor can't delete project", user, ProjectDelete, Resource{ProjectRole: "editor"}, Decision{}},
+		{"owner deletes project", user, ProjectDelete, Resource{ProjectRole: "owner"}, Decision{Allowed: true}},
+		{"editor can't manage webhooks", user, ProjectManageWebhooks, Resource{ProjectRole: "editor"}, Decision{}},
+		{"viewer sees collaborators", user, ProjectViewCollaborators, Resource{ProjectRole: "viewer"}, Decision{Allowed: true}},
+		{"stranger can't see collaborators", user, ProjectViewCollaborators, Resource{}, Decision{}},
+		{"collaborator leaves project", user, ProjectRemoveCollaborator, Resource{AuthorID: user.UserID, ProjectRole: "viewer"}, Decision{Allowed: true}},
+		{"editor can't remove collaborator", user, ProjectRemoveCollaborator, Resource{AuthorID: someone, ProjectRole: "editor"}, Decision{}},
+		{"editor creates task", user, TaskCreate, Resource{ProjectRole: "editor"}, Decision{Allowed: true}},
+		{"viewer can't delete task", user, TaskDelete, Resource{ProjectRole: "viewer"}, Decision{}},
+		{"viewer sees draft tasks", user, TaskViewDrafts, Resource{ProjectRole: "viewer"}, Decision{Allowed: true}},
+		{"editor triages answer", user, AnswerTriage, Resource{ProjectRole: "editor"}, Decision{Allowed: true}},
+		{"viewer can't triage answer", user, AnswerTriage, Resource{ProjectRole: "viewer"}, Decision{}},
+
+		// Authors:
+		{"author updates answer", user, AnswerUpdate, Resource{AuthorID: user.UserID}, Decision{Allowed: true}},
+		{"project owner can't update answer", user, AnswerUpdate, Resource{AuthorID: someone, ProjectRole: "owner"}, Decision{}},
+		{"author removes file", user, FileRemove, Resource{AuthorID: user.UserID}, Decision{Allowed: true}},
+		{"stranger can't remove file", user, FileRemove, Resource{AuthorID: someone}, Decision{}},
+		{"stranger can't revoke token", user, PersonalAccessTokenRevoke, Resource{AuthorID: someone}, Decision{}},
+
+		// Moderators and admins:
+		{"moderator can't update any project outside admin routes", moderator, ProjectUpdate, Resource{}, Decision{}},
+		{"admin can't delete any project outside admin routes", admin, ProjectDelete, Resource{}, Decision{}},
+		{"moderator updates status of any project", moderator, ProjectOverrideStatus, Resource{}, Decision{Allowed: true, Override: true}},
+		{"moderator can't delete any project", moderator, ProjectOverrideDelete, Resource{}, Decision{}},
+		{"moderator updates status of any answer", moderator, AnswerOverrideStatus, Resource{AuthorID: someone}, Decision{Allowed: true, Override: true}},
+		{"moderator can't delete any answer", moderator, AnswerOverrideDelete, Resource{AuthorID: someone}, Decision{}},
+		{"admin deletes any project", admin, ProjectOverrideDelete, Resource{}, Decision{Allowed: true, Override: true}},
+		{"admin deletes any task", admin, TaskOverrideDelete, Resource{}, Decision{Allowed: true, Override: true}},
+		{"admin deletes own answer without override", admin, AnswerDelete, Resource{AuthorID: admin.UserID}, Decision{Allowed: true}},
+		{"admin can't manage webhooks of any project", admin, ProjectManageWebhooks, Resource{}, Decision{}},
+		{"admin can't remove file of other user", admin, FileRemove, Resource{AuthorID: someone}, Decision{}},
+		{"user can't override status of own project", user, ProjectOverrideStatus, Resource{ProjectRole: "owner"}, Decision{}},
+
+		// Unknown actions and users:
+		{"unknown action", admin, Action("project:transfer"), Resource{ProjectRole: "owner"}, Decision{}},
+		{"no claims", nil, ProjectUpdate, Resource{ProjectRole: "owner"}, Decision{}},
+		{"empty author", &utilities.TokenMetaData{}, AnswerUpdate, Resource{}, Decision{}},
+	}
+
+	// Iterate through test single test cases.
+	for _, test := range tests {
+		assert.Equalf(t, test.expected, Decide(test.claims, test.action, test.resource), test.description)
+		assert.Equalf(t, test.expected.Allowed, Can(test.claims, test.action, test.resource), test.description)
+	}
+}
+
+func TestStaffRole(t *testing.T) {
+	// Define a structure for specifying input and output data of a single test case.
+	tests := []struct {
+		description string
+		role        int
+		expected    string
+	}{
+		{"admin", utilities.RoleNameAdmin, "admin"},
+		{"moderator", utilities.RoleNameModerator, "moderator"},
+		{"user", utilities.RoleNameUser, ""},
+	}
+
+	// Iterate through test single test cases.
+	for _, test := range tests {
+		credentials, _ := utilities.GenerateCredentialsByRole(test.role)
+		assert.Equalf(t, test.expected, StaffRole(&utilities.TokenMetaData{UserID: uuid.New(), Credentials: credentials}), test.description)
+	}
+
+	// Without claims.
+	assert.Equal(t, "", StaffRole(nil))
+}
@@ -0,0 +1,10 @@
+package repository
+
+const (
+	// AnswerEventCreated const for the event of a new active answer.
+	AnswerEventCreated string = "answer.created"
+	// AnswerEventUpdated const for the event of the updated (or restored by moderator) answer.
+	AnswerEventUpdated string = "answer.updated"
+	// AnswerEventDeleted const for the event of the deleted (hidden or unpublished) answer.
+	AnswerEventDeleted string = "answer.deleted"
+)
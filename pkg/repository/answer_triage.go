@@ -0,0 +1,34 @@
+package repository
+
+const (
+	// AnswerLabelBug const for the bug label of the answer.
+	AnswerLabelBug string = "bug"
+	// AnswerLabelIdea const for the idea label of the answer.
+	AnswerLabelIdea string = "idea"
+	// AnswerLabelPraise const for the praise label of the answer.
+	AnswerLabelPraise string = "praise"
+	// AnswerLabelQuestion const for the question label of the answer.
+	AnswerLabelQuestion string = "question"
+)
+
+const (
+	// AnswerStateNew const for the new workflow state of the answer.
+	AnswerStateNew string = "new"
+	// AnswerStateAcknowledged const for the acknowledged workflow state of the answer.
+	AnswerStateAcknowledged string = "acknowledged"
+	// AnswerStateInProgress const for the in progress workflow state of the answer.
+	AnswerStateInProgress string = "in_progress"
+	// AnswerStateResolved const for the resolved workflow state of the answer.
+	AnswerStateResolved string = "resolved"
+	// AnswerStateWontFix const for the won't fix workflow state of the answer.
+	AnswerStateWontFix string = "wont_fix"
+)
+
+var (
+	// AnswerLabels list of all allowed labels of the answer.
+	AnswerLabels = []string{AnswerLabelBug, AnswerLabelIdea, AnswerLabelPraise, AnswerLabelQuestion}
+	// AnswerStates list of all allowed workflow states of the answer.
+	AnswerStates = []string{
+		AnswerStateNew, AnswerStateAcknowledged, AnswerStateInProgress, AnswerStateResolved, AnswerStateWontFix,
+	}
+)
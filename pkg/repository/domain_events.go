@@ -0,0 +1,26 @@
+package repository
+
+const (
+	// DomainAggregateProject const for the project aggregate of the domain events.
+	DomainAggregateProject string = "project"
+	// DomainAggregateTask const for the task aggregate of the domain events.
+	DomainAggregateTask string = "task"
+	// DomainAggregateAnswer const for the answer aggregate of the domain events.
+	DomainAggregateAnswer string = "answer"
+)
+
+const (
+	// DomainEventCreated const for the domain event of a new aggregate.
+	DomainEventCreated string = "created"
+	// DomainEventUpdated const for the domain event of the updated aggregate.
+	DomainEventUpdated string = "updated"
+	// DomainEventDeleted const for the domain event of the deleted aggregate.
+	DomainEventDeleted string = "deleted"
+	// DomainEventStatusChanged const for the domain event of the changed status (or workflow state) of the aggregate.
+	DomainEventStatusChanged string = "status_changed"
+)
+
+const (
+	// DomainEventsChannel const for the PostgreSQL NOTIFY channel, which wakes up the outbox dispatchers.
+	DomainEventsChannel string = "domain_events"
+)
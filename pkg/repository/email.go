@@ -0,0 +1,43 @@
+package repository
+
+const (
+	// EmailStreamTransactional const for the transactional emails stream (like "forgot password").
+	EmailStreamTransactional string = "transactional"
+	// EmailStreamMarketing const for the marketing emails stream (like "invite friends and get X").
+	EmailStreamMarketing string = "marketing"
+)
+
+const (
+	// EmailEventHardBounce const for the hard bounce (email address is not exists or inactive).
+	EmailEventHardBounce string = "hard_bounce"
+	// EmailEventSoftBounce const for the soft (temporary) bounce.
+	EmailEventSoftBounce string = "soft_bounce"
+	// EmailEventSpamComplaint const for the spam complaint of the recipient.
+	EmailEventSpamComplaint string = "spam_complaint"
+	// EmailEventDelivery const for the successful delivery of the email.
+	EmailEventDelivery string = "delivery"
+	// EmailEventOpen const for the opening of the email by recipient.
+	EmailEventOpen string = "open"
+)
+
+const (
+	// EmailTemplateNewAnswer const for the template of the new answer on the user's project.
+	EmailTemplateNewAnswer string = "new_answer"
+	// EmailTemplateAnswerAccepted const for the template of the user's answer, acknowledged by project owner.
+	EmailTemplateAnswerAccepted string = "answer_accepted"
+	// EmailTemplateWeeklyDigest const for the template of the weekly digest.
+	EmailTemplateWeeklyDigest string = "weekly_digest"
+)
+
+const (
+	// EmailMessageStatusPending const for the queued email, which is waiting for (next) sending attempt.
+	EmailMessageStatusPending string = "pending"
+	// EmailMessageStatusSending const for the email, which is sending now.
+	EmailMessageStatusSending string = "sending"
+	// EmailMessageStatusSent const for the email, which was accepted by email provider.
+	EmailMessageStatusSent string = "sent"
+	// EmailMessageStatusSkipped const for the email, which was not sent by subscriptions or deliverability state.
+	EmailMessageStatusSkipped string = "skipped"
+	// EmailMessageStatusFailed const for the email, which was failed on all sending attempts.
+	EmailMessageStatusFailed string = "failed"
+)
@@ -0,0 +1,24 @@
+package repository
+
+const (
+	// LinkObjectProject const for the project object with links (website URL).
+	LinkObjectProject string = "project"
+	// LinkObjectTask const for the task object with links.
+	LinkObjectTask string = "task"
+	// LinkObjectAnswer const for the answer object with links.
+	LinkObjectAnswer string = "answer"
+)
+
+const (
+	// LinkMaxLength const for the maximum length of one link.
+	LinkMaxLength int = 2048
+	// LinksMaxCount const for the maximum count of links in one object.
+	LinksMaxCount int = 10
+	// LinkMetadataTitleMaxLength const for the maximum length of the fetched link title.
+	LinkMetadataTitleMaxLength int = 255
+	// LinkMetadataDescriptionMaxLength const for the maximum length of the fetched link description.
+	LinkMetadataDescriptionMaxLength int = 1024
+)
+
+// LinkSafeSchemes list of URL schemes allowed in links.
+var LinkSafeSchemes = []string{"http", "https"}
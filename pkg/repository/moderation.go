@@ -0,0 +1,39 @@
+package repository
+
+const (
+	// ReportReasonSpam const for the spam reason of the report.
+	ReportReasonSpam string = "spam"
+	// ReportReasonAbuse const for the abuse reason of the report.
+	ReportReasonAbuse string = "abuse"
+	// ReportReasonOffTopic const for the off-topic reason of the report.
+	ReportReasonOffTopic string = "off_topic"
+	// ReportReasonOther const for the other reason of the report.
+	ReportReasonOther string = "other"
+)
+
+const (
+	// ModerationActionHide const for the hide action of the moderator.
+	ModerationActionHide string = "hide"
+	// ModerationActionRestore const for the restore action of the moderator.
+	ModerationActionRestore string = "restore"
+	// ModerationActionDelete const for the delete action of the moderator.
+	ModerationActionDelete string = "delete"
+	// ModerationActionWarn const for the warn author action of the moderator.
+	ModerationActionWarn string = "warn"
+)
+
+const (
+	// StaffActionUpdateStatus const for the update status action of the staff (moderator or admin).
+	StaffActionUpdateStatus string = "update_status"
+	// StaffActionDelete const for the delete action of the staff (admin).
+	StaffActionDelete string = "delete"
+)
+
+const (
+	// AuditObjectProject const for the project object type in the audit log.
+	AuditObjectProject string = "project"
+	// AuditObjectTask const for the task object type in the audit log.
+	AuditObjectTask string = "task"
+	// AuditObjectAnswer const for the answer object type in the audit log.
+	AuditObjectAnswer string = "answer"
+)
@@ -0,0 +1,27 @@
+package repository
+
+const (
+	// NotificationTypeNewAnswer const for the notification about a new answer to the user's task.
+	NotificationTypeNewAnswer string = "new_answer"
+	// NotificationTypeAnswerStatusChanged const for the notification about changed workflow state, status or visibility of the user's answer.
+	NotificationTypeAnswerStatusChanged string = "answer_status_changed"
+	// NotificationTypeProjectInvitation const for the notification about invitation of the user to the project.
+	NotificationTypeProjectInvitation string = "project_invitation"
+	// NotificationTypeModerationWarning const for the notification about warning of the moderator
+	// (it can't be disabled in the user settings).
+	NotificationTypeModerationWarning string = "moderation_warning"
+)
+
+const (
+	// NotificationObjectAnswer const for the answer object type in the notification.
+	NotificationObjectAnswer string = "answer"
+	// NotificationObjectProject const for the project object type in the notification.
+	NotificationObjectProject string = "project"
+)
+
+var (
+	// NotificationTypes list of all notification types (used for preferences in the user settings).
+	NotificationTypes = []string{
+		NotificationTypeNewAnswer, NotificationTypeAnswerStatusChanged, NotificationTypeProjectInvitation,
+	}
+)
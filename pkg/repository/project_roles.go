@@ -0,0 +1,16 @@
+package repository
+
+const (
+	// ProjectRoleOwner const for the role of the project creator and co-owners
+	// (can delete project, manage webhooks and collaborators).
+	ProjectRoleOwner string = "owner"
+	// ProjectRoleEditor const for the role of the collaborator, who can edit project and its tasks.
+	ProjectRoleEditor string = "editor"
+	// ProjectRoleViewer const for the role of the collaborator, who can see drafts and results of the tasks.
+	ProjectRoleViewer string = "viewer"
+)
+
+var (
+	// ProjectRoles list of all roles of the project collaborators (from the highest to the lowest).
+	ProjectRoles = []string{ProjectRoleOwner, ProjectRoleEditor, ProjectRoleViewer}
+)
@@ -0,0 +1,37 @@
+package repository
+
+const (
+	// ProjectEventUpdated const for the event of the updated project.
+	ProjectEventUpdated string = "project.updated"
+	// ProjectEventDeleted const for the event of the deleted project.
+	ProjectEventDeleted string = "project.deleted"
+	// TaskEventCreated const for the event of a new task.
+	TaskEventCreated string = "task.created"
+	// TaskEventUpdated const for the event of the updated task.
+	TaskEventUpdated string = "task.updated"
+	// TaskEventDeleted const for the event of the deleted task.
+	TaskEventDeleted string = "task.deleted"
+	// ProjectWebhookEventPing const for the test event, which is sent only by request of the project owner.
+	ProjectWebhookEventPing string = "ping"
+)
+
+const (
+	// ProjectWebhookDeliveryStatusPending const for the delivery, which is waiting for (next) attempt.
+	ProjectWebhookDeliveryStatusPending string = "pending"
+	// ProjectWebhookDeliveryStatusSending const for the delivery, which is sending now.
+	ProjectWebhookDeliveryStatusSending string = "sending"
+	// ProjectWebhookDeliveryStatusDelivered const for the delivery, which was accepted by endpoint (2xx response).
+	ProjectWebhookDeliveryStatusDelivered string = "delivered"
+	// ProjectWebhookDeliveryStatusFailed const for the delivery, which was failed on all attempts.
+	ProjectWebhookDeliveryStatusFailed string = "failed"
+)
+
+var (
+	// ProjectWebhookEvents list of all events, which can be subscribed by the project webhook
+	// (there is no "project.created" event, because webhooks are registered on the existing project).
+	ProjectWebhookEvents = []string{
+		ProjectEventUpdated, ProjectEventDeleted,
+		TaskEventCreated, TaskEventUpdated, TaskEventDeleted,
+		AnswerEventCreated, AnswerEventUpdated, AnswerEventDeleted,
+	}
+)
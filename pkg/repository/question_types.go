@@ -0,0 +1,23 @@
+package repository
+
+const (
+	// QuestionTypeRating const for the rating scale question type.
+	QuestionTypeRating string = "rating"
+	// QuestionTypeSingleChoice const for the single choice question type.
+	QuestionTypeSingleChoice string = "single_choice"
+	// QuestionTypeMultipleChoice const for the multiple choice question type.
+	QuestionTypeMultipleChoice string = "multiple_choice"
+	// QuestionTypeYesNo const for the yes/no question type.
+	QuestionTypeYesNo string = "yes_no"
+	// QuestionTypeShortText const for the short text question type.
+	QuestionTypeShortText string = "short_text"
+)
+
+const (
+	// QuestionDefaultScaleMin const for the default minimum value of the rating scale.
+	QuestionDefaultScaleMin int = 1
+	// QuestionDefaultScaleMax const for the default maximum value of the rating scale.
+	QuestionDefaultScaleMax int = 5
+	// QuestionDefaultMaxLength const for the default maximum length of the short text.
+	QuestionDefaultMaxLength int = 255
+)
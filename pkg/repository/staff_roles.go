@@ -0,0 +1,8 @@
+package repository
+
+const (
+	// StaffRoleAdmin const for the role of the staff, who can update status and delete any project, task or answer.
+	StaffRoleAdmin string = "admin"
+	// StaffRoleModerator const for the role of the staff, who can update status of any project, task or answer.
+	StaffRoleModerator string = "moderator"
+)
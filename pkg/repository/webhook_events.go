@@ -0,0 +1,35 @@
+package repository
+
+const (
+	// WebhookVendorPostmark const for the Postmark webhooks vendor.
+	WebhookVendorPostmark string = "postmark"
+)
+
+const (
+	// WebhookEventPostmarkSubscriptionChange const for the Postmark subscription change webhook.
+	WebhookEventPostmarkSubscriptionChange string = "postmark_subscription_change"
+	// WebhookEventPostmarkBounce const for the Postmark bounce and spam complaint webhooks.
+	WebhookEventPostmarkBounce string = "postmark_bounce"
+	// WebhookEventPostmarkDelivery const for the Postmark delivery and open webhooks.
+	WebhookEventPostmarkDelivery string = "postmark_delivery"
+)
+
+const (
+	// WebhookEventStatusPending const for the received event, which is waiting for processing.
+	WebhookEventStatusPending string = "pending"
+	// WebhookEventStatusProcessing const for the event, which is processing now.
+	WebhookEventStatusProcessing string = "processing"
+	// WebhookEventStatusProcessed const for the successfully processed event.
+	WebhookEventStatusProcessed string = "processed"
+	// WebhookEventStatusFailed const for the event, which was failed on processing.
+	WebhookEventStatusFailed string = "failed"
+)
+
+const (
+	// WebhookAuthBasicAuth const for the BasicAuth strategy of the inbound webhooks authentication.
+	WebhookAuthBasicAuth string = "basic_auth"
+	// WebhookAuthHMAC const for the HMAC signature header strategy of the inbound webhooks authentication.
+	WebhookAuthHMAC string = "hmac"
+	// WebhookAuthToken const for the shared token header strategy of the inbound webhooks authentication.
+	WebhookAuthToken string = "token"
+)
@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"Komentory/api/app/controllers"
+	"Komentory/api/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminRoutes func for describe group of admin routes (only for admins, status overrides also for moderators).
+// Must be registered before private routes.
+func AdminRoutes(a *fiber.App) {
+	// Create routes group.
+	r := a.Group("/v1/admin", middleware.JWTProtected())
+
+	// Define middlewares for the roles of the staff.
+	admin, staff := middleware.AdminProtected(), middleware.StaffProtected()
+
+	// Routes for GET method:
+	r.Get("/audit/logs", admin, controllers.GetAuditLogs)         // get records of the audit log
+	r.Get("/webhook/events", admin, controllers.GetWebhookEvents) // get received webhook events
+
+	// Routes for POST method:
+	r.Post("/webhook/event/replay", admin, controllers.ReplayWebhookEvent) // return webhook event to the processing queue
+	r.Post("/user/tokens/revoke", admin, controllers.RevokeUserTokens)     // revoke all tokens of the user
+
+	// Routes for PATCH method (for moderators and admins):
+	r.Patch("/project/status", staff, controllers.OverrideProjectStatus) // update status of any project
+	r.Patch("/task/status", staff, controllers.OverrideTaskStatus)       // update status of any task
+	r.Patch("/answer/status", staff, controllers.OverrideAnswerStatus)   // update status of any answer
+
+	// Routes for DELETE method:
+	r.Delete("/project", admin, controllers.OverrideDeleteProject) // delete any project
+	r.Delete("/task", admin, controllers.OverrideDeleteTask)       // delete any task
+	r.Delete("/answer", admin, controllers.OverrideDeleteAnswer)   // delete any answer
+}
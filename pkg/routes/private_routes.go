@@ -12,22 +12,53 @@ func PrivateRoutes(a *fiber.App) {
 	// Create routes group.
 	r := a.Group("/v1", middleware.JWTProtected())
 
+	// Routes for GET method:
+	r.Get("/task/:task_id/results", controllers.GetTaskResultsByTaskID)                       // get aggregated results of task questions
+	r.Get("/moderation/answers", controllers.GetModerationQueueAnswers)                       // get answers from moderation queue
+	r.Get("/me/settings", controllers.GetUserSettings)                                        // get settings of the current user
+	r.Get("/me/notifications", controllers.GetNotifications)                                  // get notifications of the current user
+	r.Get("/me/notifications/unread", controllers.GetUnreadNotificationsCount)                // get count of unread notifications
+	r.Get("/project/:project_id/webhooks", controllers.GetProjectWebhooks)                    // get webhooks of the project
+	r.Get("/project/webhook/:webhook_id/deliveries", controllers.GetProjectWebhookDeliveries) // get delivery log of the project webhook
+	r.Get("/me/tokens", controllers.GetPersonalAccessTokens)                                  // get personal access tokens of the current user
+	r.Get("/project/:project_id/collaborators", controllers.GetProjectCollaborators)          // get collaborators of the project
+	r.Get("/project/:project_id/tasks/drafts", controllers.GetDraftTasksByProjectID)          // get draft tasks of the project
+
+	// Routes for POST method (with rate limiting):
+	r.Post("/create/project", middleware.RateLimited("create_project"), controllers.CreateNewProject)                                       // create a new project
+	r.Post("/create/task", middleware.RateLimited("create_task"), controllers.CreateNewTask)                                                // create a new task
+	r.Post("/create/answer", middleware.RateLimited("create_answer"), controllers.CreateNewAnswer)                                          // create a new answer
+	r.Post("/create/answer/report", middleware.RateLimited("create_answer_report"), controllers.CreateNewAnswerReport)                      // report one answer to moderators
+	r.Post("/project/webhook/ping", middleware.RateLimited("ping_project_webhook"), controllers.PingProjectWebhook)                         // send test event to the project webhook
+	r.Post("/me/token", middleware.RateLimited("create_personal_access_token"), controllers.CreateNewPersonalAccessToken)                   // create a new personal access token
+	r.Post("/create/project/webhook", middleware.RateLimited("create_project_webhook"), controllers.CreateNewProjectWebhook)                // register a new webhook of the project
+	r.Post("/create/project/collaborator", middleware.RateLimited("create_project_collaborator"), controllers.CreateNewProjectCollaborator) // invite a new collaborator to the project
+
 	// Routes for POST method:
-	r.Post("/create/project", controllers.CreateNewProject) // create a new project
-	r.Post("/create/task", controllers.CreateNewTask)       // create a new task
-	r.Post("/create/answer", controllers.CreateNewAnswer)   // create a new answer
+	r.Post("/me/logout", controllers.RevokeCurrentToken) // revoke the token of the current request
 
 	// Routes for PATCH method:
-	r.Patch("/update/project", controllers.UpdateProject) // update one project
-	r.Patch("/update/task", controllers.UpdateTask)       // update one task
-	r.Patch("/update/answer", controllers.UpdateAnswer)   // update one answer
+	r.Patch("/update/project", controllers.UpdateProject)                          // update one project
+	r.Patch("/update/task", controllers.UpdateTask)                                // update one task
+	r.Patch("/update/answer", controllers.UpdateAnswer)                            // update one answer
+	r.Patch("/update/project/webhook", controllers.UpdateProjectWebhook)           // update one project webhook
+	r.Patch("/update/project/collaborator", controllers.UpdateProjectCollaborator) // change role of the project collaborator
+	r.Patch("/update/answer/triage", controllers.UpdateAnswerTriage)               // update triage of one answer
+	r.Patch("/moderation/answer", controllers.ModerateAnswer)                      // hide, restore, delete answer or warn author
+	r.Patch("/me/profile", controllers.UpdateUserProfile)                          // update profile of the current user
+	r.Patch("/me/settings", controllers.UpdateUserSettings)                        // update settings of the current user
+	r.Patch("/me/notification/read", controllers.MarkNotificationRead)             // mark one notification as read
+	r.Patch("/me/notifications/read", controllers.MarkAllNotificationsRead)        // mark all notifications as read
 
-	// Routes for PUT method:
-	r.Put("/cdn/upload", controllers.PutFileToCDN) // upload file object to CDN
+	// Routes for PUT method (with rate limiting):
+	r.Put("/cdn/upload", middleware.RateLimited("cdn_upload"), controllers.PutFileToCDN) // upload file object to CDN
 
 	// Routes for DELETE method:
-	r.Delete("/delete/project", controllers.DeleteProject) // delete one project
-	r.Delete("/delete/task", controllers.DeleteTask)       // delete one task
-	r.Delete("/delete/answer", controllers.DeleteAnswer)   // delete one answer
-	r.Delete("/cdn/remove", controllers.RemoveFileFromCDN) // remove one file from CDN
+	r.Delete("/delete/project", controllers.DeleteProject)                          // delete one project
+	r.Delete("/delete/task", controllers.DeleteTask)                                // delete one task
+	r.Delete("/delete/answer", controllers.DeleteAnswer)                            // delete one answer
+	r.Delete("/delete/project/webhook", controllers.DeleteProjectWebhook)           // delete one project webhook
+	r.Delete("/cdn/remove", controllers.RemoveFileFromCDN)                          // remove one file from CDN
+	r.Delete("/delete/project/collaborator", controllers.DeleteProjectCollaborator) // remove collaborator from the project
+	r.Delete("/me/token", controllers.RevokePersonalAccessToken)                    // revoke one personal access token
 }
@@ -24,7 +24,16 @@ func PublicRoutes(a *fiber.App) {
 	r.Get("/project/:project_id/answers", controllers.GetAnswersByProjectID) // get answers by project ID
 	r.Get("/task/:task_id/answers", controllers.GetAnswersByTaskID)          // get answers by task ID
 
+	// Routes for GET method (answer streams by Server-Sent Events or WebSocket):
+	r.Get("/project/:project_id/answers/stream", controllers.StreamAnswersByProjectID) // stream answer events by project ID
+	r.Get("/task/:task_id/answers/stream", controllers.StreamAnswersByTaskID)          // stream answer events by task ID
+
 	// Routes for GET method (single, non-cached):
 	r.Get("/task/:task_id", controllers.GetTaskByID)       // get one task by ID
 	r.Get("/answer/:answer_id", controllers.GetAnswerByID) // get one answer by ID
+	r.Get("/user/:user_id", controllers.GetUserByID)       // get public profile of one user by ID
+
+	// Routes for unsubscribe by signed token (links and one-click POST from email footers):
+	r.Get("/unsubscribe", controllers.ConfirmUnsubscribeUser) // render page to confirm unsubscribe (changes nothing)
+	r.Post("/unsubscribe", controllers.UnsubscribeUser)       // unsubscribe user from email stream (RFC 8058)
 }
@@ -43,6 +43,16 @@ func TestPublicRoutes(t *testing.T) {
 			"GET", fmt.Sprintf("/v1/project/%s", uuid.New().String()),
 			404,
 		},
+		{
+			"fail: get user by wrong id",
+			"GET", "/v1/user/wrong-id",
+			400,
+		},
+		{
+			"fail: unsubscribe with wrong token",
+			"GET", "/v1/unsubscribe?token=wrong",
+			400,
+		},
 	}
 
 	// Define Fiber app.
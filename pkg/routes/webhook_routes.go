@@ -3,6 +3,9 @@ package routes
 import (
 	"Komentory/api/app/controllers"
 	"Komentory/api/pkg/middleware"
+	"Komentory/api/pkg/repository"
+	"Komentory/api/platform/webhooks"
+	"log"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -12,6 +15,18 @@ func WebhookRoutes(a *fiber.App) {
 	// Create routes group.
 	r := a.Group("/v1/webhook")
 
-	// Routes for POST method (with BasicAuth):
-	r.Post("/postmark/subscriptions", middleware.BasicAuthProtected(), controllers.UpdateUserSubscriptions) // update email subscriptions
+	// Register processors of the received events.
+	webhooks.DefaultProcessor.Register(repository.WebhookEventPostmarkSubscriptionChange, controllers.ProcessPostmarkSubscriptionChange)
+	webhooks.DefaultProcessor.Register(repository.WebhookEventPostmarkBounce, controllers.ProcessPostmarkBounce)
+	webhooks.DefaultProcessor.Register(repository.WebhookEventPostmarkDelivery, controllers.ProcessPostmarkDelivery)
+
+	// Register authentication of the vendors (webhooks of not configured vendor are denied).
+	if err := webhooks.DefaultAuthRegistry.RegisterFromEnv(repository.WebhookVendorPostmark); err != nil {
+		log.Printf("webhook auth: %v", err)
+	}
+
+	// Routes for POST method (with vendor authentication):
+	r.Post("/postmark/subscriptions", middleware.WebhookAuthProtected(repository.WebhookVendorPostmark), controllers.UpdateUserSubscriptions) // update email subscriptions
+	r.Post("/postmark/bounces", middleware.WebhookAuthProtected(repository.WebhookVendorPostmark), controllers.UpdateUserBounces)             // save bounces and spam complaints
+	r.Post("/postmark/deliveries", middleware.WebhookAuthProtected(repository.WebhookVendorPostmark), controllers.UpdateUserDeliveries)       // save deliveries and opens
 }
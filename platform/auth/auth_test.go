@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// encode (private) func for encoding number of the JWK.
+func encode(n *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(n.Bytes())
+}
+
+// jwks (private) func for creating JWKS document with the given keys.
+func jwks(keys ...JWK) []byte {
+	data, _ := json.Marshal(map[string][]JWK{"keys": keys})
+	return data
+}
+
+// sign (private) func for creating token with the given signing method, key and kid.
+func sign(method jwt.SigningMethod, key interface{}, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	tokenString, _ := token.SignedString(key)
+	return tokenString
+}
+
+func TestVerifierParse(t *testing.T) {
+	// Create RSA and EC keys.
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	rsaJWK := JWK{KeyType: "RSA", KeyID: "rsa-1", Use: "sig", N: encode(rsaKey.N), E: encode(big.NewInt(int64(rsaKey.E)))}
+	ecJWK := JWK{KeyType: "EC", KeyID: "ec-1", Curve: "P-256", X: encode(ecKey.X), Y: encode(ecKey.Y)}
+
+	// Write JWKS to the file.
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	assert.NoError(t, os.WriteFile(file, jwks(rsaJWK, ecJWK), 0o600))
+
+	// Create verifier.
+	verifier := &Verifier{
+		SecretKey: []byte("secret"),
+		KeySet:    NewKeySet(file, time.Hour),
+		Issuer:    "https://auth.komentory.com",
+		Audience:  "api",
+	}
+	claims := func(iss, aud string) jwt.MapClaims {
+		return jwt.MapClaims{"id": uuid.NewString(), "expire": float64(time.Now().Add(time.Hour).Unix()), "iss": iss, "aud": aud}
+	}
+	valid := claims("https://auth.komentory.com", "api")
+
+	tests := []struct {
+		name    string
+		token   string
+		isValid bool
+	}{
+		{"RS256", sign(jwt.SigningMethodRS256, rsaKey, "rsa-1", valid), true},
+		{"ES256", sign(jwt.SigningMethodES256, ecKey, "ec-1", valid), true},
+		{"HS256", sign(jwt.SigningMethodHS256, []byte("secret"), "", valid), true},
+		{"HS256 with other secret", sign(jwt.SigningMethodHS256, []byte("other"), "", valid), false},
+		{"RS256 with unknown kid", sign(jwt.SigningMethodRS256, rsaKey, "rsa-2", valid), false},
+		{"RS256 with kid of EC key", sign(jwt.SigningMethodRS256, rsaKey, "ec-1", valid), false},
+		{"RS384 is not allowed", sign(jwt.SigningMethodRS384, rsaKey, "rsa-1", valid), false},
+		{"wrong issuer", sign(jwt.SigningMethodRS256, rsaKey, "rsa-1", claims("https://evil.com", "api")), false},
+		{"wrong audience", sign(jwt.SigningMethodES256, ecKey, "ec-1", claims("https://auth.komentory.com", "cdn")), false},
+	}
+
+	for _, test := range tests {
+		_, err := verifier.Parse(test.token)
+		assert.Equalf(t, test.isValid, err == nil, test.name)
+	}
+}
+
+func TestKeySetRotation(t *testing.T) {
+	// Create old and new RSA keys.
+	oldKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	newKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	oldJWK := JWK{KeyType: "RSA", KeyID: "old", Algorithm: "RS256", N: encode(oldKey.N), E: encode(big.NewInt(int64(oldKey.E)))}
+	newJWK := JWK{KeyType: "RSA", KeyID: "new", Algorithm: "RS256", N: encode(newKey.N), E: encode(big.NewInt(int64(newKey.E)))}
+
+	// Create JWKS server, which publishes only old key.
+	document, requests := jwks(oldJWK), 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		_, _ = w.Write(document)
+	}))
+	defer server.Close()
+
+	keySet := NewKeySet(server.URL, time.Hour)
+	keySet.MinRefreshInterval = 0
+	verifier := &Verifier{KeySet: keySet}
+	claims := jwt.MapClaims{"id": uuid.NewString(), "expire": float64(time.Now().Add(time.Hour).Unix())}
+
+	// Token with old key is valid, keys are cached.
+	for i := 0; i < 3; i++ {
+		_, err := verifier.Parse(sign(jwt.SigningMethodRS256, oldKey, "old", claims))
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, requests)
+
+	// Publish new key: token with unknown kid reloads keys.
+	document = jwks(oldJWK, newJWK)
+	_, err := verifier.Parse(sign(jwt.SigningMethodRS256, newKey, "new", claims))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests)
+
+	// Remove old key: cached keys are used until refresh interval.
+	document = jwks(newJWK)
+	_, err = verifier.Parse(sign(jwt.SigningMethodRS256, oldKey, "old", claims))
+	assert.NoError(t, err)
+
+	keySet.RefreshInterval = 0
+	_, err = verifier.Parse(sign(jwt.SigningMethodRS256, oldKey, "old", claims))
+	assert.Error(t, err)
+
+	// Failed reload keeps cached keys.
+	document = []byte("not json")
+	_, err = verifier.Parse(sign(jwt.SigningMethodRS256, newKey, "new", claims))
+	assert.NoError(t, err)
+}
+
+func TestTokenMetaData(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		claims  jwt.MapClaims
+		isValid bool
+	}{
+		{"expire claim", jwt.MapClaims{"id": userID.String(), "expire": float64(100), "credentials": []interface{}{"answers:create"}}, true},
+		{"standard exp claim", jwt.MapClaims{"id": userID.String(), "exp": float64(time.Now().Add(time.Hour).Unix())}, true},
+		{"no user ID", jwt.MapClaims{"expire": float64(100)}, false},
+		{"no expire time", jwt.MapClaims{"id": userID.String()}, false},
+	}
+
+	for _, test := range tests {
+		metaData, err := TokenMetaData(&jwt.Token{Claims: test.claims, Valid: true})
+		assert.Equalf(t, test.isValid, err == nil, test.name)
+		if test.isValid {
+			assert.Equalf(t, userID, metaData.UserID, test.name)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JWK struct to describe one public key of the JWKS document.
+// Only RSA (for RS256) and EC P-256 (for ES256) signing keys are supported.
+// See: https://datatracker.ietf.org/doc/html/rfc7517
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	Y         string `json:"y"`
+}
+
+// publicKey (private) struct to describe parsed public key with its signing algorithm.
+type publicKey struct {
+	algorithm string
+	key       interface{}
+}
+
+// KeySet struct to describe cached public keys from the JWKS document (local file or URL).
+// Keys are identified by kid, so the auth service can publish a new key before signing tokens
+// with it and remove the old key after all tokens, signed with it, are expired.
+//  - RefreshInterval, keys older than this interval are reloaded on the next request;
+//  - MinRefreshInterval, min interval between reloads, when token has unknown kid;
+type KeySet struct {
+	Source             string
+	RefreshInterval    time.Duration
+	MinRefreshInterval time.Duration
+	Client             *http.Client
+
+	refreshMu   sync.Mutex
+	mu          sync.RWMutex
+	keys        map[string]publicKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+// NewKeySet func for create a new key set for the given JWKS source (path to file or http(s) URL).
+func NewKeySet(source string, refreshInterval time.Duration) *KeySet {
+	return &KeySet{
+		Source:             source,
+		RefreshInterval:    refreshInterval,
+		MinRefreshInterval: 30 * time.Second,
+		Client:             &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Key method for getting public key by kid for the given signing algorithm of the token.
+// Keys are reloaded, when they are stale or kid is unknown (for keys, published after the last reload).
+// If reload is failed, cached keys are used until they are reloaded successfully.
+func (s *KeySet) Key(kid, algorithm string) (interface{}, error) {
+	// Get key from cache.
+	key, found, stale, attemptedAt := s.cached(kid)
+
+	// Reload keys, if needed.
+	if stale || (!found && time.Since(attemptedAt) >= s.MinRefreshInterval) {
+		if err := s.refreshOnce(attemptedAt); err != nil {
+			log.Printf("auth: failed to refresh JWKS from %s: %v", s.Source, err)
+		}
+		key, found, _, _ = s.cached(kid)
+	}
+
+	// Check, if key is found and can be used for the algorithm.
+	if !found {
+		return nil, fmt.Errorf("signing key %q is not found", kid)
+	}
+	if key.algorithm != algorithm {
+		return nil, fmt.Errorf("signing key %q is not for %s", kid, algorithm)
+	}
+
+	return key.key, nil
+}
+
+// Refresh method for reloading keys from the source.
+func (s *KeySet) Refresh() error {
+	// Set time of the attempt (even for failed attempt, so the source is not requested on each token).
+	s.mu.Lock()
+	s.attemptedAt = time.Now()
+	s.mu.Unlock()
+
+	// Load and parse JWKS document.
+	data, err := s.load()
+	if err != nil {
+		return err
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return err
+	}
+
+	// Replace keys in cache.
+	s.mu.Lock()
+	s.keys, s.fetchedAt = keys, time.Now()
+	s.mu.Unlock()
+
+	return nil
+}
+
+// cached (private) method for getting key from cache with state of the cache.
+func (s *KeySet) cached(kid string) (publicKey, bool, bool, time.Time) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, found := s.keys[kid]
+	stale := s.keys == nil || (time.Since(s.fetchedAt) >= s.RefreshInterval && time.Since(s.attemptedAt) >= s.MinRefreshInterval)
+
+	return key, found, stale, s.attemptedAt
+}
+
+// refreshOnce (private) method for reloading keys only once for concurrent requests,
+// which saw the cache at the same attempt.
+func (s *KeySet) refreshOnce(attemptedAt time.Time) error {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	// Check, if keys were already reloaded by other request.
+	s.mu.RLock()
+	reloaded := s.attemptedAt.After(attemptedAt)
+	s.mu.RUnlock()
+	if reloaded {
+		return nil
+	}
+
+	return s.Refresh()
+}
+
+// load (private) method for reading JWKS document from the file or URL.
+func (s *KeySet) load() ([]byte, error) {
+	// Read local file.
+	if !strings.HasPrefix(s.Source, "http://") && !strings.HasPrefix(s.Source, "https://") {
+		return os.ReadFile(s.Source)
+	}
+
+	// Request document by URL.
+	resp, err := s.Client.Get(s.Source)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Check status of the response.
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("JWKS is responded with status %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
+
+// parseJWKS (private) func for parsing signing keys from the JWKS document.
+// Keys for encryption and keys with unsupported type or curve are skipped.
+func parseJWKS(data []byte) (map[string]publicKey, error) {
+	// Decode document.
+	document := struct {
+		Keys []JWK `json:"keys"`
+	}{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	// Parse each key.
+	keys := map[string]publicKey{}
+	for _, jwk := range document.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			log.Printf("auth: JWKS key %q is skipped: %v", jwk.KeyID, err)
+			continue
+		}
+		keys[jwk.KeyID] = key
+	}
+
+	// Check, if document has at least one signing key.
+	if len(keys) == 0 {
+		return nil, errors.New("JWKS has no supported signing keys")
+	}
+
+	return keys, nil
+}
+
+// publicKey (private) method for parsing RSA or EC public key from the JWK.
+func (k JWK) publicKey() (publicKey, error) {
+	switch k.KeyType {
+	case "RSA":
+		// Decode modulus and exponent.
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return publicKey{}, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil || !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31 {
+			return publicKey{}, errors.New("RSA exponent is not valid")
+		}
+
+		return k.withAlgorithm("RS256", &rsa.PublicKey{N: n, E: int(e.Int64())})
+	case "EC":
+		// Check curve (only P-256 is used for ES256).
+		if k.Curve != "P-256" {
+			return publicKey{}, fmt.Errorf("curve %q is not supported", k.Curve)
+		}
+
+		// Decode coordinates and check, if point is on the curve.
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return publicKey{}, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return publicKey{}, err
+		}
+		if !elliptic.P256().IsOnCurve(x, y) {
+			return publicKey{}, errors.New("EC point is not on the curve")
+		}
+
+		return k.withAlgorithm("ES256", &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y})
+	default:
+		return publicKey{}, fmt.Errorf("key type %q is not supported", k.KeyType)
+	}
+}
+
+// withAlgorithm (private) method for checking algorithm of the JWK (if it's set) for the key type.
+func (k JWK) withAlgorithm(algorithm string, key interface{}) (publicKey, error) {
+	if k.Algorithm != "" && k.Algorithm != algorithm {
+		return publicKey{}, fmt.Errorf("algorithm %q is not supported", k.Algorithm)
+	}
+
+	return publicKey{algorithm: algorithm, key: key}, nil
+}
+
+// decodeBigInt (private) func for decoding base64url encoded big-endian number of the JWK.
+func decodeBigInt(value string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil || len(b) == 0 {
+		return nil, errors.New("key parameter is not valid base64url")
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/platform/database"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+// PersonalAccessTokenPrefix prefix of the personal access tokens (to distinguish them from JWT).
+const PersonalAccessTokenPrefix string = "kpat_"
+
+// PersonalAccessTokenStore interface for finding active personal access tokens and saving their usage.
+type PersonalAccessTokenStore interface {
+	FindActivePersonalAccessTokenByHash(hash string) (models.PersonalAccessToken, error)
+	TouchPersonalAccessToken(id uuid.UUID, usedAt time.Time) error
+}
+
+// DefaultPersonalAccessTokenStore store used by the private routes.
+var DefaultPersonalAccessTokenStore PersonalAccessTokenStore = &databaseStore{}
+
+// GeneratePersonalAccessToken func for generating a new random personal access token.
+// Returns token (to show it to the user only once) and its hash (to save it to the database).
+func GeneratePersonalAccessToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	token := PersonalAccessTokenPrefix + hex.EncodeToString(b)
+
+	return token, HashPersonalAccessToken(token), nil
+}
+
+// HashPersonalAccessToken func for hashing personal access token by SHA-256.
+// Token has 256 bits of entropy, so slow password hashing is not needed.
+func HashPersonalAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsPersonalAccessToken func for checking, if current request is authenticated by personal access token.
+func IsPersonalAccessToken(c *fiber.Ctx) bool {
+	token, ok := c.Locals(ContextKey).(*jwt.Token)
+	if !ok {
+		return strings.HasPrefix(ExtractToken(c), PersonalAccessTokenPrefix)
+	}
+
+	_, ok = PersonalAccessTokenID(token)
+
+	return ok
+}
+
+// PersonalAccessTokenID func for getting ID of the personal access token from the verified token.
+// Returns false, if token is JWT.
+func PersonalAccessTokenID(token *jwt.Token) (uuid.UUID, bool) {
+	claims, _ := token.Claims.(jwt.MapClaims)
+	id, _ := claims["pat_id"].(string)
+	patID, err := uuid.Parse(id)
+
+	return patID, err == nil
+}
+
+// ParsePersonalAccessToken func for finding active personal access token and saving its usage.
+// Returns token with the same claims as JWT ("id", "expire", "iat", "credentials") and "pat_id" claim,
+// so it can be used by all private routes.
+func ParsePersonalAccessToken(tokenString string) (*jwt.Token, error) {
+	// Find token by hash.
+	pat, err := DefaultPersonalAccessTokenStore.FindActivePersonalAccessTokenByHash(HashPersonalAccessToken(tokenString))
+	if err == sql.ErrNoRows {
+		return nil, errors.New("personal access token is not valid, expired or revoked")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Save time of the last usage (failure is not a reason to reject the request).
+	if err := DefaultPersonalAccessTokenStore.TouchPersonalAccessToken(pat.ID, time.Now()); err != nil {
+		log.Printf("auth: failed to save usage of the personal access token %s: %v", pat.ID, err)
+	}
+
+	// Define credentials of the token.
+	credentials := make([]interface{}, len(pat.Credentials))
+	for i, credential := range pat.Credentials {
+		credentials[i] = credential
+	}
+
+	return &jwt.Token{
+		Header: map[string]interface{}{"typ": "PAT"},
+		Claims: jwt.MapClaims{
+			"id":          pat.UserID.String(),
+			"expire":      float64(pat.ExpiresAt.Unix()),
+			"iat":         float64(pat.CreatedAt.Unix()),
+			"credentials": credentials,
+			"pat_id":      pat.ID.String(),
+		},
+		Valid: true,
+	}, nil
+}
+
+// databaseStore (private) struct to describe personal access tokens in the database.
+type databaseStore struct{}
+
+// FindActivePersonalAccessTokenByHash method for finding active token in the database.
+func (s *databaseStore) FindActivePersonalAccessTokenByHash(hash string) (models.PersonalAccessToken, error) {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return models.PersonalAccessToken{}, err
+	}
+
+	return db.FindActivePersonalAccessTokenByHash(hash)
+}
+
+// TouchPersonalAccessToken method for saving time of the last usage in the database.
+func (s *databaseStore) TouchPersonalAccessToken(id uuid.UUID, usedAt time.Time) error {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return err
+	}
+
+	return db.TouchPersonalAccessToken(id, usedAt)
+}
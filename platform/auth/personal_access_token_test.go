@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"Komentory/api/app/models"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubStore (private) struct to describe personal access tokens in memory.
+type stubStore struct {
+	tokens []models.PersonalAccessToken
+	used   map[uuid.UUID]time.Time
+}
+
+// FindActivePersonalAccessTokenByHash method for finding active token in memory.
+func (s *stubStore) FindActivePersonalAccessTokenByHash(hash string) (models.PersonalAccessToken, error) {
+	for _, t := range s.tokens {
+		if t.TokenHash == hash && t.RevokedAt == nil && t.ExpiresAt.After(time.Now()) {
+			return t, nil
+		}
+	}
+	return models.PersonalAccessToken{}, sql.ErrNoRows
+}
+
+// TouchPersonalAccessToken method for saving time of the last usage in memory.
+func (s *stubStore) TouchPersonalAccessToken(id uuid.UUID, usedAt time.Time) error {
+	s.used[id] = usedAt
+	return nil
+}
+
+func TestParsePersonalAccessToken(t *testing.T) {
+	// Generate tokens.
+	active, activeHash, err := GeneratePersonalAccessToken()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(active, PersonalAccessTokenPrefix))
+	assert.Equal(t, activeHash, HashPersonalAccessToken(active))
+	revoked, revokedHash, _ := GeneratePersonalAccessToken()
+	expired, expiredHash, _ := GeneratePersonalAccessToken()
+
+	// Replace default store.
+	now, userID := time.Now(), uuid.New()
+	store := &stubStore{
+		tokens: []models.PersonalAccessToken{
+			{ID: uuid.New(), UserID: userID, TokenHash: activeHash, Credentials: []string{"tasks:create:own"}, ExpiresAt: now.Add(time.Hour)},
+			{ID: uuid.New(), UserID: userID, TokenHash: revokedHash, ExpiresAt: now.Add(time.Hour), RevokedAt: &now},
+			{ID: uuid.New(), UserID: userID, TokenHash: expiredHash, ExpiresAt: now.Add(-time.Hour)},
+		},
+		used: map[uuid.UUID]time.Time{},
+	}
+	defaultStore := DefaultPersonalAccessTokenStore
+	DefaultPersonalAccessTokenStore = store
+	defer func() { DefaultPersonalAccessTokenStore = defaultStore }()
+	SetDefaultRevocations(NewRevocations(&stubRevocationStore{}, time.Minute))
+	defer SetDefaultRevocations(nil)
+
+	// Active token has the same metadata as JWT and its usage is saved.
+	token, err := Authenticate(active)
+	assert.NoError(t, err)
+	metaData, err := TokenMetaData(token)
+	assert.NoError(t, err)
+	assert.Equal(t, userID, metaData.UserID)
+	assert.Equal(t, []string{"tasks:create:own"}, metaData.Credentials)
+	assert.Contains(t, store.used, store.tokens[0].ID)
+
+	// Revoked, expired and unknown tokens are rejected.
+	for _, tokenString := range []string{revoked, expired, PersonalAccessTokenPrefix + "unknown"} {
+		_, err := Authenticate(tokenString)
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,310 @@
+package auth
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/platform/database"
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+// RevocationStore interface for checking revoked tokens (by "jti" claim and by user).
+type RevocationStore interface {
+	IsTokenRevoked(jti string) (bool, error)
+	GetUserTokensIssuedBefore(userID uuid.UUID) (time.Time, error)
+}
+
+// Revocations struct to describe checker of the revoked tokens with in-process cache.
+// Revocations, made by other API instances, are applied after CacheTTL.
+type Revocations struct {
+	CacheTTL time.Duration
+
+	store  RevocationStore
+	mu     sync.Mutex
+	tokens map[string]cachedRevocation
+	users  map[string]cachedRevocation
+}
+
+// cachedRevocation (private) struct to describe cached state of the token or user.
+type cachedRevocation struct {
+	revoked            bool
+	tokensIssuedBefore time.Time
+	cachedAt           time.Time
+}
+
+var (
+	defaultRevocationsMu sync.Mutex
+	defaultRevocations   *Revocations
+)
+
+// maxCachedRevocations max count of the cached tokens (or users), before expired entries are removed.
+const maxCachedRevocations int = 10000
+
+// DefaultRevocations func for getting revocations checker, used by the private routes.
+// Cache TTL is set by JWT_REVOCATION_CACHE_SECONDS environment variable (by default, 30 seconds).
+func DefaultRevocations() *Revocations {
+	defaultRevocationsMu.Lock()
+	defer defaultRevocationsMu.Unlock()
+
+	if defaultRevocations == nil {
+		cacheSeconds, err := strconv.Atoi(os.Getenv("JWT_REVOCATION_CACHE_SECONDS"))
+		if err != nil || cacheSeconds < 0 {
+			cacheSeconds = 30
+		}
+		defaultRevocations = NewRevocations(&databaseStore{}, time.Duration(cacheSeconds)*time.Second)
+	}
+
+	return defaultRevocations
+}
+
+// SetDefaultRevocations func for replacing default revocations checker (for tests and custom setups).
+func SetDefaultRevocations(revocations *Revocations) {
+	defaultRevocationsMu.Lock()
+	defer defaultRevocationsMu.Unlock()
+
+	defaultRevocations = revocations
+}
+
+// NewRevocations func for create a new revocations checker with the given store and cache TTL.
+func NewRevocations(store RevocationStore, cacheTTL time.Duration) *Revocations {
+	return &Revocations{
+		CacheTTL: cacheTTL,
+		store:    store,
+		tokens:   map[string]cachedRevocation{},
+		users:    map[string]cachedRevocation{},
+	}
+}
+
+// Check method for checking, if verified token was revoked by "jti" claim
+// or by revocation of all tokens of the user.
+func (r *Revocations) Check(token *jwt.Token) error {
+	// Get claims of the token.
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return errors.New("token claims are not valid")
+	}
+
+	// Check, if token is revoked by jti.
+	if jti, _ := claims["jti"].(string); jti != "" {
+		revoked, err := r.isTokenRevoked(jti)
+		if err != nil {
+			return err
+		}
+		if revoked {
+			return errors.New("token was revoked")
+		}
+	}
+
+	// Check, if all tokens of the user are revoked.
+	id, _ := claims["id"].(string)
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return errors.New("token has no valid user ID")
+	}
+	tokensIssuedBefore, err := r.userTokensIssuedBefore(userID)
+	if err != nil {
+		return err
+	}
+	if !tokensIssuedBefore.IsZero() {
+		// Token without usable issue time is revoked too (fail closed).
+		issuedAt := IssuedAt(claims)
+		if issuedAt.IsZero() || issuedAt.Before(tokensIssuedBefore) {
+			return errors.New("token was revoked")
+		}
+	}
+
+	return nil
+}
+
+// Forget method for removing cached state of the token (after it was revoked by this API instance).
+func (r *Revocations) Forget(jti string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.tokens, jti)
+}
+
+// ForgetUser method for removing cached state of the user (after tokens were revoked by this API instance).
+func (r *Revocations) ForgetUser(userID uuid.UUID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.users, userID.String())
+}
+
+// isTokenRevoked (private) method for checking revoked token by jti in cache or in store.
+func (r *Revocations) isTokenRevoked(jti string) (bool, error) {
+	// Get state from cache.
+	r.mu.Lock()
+	cached, ok := r.tokens[jti]
+	r.mu.Unlock()
+	if ok && time.Since(cached.cachedAt) < r.CacheTTL {
+		return cached.revoked, nil
+	}
+
+	// Get state from store.
+	revoked, err := r.store.IsTokenRevoked(jti)
+	if err != nil {
+		return false, err
+	}
+
+	// Save state to cache.
+	r.mu.Lock()
+	pruneRevocations(r.tokens, r.CacheTTL)
+	r.tokens[jti] = cachedRevocation{revoked: revoked, cachedAt: time.Now()}
+	r.mu.Unlock()
+
+	return revoked, nil
+}
+
+// userTokensIssuedBefore (private) method for getting time of the user revocation from cache or store.
+func (r *Revocations) userTokensIssuedBefore(userID uuid.UUID) (time.Time, error) {
+	// Get state from cache.
+	r.mu.Lock()
+	cached, ok := r.users[userID.String()]
+	r.mu.Unlock()
+	if ok && time.Since(cached.cachedAt) < r.CacheTTL {
+		return cached.tokensIssuedBefore, nil
+	}
+
+	// Get state from store.
+	tokensIssuedBefore, err := r.store.GetUserTokensIssuedBefore(userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// Save state to cache.
+	r.mu.Lock()
+	pruneRevocations(r.users, r.CacheTTL)
+	r.users[userID.String()] = cachedRevocation{tokensIssuedBefore: tokensIssuedBefore, cachedAt: time.Now()}
+	r.mu.Unlock()
+
+	return tokensIssuedBefore, nil
+}
+
+// pruneRevocations (private) func for removing expired entries, when cache is too big.
+func pruneRevocations(cache map[string]cachedRevocation, ttl time.Duration) {
+	if len(cache) < maxCachedRevocations {
+		return
+	}
+
+	for key, cached := range cache {
+		if time.Since(cached.cachedAt) >= ttl {
+			delete(cache, key)
+		}
+	}
+}
+
+// IssuedAt func for getting issue time of the token from "iat" claim.
+// Tokens of the auth service without "iat" claim are issued JWT_SECRET_KEY_EXPIRE_MINUTES_COUNT
+// minutes before their expire time. Returns zero time, if issue time is unknown.
+func IssuedAt(claims jwt.MapClaims) time.Time {
+	// Get time from "iat" claim.
+	if iat, ok := claims["iat"].(float64); ok {
+		return time.Unix(int64(iat), 0)
+	}
+
+	// Get expire time.
+	expire, ok := claims["expire"].(float64)
+	if !ok {
+		if expire, ok = claims["exp"].(float64); !ok {
+			return time.Time{}
+		}
+	}
+
+	// Define lifetime of the token (issue time is unknown without valid lifetime).
+	expireMinutes, err := strconv.Atoi(os.Getenv("JWT_SECRET_KEY_EXPIRE_MINUTES_COUNT"))
+	if err != nil || expireMinutes <= 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(int64(expire), 0).Add(-time.Duration(expireMinutes) * time.Minute)
+}
+
+// IsTokenRevoked method for checking revoked token in the database.
+func (s *databaseStore) IsTokenRevoked(jti string) (bool, error) {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return false, err
+	}
+
+	return db.IsTokenRevoked(jti)
+}
+
+// GetUserTokensIssuedBefore method for getting time of the user revocation from the database.
+func (s *databaseStore) GetUserTokensIssuedBefore(userID uuid.UUID) (time.Time, error) {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return db.GetUserTokensIssuedBefore(userID)
+}
+
+// RevokeToken func for revoking one verified token by "jti" claim (until its expire time).
+func RevokeToken(token *jwt.Token) error {
+	// Get claims of the token.
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return errors.New("token claims are not valid")
+	}
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		return errors.New("token has no jti claim")
+	}
+	metaData, err := TokenMetaData(token)
+	if err != nil {
+		return err
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return err
+	}
+
+	// Revoke token.
+	if err := db.RevokeToken(&models.RevokedToken{
+		JTI:       jti,
+		CreatedAt: time.Now(),
+		UserID:    metaData.UserID,
+		ExpiresAt: time.Unix(metaData.Expire, 0),
+	}); err != nil {
+		return err
+	}
+
+	// Remove cached state of the token.
+	DefaultRevocations().Forget(jti)
+
+	return nil
+}
+
+// RevokeUserTokens func for revoking all tokens of the user (JWT and personal access tokens), issued before now.
+func RevokeUserTokens(userID uuid.UUID, revokedBy *uuid.UUID) error {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return err
+	}
+
+	// Revoke tokens of the user.
+	if err := db.RevokeUserTokens(&models.UserTokenRevocation{
+		UserID:             userID,
+		TokensIssuedBefore: time.Now(),
+		RevokedBy:          revokedBy,
+	}); err != nil {
+		return err
+	}
+
+	// Remove cached state of the user.
+	DefaultRevocations().ForgetUser(userID)
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubRevocationStore (private) struct to describe revoked tokens in memory.
+type stubRevocationStore struct {
+	tokens  map[string]bool
+	users   map[uuid.UUID]time.Time
+	queries int
+}
+
+// IsTokenRevoked method for checking revoked token in memory.
+func (s *stubRevocationStore) IsTokenRevoked(jti string) (bool, error) {
+	s.queries++
+	return s.tokens[jti], nil
+}
+
+// GetUserTokensIssuedBefore method for getting time of the user revocation from memory.
+func (s *stubRevocationStore) GetUserTokensIssuedBefore(userID uuid.UUID) (time.Time, error) {
+	s.queries++
+	return s.users[userID], nil
+}
+
+func TestRevocationsCheck(t *testing.T) {
+	// Define users and revocations.
+	now, revokedUser, activeUser := time.Now(), uuid.New(), uuid.New()
+	store := &stubRevocationStore{
+		tokens: map[string]bool{"leaked": true},
+		users:  map[uuid.UUID]time.Time{revokedUser: now},
+	}
+	revocations := NewRevocations(store, time.Minute)
+	token := func(userID uuid.UUID, jti string, issuedAt time.Time) *jwt.Token {
+		return &jwt.Token{Claims: jwt.MapClaims{"id": userID.String(), "jti": jti, "iat": float64(issuedAt.Unix())}, Valid: true}
+	}
+
+	tests := []struct {
+		name      string
+		token     *jwt.Token
+		isRevoked bool
+	}{
+		{"active token", token(activeUser, "a", now), false},
+		{"revoked by jti", token(activeUser, "leaked", now), true},
+		{"issued before user revocation", token(revokedUser, "b", now.Add(-time.Minute)), true},
+		{"issued after user revocation", token(revokedUser, "c", now.Add(time.Minute)), false},
+		{"without issue time after user revocation", &jwt.Token{Claims: jwt.MapClaims{"id": revokedUser.String(), "jti": "d"}, Valid: true}, true},
+		{"without issue time and user revocation", &jwt.Token{Claims: jwt.MapClaims{"id": activeUser.String(), "jti": "e"}, Valid: true}, false},
+	}
+
+	for _, test := range tests {
+		assert.Equalf(t, test.isRevoked, revocations.Check(test.token) != nil, test.name)
+	}
+
+	// Revocations are cached until TTL.
+	queries := store.queries
+	store.tokens["a"] = true
+	assert.NoError(t, revocations.Check(token(activeUser, "a", now)))
+	assert.Equal(t, queries, store.queries)
+
+	// Forgotten token is checked in store again.
+	revocations.Forget("a")
+	assert.Error(t, revocations.Check(token(activeUser, "a", now)))
+}
+
+func TestIssuedAt(t *testing.T) {
+	// Set lifetime of the tokens without "iat" claim.
+	t.Setenv("JWT_SECRET_KEY_EXPIRE_MINUTES_COUNT", "15")
+	expire := time.Unix(1700000000, 0)
+
+	assert.Equal(t, expire, IssuedAt(jwt.MapClaims{"iat": float64(expire.Unix()), "expire": float64(expire.Unix())}))
+	assert.Equal(t, expire.Add(-15*time.Minute), IssuedAt(jwt.MapClaims{"expire": float64(expire.Unix())}))
+	assert.True(t, IssuedAt(jwt.MapClaims{}).IsZero())
+
+	// Issue time is unknown without valid lifetime of the tokens.
+	t.Setenv("JWT_SECRET_KEY_EXPIRE_MINUTES_COUNT", "")
+	assert.True(t, IssuedAt(jwt.MapClaims{"expire": float64(expire.Unix())}).IsZero())
+	t.Setenv("JWT_SECRET_KEY_EXPIRE_MINUTES_COUNT", "fifteen")
+	assert.True(t, IssuedAt(jwt.MapClaims{"expire": float64(expire.Unix())}).IsZero())
+}
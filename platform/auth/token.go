@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Komentory/utilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+// ContextKey key of the verified JWT in the locals of the request (set by JWTProtected middleware).
+const ContextKey string = "jwt"
+
+// ExtractToken func for getting token from the "Authorization: Bearer <token>" header.
+func ExtractToken(c *fiber.Ctx) string {
+	// Get Authorization header.
+	header := c.Get(fiber.HeaderAuthorization)
+
+	// Check, if header has Bearer scheme.
+	if len(header) > 7 && strings.EqualFold(header[:7], "Bearer ") {
+		return strings.TrimSpace(header[7:])
+	}
+
+	return ""
+}
+
+// Authenticate func for verifying token from Authorization header: personal access token
+// (by PersonalAccessTokenPrefix) or JWT of the default verifier. Revoked tokens are rejected.
+func Authenticate(tokenString string) (*jwt.Token, error) {
+	// Parse and verify token.
+	var token *jwt.Token
+	var err error
+	if strings.HasPrefix(tokenString, PersonalAccessTokenPrefix) {
+		token, err = ParsePersonalAccessToken(tokenString)
+	} else {
+		token, err = DefaultVerifier().Parse(tokenString)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Check, if token was revoked.
+	if err := DefaultRevocations().Check(token); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// CurrentToken func for getting token, verified by JWTProtected middleware
+// (or from Authorization header, if route is not protected by the middleware).
+func CurrentToken(c *fiber.Ctx) (*jwt.Token, error) {
+	// Get verified token from locals.
+	if token, ok := c.Locals(ContextKey).(*jwt.Token); ok {
+		return token, nil
+	}
+
+	// Get token from header.
+	tokenString := ExtractToken(c)
+	if tokenString == "" {
+		return nil, errors.New("token is not verifiably")
+	}
+
+	// Parse and verify token.
+	return Authenticate(tokenString)
+}
+
+// ExtractTokenMetaData func for getting metadata from the current token.
+// Unlike utilities.ExtractTokenMetaData, it supports all signing methods of the default verifier
+// and personal access tokens.
+func ExtractTokenMetaData(c *fiber.Ctx) (*utilities.TokenMetaData, error) {
+	// Get current token.
+	token, err := CurrentToken(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return TokenMetaData(token)
+}
+
+// TokenMetaData func for getting user ID, credentials and expire time from claims of the verified token.
+// Expire time is taken from "expire" claim (or from standard "exp" claim, if it's not set).
+func TokenMetaData(token *jwt.Token) (*utilities.TokenMetaData, error) {
+	// Get claims of the token.
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("token is not valid")
+	}
+
+	// User ID.
+	id, _ := claims["id"].(string)
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.New("token has no valid user ID")
+	}
+
+	// Expires time.
+	expire, ok := claims["expire"].(float64)
+	if !ok {
+		if expire, ok = claims["exp"].(float64); !ok {
+			return nil, errors.New("token has no expire time")
+		}
+	}
+
+	// User credentials.
+	rawCredentials, _ := claims["credentials"].([]interface{})
+	credentials := make([]string, 0, len(rawCredentials))
+	for _, rawCredential := range rawCredentials {
+		if credential, ok := rawCredential.(string); ok {
+			credentials = append(credentials, credential)
+		}
+	}
+
+	return &utilities.TokenMetaData{
+		UserID:      userID,
+		Credentials: credentials,
+		Expire:      int64(expire),
+	}, nil
+}
+
+// TokenValidateExpireTime func for validating JWT with expire time.
+// It's the same as utilities.TokenValidateExpireTime, but for tokens of the default verifier.
+func TokenValidateExpireTime(c *fiber.Ctx) (*utilities.TokenMetaData, error) {
+	return TokenValidateExpireTimeAndCredentials(c, nil)
+}
+
+// TokenValidateExpireTimeAndCredentials func for validating JWT with expire time and credentials.
+// It's the same as utilities.TokenValidateExpireTimeAndCredentials, but for tokens of the default verifier.
+func TokenValidateExpireTimeAndCredentials(c *fiber.Ctx, credentials []string) (*utilities.TokenMetaData, error) {
+	// Get claims from JWT.
+	claims, err := ExtractTokenMetaData(c)
+	if err != nil {
+		// Return JWT parse error.
+		return nil, err
+	}
+
+	// Checking, if now time greather than expiration from JWT.
+	if time.Now().Unix() > claims.Expire {
+		// Return unauthorized (permission denied) error message.
+		return nil, errors.New(utilities.GenerateErrorMessage(401, "token", "was expired"))
+	}
+
+	// Checking, if list of credentials has needed credential.
+	for _, credential := range credentials {
+		// Return unauthorized (permission denied) error message.
+		if !utilities.SearchStringInArray(credential, claims.Credentials) {
+			return nil, errors.New(utilities.GenerateErrorMessage(401, "token", "no required credentials"))
+		}
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Verifier struct to describe verifier of the JWT signature, issuer and audience.
+//  - SecretKey, HMAC secret for HS256 tokens (shared with the auth service), empty to disallow HS256;
+//  - KeySet, public keys for RS256 and ES256 tokens, nil to disallow them;
+//  - Issuer and Audience, required "iss" and "aud" claims, empty to skip checks;
+type Verifier struct {
+	SecretKey []byte
+	KeySet    *KeySet
+	Issuer    string
+	Audience  string
+}
+
+var (
+	defaultVerifierMu sync.Mutex
+	defaultVerifier   *Verifier
+)
+
+// DefaultVerifier func for getting verifier, configured by environment variables
+// (it's created on the first call, so .env file is already loaded).
+func DefaultVerifier() *Verifier {
+	defaultVerifierMu.Lock()
+	defer defaultVerifierMu.Unlock()
+
+	if defaultVerifier == nil {
+		defaultVerifier = NewVerifierFromEnv()
+	}
+
+	return defaultVerifier
+}
+
+// SetDefaultVerifier func for replacing default verifier (for tests and custom setups).
+func SetDefaultVerifier(verifier *Verifier) {
+	defaultVerifierMu.Lock()
+	defer defaultVerifierMu.Unlock()
+
+	defaultVerifier = verifier
+}
+
+// NewVerifierFromEnv func for create a new verifier from environment variables:
+//  - JWT_SECRET_KEY, HMAC secret for HS256 tokens;
+//  - JWT_JWKS_URL or JWT_JWKS_FILE, JWKS document with public keys for RS256 and ES256 tokens;
+//  - JWT_JWKS_REFRESH_MINUTES_COUNT, interval for reloading JWKS document (by default, 60 minutes);
+//  - JWT_ISSUER and JWT_AUDIENCE, required "iss" and "aud" claims;
+func NewVerifierFromEnv() *Verifier {
+	// Create verifier with HMAC secret and claims to check.
+	verifier := &Verifier{
+		SecretKey: []byte(os.Getenv("JWT_SECRET_KEY")),
+		Issuer:    os.Getenv("JWT_ISSUER"),
+		Audience:  os.Getenv("JWT_AUDIENCE"),
+	}
+
+	// Define source of the JWKS document.
+	source := os.Getenv("JWT_JWKS_URL")
+	if source == "" {
+		source = os.Getenv("JWT_JWKS_FILE")
+	}
+
+	// Create key set, if JWKS is configured.
+	if source != "" {
+		refreshMinutes, err := strconv.Atoi(os.Getenv("JWT_JWKS_REFRESH_MINUTES_COUNT"))
+		if err != nil || refreshMinutes <= 0 {
+			refreshMinutes = 60
+		}
+		verifier.KeySet = NewKeySet(source, time.Duration(refreshMinutes)*time.Minute)
+	}
+
+	return verifier
+}
+
+// Parse method for parsing JWT and checking its signature, time claims, issuer and audience.
+func (v *Verifier) Parse(tokenString string) (*jwt.Token, error) {
+	// Define allowed signing methods (to prevent "alg" confusion).
+	methods := []string{}
+	if len(v.SecretKey) > 0 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if v.KeySet != nil {
+		methods = append(methods, jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg())
+	}
+
+	// Parse token and check signature.
+	parser := &jwt.Parser{ValidMethods: methods}
+	token, err := parser.Parse(tokenString, v.key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check issuer and audience of the token.
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New("token claims are not valid")
+	}
+	if v.Issuer != "" && !claims.VerifyIssuer(v.Issuer, true) {
+		return nil, errors.New("token issuer is not valid")
+	}
+	if v.Audience != "" && !claims.VerifyAudience(v.Audience, true) {
+		return nil, errors.New("token audience is not valid")
+	}
+
+	return token, nil
+}
+
+// key (private) method for getting key to check signature of the token.
+func (v *Verifier) key(token *jwt.Token) (interface{}, error) {
+	// Use HMAC secret for HS256 tokens.
+	if token.Method == jwt.SigningMethodHS256 {
+		return v.SecretKey, nil
+	}
+
+	// Use public key by kid for RS256 and ES256 tokens.
+	kid, _ := token.Header["kid"].(string)
+	if v.KeySet == nil {
+		return nil, fmt.Errorf("signing method %s is not allowed", token.Method.Alg())
+	}
+
+	return v.KeySet.Key(kid, token.Method.Alg())
+}
@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MemoryStorage struct to describe in-memory storage, that implements fiber.Storage interface.
+// Can be used as a default backend for middlewares (rate limiter, etc) on single API instance.
+type MemoryStorage struct {
+	mu        sync.RWMutex
+	items     map[string]memoryItem
+	cleanedAt time.Time
+}
+
+// memoryCleanupInterval (private) const for the interval between removing expired items.
+const memoryCleanupInterval = time.Minute
+
+// memoryItem (private) struct to describe one value in the memory storage.
+type memoryItem struct {
+	value    []byte
+	expireAt time.Time // zero time means live for ever
+}
+
+// NewMemoryStorage func for create a new in-memory storage.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		items: make(map[string]memoryItem),
+	}
+}
+
+// Get method for getting the value for the given key.
+// Returns nil, if storage does not contain the key or it was expired.
+func (s *MemoryStorage) Get(key string) ([]byte, error) {
+	s.mu.RLock()
+	item, ok := s.items[key]
+	s.mu.RUnlock()
+
+	// Check, if item is not found or expired.
+	if !ok || (!item.expireAt.IsZero() && time.Now().After(item.expireAt)) {
+		return nil, nil
+	}
+
+	return item.value, nil
+}
+
+// Set method for storing the value for the given key with a time-to-live (0 means live for ever).
+func (s *MemoryStorage) Set(key string, value []byte, ttl time.Duration) error {
+	// Ignore empty key or value.
+	if key == "" || len(value) == 0 {
+		return nil
+	}
+
+	// Define expiration time.
+	var expireAt time.Time
+	if ttl > 0 {
+		expireAt = time.Now().Add(ttl)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Remove expired items from time to time.
+	if time.Since(s.cleanedAt) > memoryCleanupInterval {
+		s.removeExpired()
+	}
+
+	s.items[key] = memoryItem{value: value, expireAt: expireAt}
+
+	return nil
+}
+
+// Incr method for incrementing counter by the given key under one lock.
+// Counter is started from 1 with a new time-to-live, if it is not found or it was expired.
+// Returns count and expiration time of the counter.
+func (s *MemoryStorage) Incr(key string, ttl time.Duration) (int, time.Time, error) {
+	// Define current time.
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Remove expired items from time to time.
+	if time.Since(s.cleanedAt) > memoryCleanupInterval {
+		s.removeExpired()
+	}
+
+	// Get counter, if it is not expired.
+	count, expireAt := 0, now.Add(ttl)
+	if item, ok := s.items[key]; ok && now.Before(item.expireAt) {
+		count, _ = strconv.Atoi(string(item.value))
+		expireAt = item.expireAt
+	}
+
+	// Increment and save counter.
+	count++
+	s.items[key] = memoryItem{value: []byte(strconv.Itoa(count)), expireAt: expireAt}
+
+	return count, expireAt, nil
+}
+
+// Delete method for deleting the value for the given key.
+func (s *MemoryStorage) Delete(key string) error {
+	s.mu.Lock()
+	delete(s.items, key)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Reset method for deleting all keys from the storage.
+func (s *MemoryStorage) Reset() error {
+	s.mu.Lock()
+	s.items = make(map[string]memoryItem)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Close method for closing the storage (nothing to close for in-memory storage).
+func (s *MemoryStorage) Close() error {
+	return nil
+}
+
+// removeExpired (private) method for removing all expired items (must be called under lock).
+func (s *MemoryStorage) removeExpired() {
+	now := time.Now()
+	s.cleanedAt = now
+	for key, item := range s.items {
+		if !item.expireAt.IsZero() && now.After(item.expireAt) {
+			delete(s.items, key)
+		}
+	}
+}
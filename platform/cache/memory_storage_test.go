@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStorage(t *testing.T) {
+	// Create a new memory storage.
+	storage := NewMemoryStorage()
+
+	// Set values with and without expiration.
+	assert.NoError(t, storage.Set("forever", []byte("1"), 0))
+	assert.NoError(t, storage.Set("expired", []byte("2"), time.Millisecond))
+
+	// Wait for expiration.
+	time.Sleep(5 * time.Millisecond)
+
+	// Checking, if value without expiration is still exists.
+	value, err := storage.Get("forever")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("1"), value)
+
+	// Checking, if expired value is not found.
+	value, err = storage.Get("expired")
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+
+	// Checking, if deleted value is not found.
+	assert.NoError(t, storage.Delete("forever"))
+	value, err = storage.Get("forever")
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestMemoryStorageIncr(t *testing.T) {
+	// Create a new memory storage.
+	storage := NewMemoryStorage()
+
+	// Increment counter concurrently.
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, _ = storage.Incr("counter", time.Minute)
+		}()
+	}
+	wg.Wait()
+
+	// Checking, if all increments are counted in the same window.
+	count, expireAt, err := storage.Incr("counter", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, 51, count)
+	assert.True(t, expireAt.After(time.Now()))
+
+	// Checking, if expired counter is started again.
+	_, _, _ = storage.Incr("expired", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	count, _, err = storage.Incr("expired", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
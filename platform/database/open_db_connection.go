@@ -8,10 +8,25 @@ import (
 
 // Queries struct for collect all app queries.
 type Queries struct {
-	*queries.UserQueries    // load queries from User model
-	*queries.ProjectQueries // load queries from Project model
-	*queries.TaskQueries    // load queries from Task model
-	*queries.AnswerQueries  // load queries from Answer model
+	*queries.UserQueries                // load queries from User model
+	*queries.ProjectQueries             // load queries from Project model
+	*queries.TaskQueries                // load queries from Task model
+	*queries.AnswerQueries              // load queries from Answer model
+	*queries.ModerationQueries          // load queries from Moderation model
+	*queries.LinkQueries                // load queries from Link model
+	*queries.CounterQueries             // load queries for denormalized counters
+	*queries.DeliverabilityQueries      // load queries from EmailDeliverability model
+	*queries.WebhookEventQueries        // load queries from WebhookEvent model
+	*queries.EmailMessageQueries        // load queries from EmailMessage model
+	*queries.DigestQueries              // load queries from WeeklyDigest model
+	*queries.NotificationQueries        // load queries from Notification model
+	*queries.ProjectWebhookQueries      // load queries from ProjectWebhook model
+	*queries.DomainEventQueries         // load queries from DomainEvent model (outbox)
+	*queries.PersonalAccessTokenQueries // load queries from PersonalAccessToken model
+	*queries.TokenRevocationQueries     // load queries from RevokedToken and UserTokenRevocation models
+	*queries.ProjectCollaboratorQueries // load queries from ProjectCollaborator model
+	*queries.StaffQueries               // load queries for overrides of the staff and AuditLog model
+	*queries.RateLimitQueries           // load queries for counters of the rate limiter
 }
 
 // OpenDBConnection func for opening database connection.
@@ -24,9 +39,24 @@ func OpenDBConnection() (*Queries, error) {
 
 	return &Queries{
 		// Set queries from models:
-		UserQueries:    &queries.UserQueries{DB: db},    // from User model
-		ProjectQueries: &queries.ProjectQueries{DB: db}, // from Project model
-		TaskQueries:    &queries.TaskQueries{DB: db},    // from Task model
-		AnswerQueries:  &queries.AnswerQueries{DB: db},  // from Answer model
+		UserQueries:                &queries.UserQueries{DB: db},                // from User model
+		ProjectQueries:             &queries.ProjectQueries{DB: db},             // from Project model
+		TaskQueries:                &queries.TaskQueries{DB: db},                // from Task model
+		AnswerQueries:              &queries.AnswerQueries{DB: db},              // from Answer model
+		ModerationQueries:          &queries.ModerationQueries{DB: db},          // from Moderation model
+		LinkQueries:                &queries.LinkQueries{DB: db},                // from Link model
+		CounterQueries:             &queries.CounterQueries{DB: db},             // for denormalized counters
+		DeliverabilityQueries:      &queries.DeliverabilityQueries{DB: db},      // from EmailDeliverability model
+		WebhookEventQueries:        &queries.WebhookEventQueries{DB: db},        // from WebhookEvent model
+		EmailMessageQueries:        &queries.EmailMessageQueries{DB: db},        // from EmailMessage model
+		DigestQueries:              &queries.DigestQueries{DB: db},              // from WeeklyDigest model
+		NotificationQueries:        &queries.NotificationQueries{DB: db},        // from Notification model
+		ProjectWebhookQueries:      &queries.ProjectWebhookQueries{DB: db},      // from ProjectWebhook model
+		DomainEventQueries:         &queries.DomainEventQueries{DB: db},         // from DomainEvent model (outbox)
+		PersonalAccessTokenQueries: &queries.PersonalAccessTokenQueries{DB: db}, // from PersonalAccessToken model
+		TokenRevocationQueries:     &queries.TokenRevocationQueries{DB: db},     // from RevokedToken and UserTokenRevocation models
+		ProjectCollaboratorQueries: &queries.ProjectCollaboratorQueries{DB: db}, // from ProjectCollaborator model
+		StaffQueries:               &queries.StaffQueries{DB: db},               // for overrides of the staff and AuditLog model
+		RateLimitQueries:           &queries.RateLimitQueries{DB: db},           // for counters of the rate limiter
 	}, nil
 }
@@ -0,0 +1,209 @@
+package digest
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/repository"
+	"Komentory/api/platform/database"
+	"Komentory/api/platform/mail"
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Store interface for getting new answers of the project owners and saving sent digests.
+type Store interface {
+	GetWeeklyDigestProjects(periodEnd, lastDigestBefore, firstPeriodStart time.Time) ([]models.WeeklyDigestProject, error)
+	UpdateWeeklyDigest(userID uuid.UUID, periodEnd time.Time) error
+}
+
+// Sender interface for sending (or writing) weekly digest of the one project owner.
+type Sender interface {
+	SendDigest(ctx context.Context, d *Digest) error
+}
+
+// Digest struct to describe weekly digest of the one project owner.
+type Digest struct {
+	UserID      uuid.UUID
+	Email       string
+	FirstName   string
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Projects    []models.WeeklyDigestProject
+}
+
+// Job struct to describe job for sending weekly digests to the project owners with new answers.
+// Digest is sent once a week, so job can be run more often (like every day by cron).
+//  - DryRun == true, for sending without saving of the sent digests (like writing them to disk);
+type Job struct {
+	store  Store
+	sender Sender
+	DryRun bool
+}
+
+// NewJob func for create a new job with the given store and sender.
+func NewJob(store Store, sender Sender) *Job {
+	return &Job{store: store, sender: sender}
+}
+
+// NewDefaultJob func for create a new job with the database store.
+// Digests are added to the emails queue or written to the given folder, if dryRun is true.
+func NewDefaultJob(dryRun bool, dir string) *Job {
+	// Define sender of the digests.
+	var sender Sender = &QueueSender{}
+	if dryRun {
+		sender = &FileSender{Sender: &mail.FileSender{Dir: dir}, From: os.Getenv("MAIL_FROM"), AppURL: os.Getenv("MAIL_APP_URL")}
+	}
+
+	return &Job{store: &databaseStore{}, sender: sender, DryRun: dryRun}
+}
+
+// Run method for sending digests for the week before the given time.
+// Returns count of the sent digests and the first error (other digests are still sent).
+func (j *Job) Run(ctx context.Context, now time.Time) (int, error) {
+	// Get projects with new answers of the owners, who have not got digest for the last 6 days.
+	projects, err := j.store.GetWeeklyDigestProjects(now, now.Add(-6*24*time.Hour), now.Add(-7*24*time.Hour))
+	if err != nil {
+		return 0, err
+	}
+
+	// Define count of the sent digests and the first error.
+	sentCount, firstErr := 0, error(nil)
+
+	for _, d := range groupDigests(projects, now) {
+		// Send digest.
+		if err := j.sender.SendDigest(ctx, d); err != nil {
+			log.Printf("digest: %s: %v", d.UserID, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		// Save end of the period of the sent digest.
+		if !j.DryRun {
+			if err := j.store.UpdateWeeklyDigest(d.UserID, d.PeriodEnd); err != nil {
+				return sentCount, err
+			}
+		}
+
+		sentCount++
+	}
+
+	return sentCount, firstErr
+}
+
+// TemplateModel method for getting variables of the weekly digest template.
+func (d *Digest) TemplateModel() map[string]interface{} {
+	// Define projects and total count of the new answers.
+	projects, answersCount := []map[string]interface{}{}, 0
+	for _, project := range d.Projects {
+		projects = append(projects, map[string]interface{}{
+			"project_id":         project.ProjectID,
+			"title":              project.ProjectTitle,
+			"answers_count":      project.AnswersCount,
+			"contributors_count": project.ContributorsCount,
+		})
+		answersCount += project.AnswersCount
+	}
+
+	return map[string]interface{}{
+		"period_start":  d.PeriodStart.Format("Jan 2"),
+		"period_end":    d.PeriodEnd.Format("Jan 2"),
+		"answers_count": answersCount,
+		"projects":      projects,
+	}
+}
+
+// QueueSender struct to describe sender, which adds digests to the emails queue of the default mailer
+// (subscriptions and deliverability state are checked before sending).
+type QueueSender struct{}
+
+// SendDigest method for adding digest to the emails queue.
+func (s *QueueSender) SendDigest(_ context.Context, d *Digest) error {
+	return mail.Enqueue(d.UserID, repository.EmailStreamMarketing, repository.EmailTemplateWeeklyDigest, d.TemplateModel())
+}
+
+// FileSender struct to describe sender, which renders digests with local template and writes them to disk.
+type FileSender struct {
+	Sender *mail.FileSender
+	From   string
+	AppURL string
+}
+
+// SendDigest method for writing rendered digest to disk.
+func (s *FileSender) SendDigest(ctx context.Context, d *Digest) error {
+	// Set variables of the recipient.
+	model := d.TemplateModel()
+	model["first_name"] = d.FirstName
+	model["app_url"] = s.AppURL
+	model["unsubscribe_url"] = "(dry run)"
+
+	// Write email to disk.
+	path, err := s.Sender.Send(ctx, &mail.Message{
+		From:          s.From,
+		To:            d.Email,
+		Stream:        repository.EmailStreamMarketing,
+		Template:      repository.EmailTemplateWeeklyDigest,
+		TemplateModel: model,
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("digest: %s was written to %s", d.Email, path)
+
+	return nil
+}
+
+// groupDigests (private) func for grouping projects (ordered by owner) to the digests of their owners.
+func groupDigests(projects []models.WeeklyDigestProject, periodEnd time.Time) []*Digest {
+	// Define digests.
+	digests := []*Digest{}
+
+	for _, project := range projects {
+		// Start a new digest for the next owner.
+		if len(digests) == 0 || digests[len(digests)-1].UserID != project.UserID {
+			digests = append(digests, &Digest{
+				UserID:      project.UserID,
+				Email:       project.Email,
+				FirstName:   project.FirstName,
+				PeriodStart: project.PeriodStart,
+				PeriodEnd:   periodEnd,
+			})
+		}
+
+		// Add project to the digest of the owner.
+		d := digests[len(digests)-1]
+		d.Projects = append(d.Projects, project)
+	}
+
+	return digests
+}
+
+// databaseStore (private) struct to describe store of the weekly digests in the database.
+type databaseStore struct{}
+
+// GetWeeklyDigestProjects method for getting projects with new answers from the database.
+func (s *databaseStore) GetWeeklyDigestProjects(periodEnd, lastDigestBefore, firstPeriodStart time.Time) ([]models.WeeklyDigestProject, error) {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return nil, err
+	}
+
+	return db.GetWeeklyDigestProjects(periodEnd, lastDigestBefore, firstPeriodStart)
+}
+
+// UpdateWeeklyDigest method for saving sent digest to the database.
+func (s *databaseStore) UpdateWeeklyDigest(userID uuid.UUID, periodEnd time.Time) error {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return err
+	}
+
+	return db.UpdateWeeklyDigest(userID, periodEnd)
+}
@@ -0,0 +1,100 @@
+package digest
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/platform/mail"
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubStore (private) struct to describe store, which keeps projects and sent digests in memory.
+type stubStore struct {
+	projects []models.WeeklyDigestProject
+	sent     map[uuid.UUID]time.Time
+}
+
+// GetWeeklyDigestProjects method for getting projects from memory.
+func (s *stubStore) GetWeeklyDigestProjects(_, _, _ time.Time) ([]models.WeeklyDigestProject, error) {
+	return s.projects, nil
+}
+
+// UpdateWeeklyDigest method for saving sent digest in memory.
+func (s *stubStore) UpdateWeeklyDigest(userID uuid.UUID, periodEnd time.Time) error {
+	s.sent[userID] = periodEnd
+	return nil
+}
+
+// stubSender (private) struct to describe sender, which fails for the given user.
+type stubSender struct {
+	failedUserID uuid.UUID
+	digests      []*Digest
+}
+
+// SendDigest method for saving digest in memory.
+func (s *stubSender) SendDigest(_ context.Context, d *Digest) error {
+	if d.UserID == s.failedUserID {
+		return errors.New("queue is not available")
+	}
+	s.digests = append(s.digests, d)
+	return nil
+}
+
+func TestJobRun(t *testing.T) {
+	// Create projects of two owners.
+	owner, failedOwner, now := uuid.New(), uuid.New(), time.Now()
+	store := &stubStore{
+		projects: []models.WeeklyDigestProject{
+			{UserID: owner, ProjectTitle: "First", AnswersCount: 3, ContributorsCount: 2},
+			{UserID: owner, ProjectTitle: "Second", AnswersCount: 1, ContributorsCount: 1},
+			{UserID: failedOwner, ProjectTitle: "Third", AnswersCount: 5, ContributorsCount: 5},
+		},
+		sent: map[uuid.UUID]time.Time{},
+	}
+	sender := &stubSender{failedUserID: failedOwner}
+
+	// Check, that digests are grouped by owner and failed digest is not saved.
+	sentCount, err := NewJob(store, sender).Run(context.Background(), now)
+	assert.Error(t, err)
+	assert.Equal(t, 1, sentCount)
+	assert.Len(t, sender.digests, 1)
+	assert.Len(t, sender.digests[0].Projects, 2)
+	assert.Equal(t, 4, sender.digests[0].TemplateModel()["answers_count"])
+	assert.Equal(t, map[uuid.UUID]time.Time{owner: now}, store.sent)
+
+	// Check, that dry run does not save sent digests.
+	store.sent = map[uuid.UUID]time.Time{}
+	job := NewJob(store, &stubSender{})
+	job.DryRun = true
+	sentCount, err = job.Run(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, sentCount)
+	assert.Empty(t, store.sent)
+}
+
+func TestFileSender(t *testing.T) {
+	// Create a new sender with temporary folder.
+	dir := t.TempDir()
+	sender := &FileSender{Sender: &mail.FileSender{Dir: dir}, AppURL: "https://komentory.com"}
+
+	// Write digest to disk.
+	assert.NoError(t, sender.SendDigest(context.Background(), &Digest{
+		Email:     "owner@example.com",
+		FirstName: "John",
+		Projects:  []models.WeeklyDigestProject{{ProjectTitle: "First", AnswersCount: 3, ContributorsCount: 2}},
+	}))
+
+	// Check content of the digest.
+	files, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	raw, err := os.ReadFile(dir + "/" + files[0].Name())
+	assert.NoError(t, err)
+	assert.Contains(t, string(raw), "Subject: 3 new answers to your projects on Komentory")
+	assert.Contains(t, string(raw), "First: 3 new answers from 2 contributors")
+}
@@ -23,6 +23,14 @@ var (
 	//go:embed sql_queries/task_getManyByProjectID.sql
 	SQLQueryGetManyTasksByProjectID string
 
+	// SQLQueryGetDraftTasksByProjectID string with query for getting not active (draft and unpublished) tasks by project ID.
+	//go:embed sql_queries/task_getDraftsByProjectID.sql
+	SQLQueryGetDraftTasksByProjectID string
+
+	// SQLQueryGetResultsByTaskID string with query for getting aggregated results of the task questions.
+	//go:embed sql_queries/task_getResultsByTaskID.sql
+	SQLQueryGetResultsByTaskID string
+
 	// SQLQueryGetOneAnswerByID string with query for getting one answer by ID.
 	//go:embed sql_queries/answer_getOneByID.sql
 	SQLQueryGetOneAnswerByID string
@@ -34,4 +42,20 @@ var (
 	// SQLQueryGetManyAnswersByProjectID string with query for getting all (many) answers by project ID.
 	//go:embed sql_queries/answer_getManyByProjectID.sql
 	SQLQueryGetManyAnswersByProjectID string
+
+	// SQLQueryGetManyAnswersByProjectIDWithTriage string with query for getting all (many) answers with triage by project ID.
+	//go:embed sql_queries/answer_getManyByProjectIDWithTriage.sql
+	SQLQueryGetManyAnswersByProjectIDWithTriage string
+
+	// SQLQueryGetModerationQueueAnswers string with query for getting all (many) answers with unresolved reports.
+	//go:embed sql_queries/moderation_getAnswersQueue.sql
+	SQLQueryGetModerationQueueAnswers string
+
+	// SQLQueryGetOneUserByID string with query for getting public profile of the user by ID.
+	//go:embed sql_queries/user_getOneByID.sql
+	SQLQueryGetOneUserByID string
+
+	// SQLQueryGetWeeklyDigestProjects string with query for getting projects with new answers for the weekly digests.
+	//go:embed sql_queries/digest_getWeeklyDigestProjects.sql
+	SQLQueryGetWeeklyDigestProjects string
 )
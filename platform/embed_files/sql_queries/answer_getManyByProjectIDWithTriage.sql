@@ -0,0 +1,39 @@
+--
+-- Query to get all (many) answers with triage attributes by project ID (for project owner).
+-- Show only answers with answer_status == 1 (active) and not hidden by moderation.
+-- Filter by triage label ($2) and state ($3), if they are not empty strings.
+-- Function signature:
+--  func (q *AnswerQueries) GetAnswersByProjectIDWithTriage(project_id uuid.UUID, label, state string) ([]models.GetAnswersWithTriage, int, error)
+--
+
+SELECT
+	a.id,
+	a.created_at,
+	a.updated_at,
+	a.task_id,
+	a.answer_attrs,
+	jsonb_build_object(
+		'user_id', u.id,
+		'first_name', u.user_attrs->'first_name',
+		'last_name', u.user_attrs->'last_name',
+		'picture', u.user_attrs->'picture',
+		'abilities', u.user_attrs->'abilities'
+	) AS author,
+	jsonb_build_object(
+		'label', COALESCE(tr.label, ''),
+		'state', COALESCE(tr.state, 'new'),
+		'note', COALESCE(tr.note, ''),
+		'updated_at', tr.updated_at
+	) AS triage
+FROM
+	answers AS a
+	LEFT JOIN users AS u ON a.user_id = u.id
+	LEFT JOIN answer_triages AS tr ON tr.answer_id = a.id
+WHERE
+	a.project_id = $1::uuid
+	AND a.answer_status = 1
+	AND a.hidden_at IS NULL
+	AND ($2::varchar = '' OR COALESCE(tr.label, '') = $2::varchar)
+	AND ($3::varchar = '' OR COALESCE(tr.state, 'new') = $3::varchar)
+ORDER BY
+	a.created_at DESC
\ No newline at end of file
@@ -1,6 +1,6 @@
 --
 -- Query to get all (many) answers by task ID.
--- Show only answers with answer_status == 1 (active).
+-- Show only answers with answer_status == 1 (active) and not hidden by moderation.
 -- Function signature:
 --  func (q *AnswerQueries) GetAnswersByTaskID(task_id uuid.UUID) ([]models.GetAnswers, int, error)
 -- 
@@ -23,5 +23,6 @@ FROM
 WHERE
 	a.task_id = $1::uuid
 	AND a.answer_status = 1
+	AND a.hidden_at IS NULL
 ORDER BY
 	a.created_at DESC
\ No newline at end of file
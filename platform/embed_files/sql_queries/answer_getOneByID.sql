@@ -1,5 +1,6 @@
 --
 -- Query to get one answer by ID.
+-- Show only answers not hidden by moderation.
 -- Function signature:
 --  func (q *AnswerQueries) GetAnswerByID(answer_id uuid.UUID) (models.Answer, int, error)
 -- 
@@ -23,6 +24,7 @@ FROM
 	LEFT JOIN users AS u ON u.id = a.user_id
 WHERE
 	a.id = $1::uuid
+	AND a.hidden_at IS NULL
 GROUP BY
 	a.id,
 	u.id
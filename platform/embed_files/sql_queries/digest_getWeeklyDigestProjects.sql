@@ -0,0 +1,39 @@
+--
+-- Query to get projects with new answers for the weekly digests of their owners.
+-- Only owners, subscribed to the marketing emails, whose previous digest period ended before $2, are selected.
+-- Period of the digest starts at the end of the previous one (or at $3 for the first digest) and ends at $1.
+-- Function signature:
+--  func (q *DigestQueries) GetWeeklyDigestProjects(periodEnd, lastDigestBefore, firstPeriodStart time.Time) ([]models.WeeklyDigestProject, error)
+--
+
+SELECT
+	p.user_id,
+	u.email,
+	COALESCE(u.user_attrs->>'first_name', '') AS first_name,
+	COALESCE(d.period_end, $3::timestamp) AS period_start,
+	p.id AS project_id,
+	COALESCE(p.project_attrs->>'title', '') AS project_title,
+	COUNT(a.id) AS answers_count,
+	COUNT(DISTINCT a.user_id) AS contributors_count
+FROM
+	projects AS p
+	JOIN users AS u ON u.id = p.user_id
+	LEFT JOIN weekly_digests AS d ON d.user_id = p.user_id
+	JOIN answers AS a ON a.project_id = p.id
+WHERE
+	COALESCE((u.user_settings->'email_subscriptions'->>'marketing')::boolean, false)
+	AND (d.period_end IS NULL OR d.period_end <= $2::timestamp)
+	AND a.created_at > COALESCE(d.period_end, $3::timestamp)
+	AND a.created_at <= $1::timestamp
+	AND a.answer_status = 1
+	AND a.hidden_at IS NULL
+	AND a.user_id <> p.user_id
+GROUP BY
+	p.user_id,
+	u.email,
+	u.user_attrs,
+	d.period_end,
+	p.id
+ORDER BY
+	p.user_id,
+	answers_count DESC
\ No newline at end of file
@@ -0,0 +1,53 @@
+--
+-- Query to get all (many) answers with unresolved reports (moderation queue).
+-- Show answers with the most reports first.
+-- Function signature:
+--  func (q *ModerationQueries) GetModerationQueueAnswers() ([]models.GetModerationQueueAnswers, int, error)
+--
+
+SELECT
+	a.id,
+	a.created_at,
+	a.updated_at,
+	a.hidden_at,
+	a.project_id,
+	a.task_id,
+	a.answer_status,
+	a.answer_attrs,
+	jsonb_build_object(
+		'user_id', u.id,
+		'first_name', u.user_attrs->'first_name',
+		'last_name', u.user_attrs->'last_name',
+		'picture', u.user_attrs->'picture'
+	) AS author,
+	r.reports_count,
+	r.reasons
+FROM
+	(
+		SELECT
+			rr.answer_id,
+			SUM(rr.count) AS reports_count,
+			MIN(rr.first_reported_at) AS first_reported_at,
+			jsonb_object_agg(rr.reason, rr.count) AS reasons
+		FROM (
+			SELECT
+				answer_id,
+				reason,
+				COUNT(*) AS count,
+				MIN(created_at) AS first_reported_at
+			FROM
+				answer_reports
+			WHERE
+				resolved_at IS NULL
+			GROUP BY
+				answer_id,
+				reason
+		) AS rr
+		GROUP BY
+			rr.answer_id
+	) AS r
+	JOIN answers AS a ON a.id = r.answer_id
+	LEFT JOIN users AS u ON u.id = a.user_id
+ORDER BY
+	r.reports_count DESC,
+	r.first_reported_at ASC
\ No newline at end of file
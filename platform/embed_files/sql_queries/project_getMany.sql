@@ -16,15 +16,13 @@ SELECT
 		'last_name', u.user_attrs->'last_name',
 		'picture', u.user_attrs->'picture'
 	) AS author,
-	COUNT(t.id) AS tasks_count
+	p.tasks_count,
+	p.answers_count,
+	p.contributors_count
 FROM
 	projects AS p
 	LEFT JOIN users AS u ON u.id = p.user_id
-	LEFT JOIN tasks AS t ON t.project_id = p.id
 WHERE
 	p.project_status = 1
-GROUP BY
-	p.id,
-	u.id
 ORDER BY
 	p.created_at DESC
\ No newline at end of file
@@ -16,7 +16,9 @@ SELECT
 		'last_name', u.user_attrs->'last_name',
 		'picture', u.user_attrs->'picture'
 	) AS author,
-	COUNT(t.id) AS tasks_count,
+	p.tasks_count,
+	p.answers_count,
+	p.contributors_count,
 	COALESCE(
 		jsonb_agg(
 			jsonb_build_object(
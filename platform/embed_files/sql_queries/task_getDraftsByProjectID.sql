@@ -0,0 +1,22 @@
+--
+-- Query to get not active (draft and unpublished) tasks by project ID.
+-- Show only tasks with task_status != 1 (for the project collaborators).
+-- Function signature:
+--  func (q *TaskQueries) GetDraftTasksByProjectID(project_id uuid.UUID) ([]models.GetDraftTasks, int, error)
+-- 
+
+SELECT
+	t.id,
+	t.created_at,
+	t.updated_at,
+	t.task_status,
+	t.task_attrs,
+	t.answers_count,
+	t.contributors_count
+FROM
+	tasks AS t
+WHERE
+	t.project_id = $1::uuid
+	AND t.task_status != 1
+ORDER BY
+	t.created_at DESC
\ No newline at end of file
@@ -10,14 +10,12 @@ SELECT
 	t.created_at,
 	t.updated_at,
 	t.task_attrs,
-	COUNT(a.id) AS answers_count
+	t.answers_count,
+	t.contributors_count
 FROM
 	tasks AS t
-	LEFT JOIN answers AS a ON a.task_id = t.id
 WHERE
 	t.project_id = $1::uuid
 	AND t.task_status = 1
-GROUP BY
-	t.id
 ORDER BY
 	t.created_at DESC
\ No newline at end of file
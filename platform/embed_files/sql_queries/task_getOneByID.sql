@@ -5,13 +5,9 @@
 -- 
 
 SELECT
-	t.*,
-	COUNT(a.id) AS answers_count
+	t.*
 FROM
 	tasks AS t
-	LEFT JOIN answers AS a ON a.task_id = t.id
 WHERE
 	t.id = $1::uuid
-GROUP BY
-	t.id
 LIMIT 1
\ No newline at end of file
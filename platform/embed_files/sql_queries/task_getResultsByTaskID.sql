@@ -0,0 +1,72 @@
+--
+-- Query to get aggregated results of the structured questions by task ID.
+-- Count only answers with answer_status == 1 (active) and not hidden by moderation.
+-- Average of the rating skips not numeric values (saved before type of the question was changed).
+-- Function signature:
+--  func (q *TaskQueries) GetTaskResultsByTaskID(task_id uuid.UUID) ([]models.GetTaskQuestionResult, int, error)
+--
+
+WITH questions AS (
+	SELECT
+		q.value->>'id' AS question_id,
+		q.value->>'type' AS question_type,
+		q.position
+	FROM
+		tasks AS t
+		CROSS JOIN LATERAL jsonb_array_elements(COALESCE(t.task_attrs->'questions', '[]')) WITH ORDINALITY AS q(value, position)
+	WHERE
+		t.id = $1::uuid
+),
+responses AS (
+	SELECT
+		r.value->>'question_id' AS question_id,
+		r.value->'value' AS value
+	FROM
+		answers AS a
+		CROSS JOIN LATERAL jsonb_array_elements(COALESCE(a.answer_attrs->'responses', '[]')) AS r(value)
+	WHERE
+		a.task_id = $1::uuid
+		AND a.answer_status = 1
+		AND a.hidden_at IS NULL
+),
+response_values AS (
+	SELECT
+		r.question_id,
+		v.value #>> '{}' AS value
+	FROM
+		responses AS r
+		CROSS JOIN LATERAL jsonb_array_elements(
+			CASE jsonb_typeof(r.value) WHEN 'array' THEN r.value ELSE jsonb_build_array(r.value) END
+		) AS v(value)
+)
+SELECT
+	q.question_id,
+	q.question_type,
+	(
+		SELECT COUNT(*)
+		FROM responses AS r
+		WHERE r.question_id = q.question_id
+	) AS responses_count,
+	(
+		SELECT AVG(rv.value::numeric)::float8
+		FROM response_values AS rv
+		WHERE
+			rv.question_id = q.question_id
+			AND q.question_type = 'rating'
+			AND rv.value ~ '^-?[0-9]+(\.[0-9]+)?$'
+	) AS average,
+	COALESCE(
+		(
+			SELECT jsonb_object_agg(d.value, d.count)
+			FROM (
+				SELECT rv.value, COUNT(*) AS count
+				FROM response_values AS rv
+				WHERE rv.question_id = q.question_id AND q.question_type <> 'short_text'
+				GROUP BY rv.value
+			) AS d
+		), '{}'
+	) AS distribution
+FROM
+	questions AS q
+ORDER BY
+	q.position
\ No newline at end of file
@@ -0,0 +1,26 @@
+--
+-- Query to get public profile of the user by ID.
+-- Count only active (status == 1) projects and answers, which are not hidden by moderators.
+-- Function signature:
+--  func (q *UserQueries) GetUserByID(user_id uuid.UUID) (models.GetUser, int, error)
+--
+
+SELECT
+	u.id,
+	u.created_at,
+	u.user_attrs,
+	(
+		SELECT COUNT(*)
+		FROM projects AS p
+		WHERE p.user_id = u.id AND p.project_status = 1
+	) AS projects_count,
+	(
+		SELECT COUNT(*)
+		FROM answers AS a
+		WHERE a.user_id = u.id AND a.answer_status = 1 AND a.hidden_at IS NULL
+	) AS answers_count
+FROM
+	users AS u
+WHERE
+	u.id = $1::uuid
+LIMIT 1
\ No newline at end of file
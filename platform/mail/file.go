@@ -0,0 +1,67 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FileSender struct to describe local stand-in sender, which writes rendered emails to the folder
+// (for development and dry runs).
+type FileSender struct {
+	Dir string
+}
+
+// Send method for writing rendered email to the new .eml file in the folder.
+// Returns path to the file as message ID.
+func (s *FileSender) Send(_ context.Context, m *Message) (string, error) {
+	// Render local template.
+	raw, err := renderRawMessage(m)
+	if err != nil {
+		return "", err
+	}
+
+	// Define folder for emails.
+	dir := s.Dir
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "komentory-mails")
+	}
+
+	// Create folder, if it is not exists.
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	// Write email to the file.
+	path := filepath.Join(dir, fmt.Sprintf("%d_%s.eml", time.Now().UnixNano(), m.Template))
+	if err := os.WriteFile(path, raw, 0o644); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// renderRawMessage (private) func for rendering message with the local template to raw email (RFC 5322).
+func renderRawMessage(m *Message) ([]byte, error) {
+	// Render local template.
+	subject, body, err := Render(m.Template, m.TemplateModel)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build headers and body of the email.
+	var raw strings.Builder
+	fmt.Fprintf(&raw, "From: %s\r\n", m.From)
+	fmt.Fprintf(&raw, "To: %s\r\n", m.To)
+	fmt.Fprintf(&raw, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&raw, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&raw, "X-Message-Stream: %s\r\n", m.Stream)
+	raw.WriteString("MIME-Version: 1.0\r\n")
+	raw.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	raw.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	return []byte(raw.String()), nil
+}
@@ -0,0 +1,68 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Message struct to describe one outbound email, built from the template.
+//  - Stream == transactional | marketing;
+type Message struct {
+	From          string
+	To            string
+	Stream        string
+	Template      string
+	TemplateModel map[string]interface{}
+}
+
+// Sender interface for sending email by email provider (or local stand-in).
+// Returns ID of the message, given by email provider.
+type Sender interface {
+	Send(ctx context.Context, m *Message) (string, error)
+}
+
+// PermanentError struct to describe sending error, which will not be fixed by the next attempt
+// (like invalid or inactive email address).
+type PermanentError struct {
+	Err error
+}
+
+// Error method for getting text of the permanent error.
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap method for getting original error.
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// IsPermanent func for checking, if the given sending error is permanent.
+func IsPermanent(err error) bool {
+	var permanentError *PermanentError
+	return errors.As(err, &permanentError)
+}
+
+// NewSender func for creating sender by MAIL_SENDER environment variable:
+//  - "postmark", for sending by Postmark API (POSTMARK_SERVER_TOKEN);
+//  - "smtp", for sending by SMTP server, like MailHog (MAIL_SMTP_ADDR);
+//  - "file", for writing emails to the local folder (MAIL_FILE_DIR);
+func NewSender() (Sender, error) {
+	// Switch senders.
+	switch sender := os.Getenv("MAIL_SENDER"); sender {
+	case "postmark":
+		return NewPostmarkSender(os.Getenv("POSTMARK_SERVER_TOKEN")), nil
+	case "smtp":
+		return &SMTPSender{
+			Addr:     os.Getenv("MAIL_SMTP_ADDR"),
+			Username: os.Getenv("MAIL_SMTP_USERNAME"),
+			Password: os.Getenv("MAIL_SMTP_PASSWORD"),
+		}, nil
+	case "file":
+		return &FileSender{Dir: os.Getenv("MAIL_FILE_DIR")}, nil
+	default:
+		return nil, fmt.Errorf("wrong or unsupported mail sender (%s)", sender)
+	}
+}
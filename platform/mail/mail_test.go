@@ -0,0 +1,242 @@
+package mail
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/repository"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubStore (private) struct to describe store, which keeps recipients and outcomes in memory.
+type stubStore struct {
+	recipients map[uuid.UUID]models.EmailRecipient
+	outcomes   map[uuid.UUID]string
+	queued     int
+}
+
+// CreateEmailMessage method for counting queued emails.
+func (s *stubStore) CreateEmailMessage(_ *models.EmailMessage) error {
+	s.queued++
+	return nil
+}
+
+// ClaimDueEmailMessages method for claiming nothing.
+func (s *stubStore) ClaimDueEmailMessages(_ int, _ time.Duration) ([]models.EmailMessage, error) {
+	return nil, nil
+}
+
+// GetEmailRecipientByID method for getting recipient from memory.
+func (s *stubStore) GetEmailRecipientByID(userID uuid.UUID) (models.EmailRecipient, error) {
+	recipient, ok := s.recipients[userID]
+	if !ok {
+		return recipient, sql.ErrNoRows
+	}
+	return recipient, nil
+}
+
+// FinishEmailMessage method for saving final outcome in memory.
+func (s *stubStore) FinishEmailMessage(id uuid.UUID, status, _, sendingError string) error {
+	s.outcomes[id] = strings.TrimSpace(status + " " + sendingError)
+	return nil
+}
+
+// RetryEmailMessage method for saving retry in memory.
+func (s *stubStore) RetryEmailMessage(id uuid.UUID, _ time.Time, sendingError string) error {
+	s.outcomes[id] = "retry " + sendingError
+	return nil
+}
+
+// stubSender (private) struct to describe sender, which returns the given error.
+type stubSender struct {
+	err  error
+	sent []*Message
+}
+
+// Send method for saving message in memory.
+func (s *stubSender) Send(_ context.Context, m *Message) (string, error) {
+	s.sent = append(s.sent, m)
+	return "message-id", s.err
+}
+
+func TestRender(t *testing.T) {
+	// Check all local templates.
+	for _, name := range []string{
+		repository.EmailTemplateNewAnswer, repository.EmailTemplateAnswerAccepted, repository.EmailTemplateWeeklyDigest,
+	} {
+		subject, body, err := Render(name, map[string]interface{}{
+			"first_name":    "John",
+			"project_title": "Komentory",
+			"projects":      []map[string]interface{}{{"title": "Komentory", "answers_count": 2}},
+		})
+		assert.NoErrorf(t, err, name)
+		assert.Containsf(t, subject, "Komentory", name)
+		assert.Containsf(t, body, "Hi John,", name)
+	}
+
+	// Check unknown template.
+	_, _, err := Render("unknown", nil)
+	assert.True(t, IsPermanent(err))
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Minute, RetryDelay(1))
+	assert.Equal(t, 4*time.Minute, RetryDelay(2))
+	assert.Equal(t, 16*time.Minute, RetryDelay(3))
+	assert.Equal(t, 6*time.Hour, RetryDelay(10))
+}
+
+func TestMailerEnqueue(t *testing.T) {
+	store := &stubStore{}
+
+	// Check, if email is not queued without sender.
+	t.Setenv("MAIL_SENDER", "")
+	err := NewMailer(nil, store).Enqueue(uuid.New(), repository.EmailStreamTransactional, repository.EmailTemplateNewAnswer, nil)
+	assert.ErrorIs(t, err, ErrSenderNotConfigured)
+	assert.Equal(t, 0, store.queued)
+
+	// Check, if email is queued for sender of the other API instance.
+	t.Setenv("MAIL_SENDER", "postmark")
+	err = NewMailer(nil, store).Enqueue(uuid.New(), repository.EmailStreamTransactional, repository.EmailTemplateNewAnswer, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, store.queued)
+}
+
+func TestMailerSend(t *testing.T) {
+	// Set secret key for signing unsubscribe tokens.
+	t.Setenv("UNSUBSCRIBE_SECRET_KEY", "secret")
+
+	// Create recipients.
+	subscribed, unsubscribed, bounced := uuid.New(), uuid.New(), uuid.New()
+	bouncedAt := time.Now()
+	store := &stubStore{
+		recipients: map[uuid.UUID]models.EmailRecipient{
+			subscribed: {ID: subscribed, Email: "a@example.com", Settings: models.UserSettings{
+				EmailSubscriptions: models.EmailSubscriptions{Transactional: true},
+			}},
+			unsubscribed: {ID: unsubscribed, Email: "b@example.com"},
+			bounced: {ID: bounced, Email: "c@example.com", HardBouncedAt: &bouncedAt, Settings: models.UserSettings{
+				EmailSubscriptions: models.EmailSubscriptions{Transactional: true},
+			}},
+		},
+		outcomes: map[uuid.UUID]string{},
+	}
+
+	// Define a structure for specifying input and output data of a single test case.
+	tests := []struct {
+		description     string
+		userID          uuid.UUID
+		attempts        int
+		sendingError    error
+		expectedOutcome string
+	}{
+		{"success: sent", subscribed, 1, nil, "sent"},
+		{"skip: not subscribed", unsubscribed, 1, nil, "skipped recipient is not subscribed to the transactional stream"},
+		{"skip: hard bounced", bounced, 1, nil, "skipped email address of the recipient is hard-bounced or marked email as spam"},
+		{"fail: unknown recipient", uuid.New(), 1, nil, "failed recipient not found"},
+		{"fail: permanent error", subscribed, 1, &PermanentError{Err: errors.New("inactive")}, "failed inactive"},
+		{"retry: temporary error", subscribed, 1, errors.New("timeout"), "retry timeout"},
+		{"fail: all attempts are used", subscribed, 5, errors.New("timeout"), "failed timeout"},
+	}
+
+	// Iterate through test single test cases.
+	for _, test := range tests {
+		sender := &stubSender{err: test.sendingError}
+		mailer := NewMailer(sender, store)
+		mailer.MaxAttempts = 5
+		mailer.UnsubscribeURL = "https://api.example.com/v1/unsubscribe"
+
+		message := &models.EmailMessage{
+			ID:       uuid.New(),
+			UserID:   test.userID,
+			Stream:   repository.EmailStreamTransactional,
+			Template: repository.EmailTemplateNewAnswer,
+			Attempts: test.attempts,
+		}
+		assert.NoErrorf(t, mailer.Send(context.Background(), message), test.description)
+		assert.Equalf(t, test.expectedOutcome, store.outcomes[message.ID], test.description)
+
+		// Check variables of the recipient in the sent message.
+		if len(sender.sent) > 0 {
+			assert.Equalf(t, "a@example.com", sender.sent[0].To, test.description)
+			assert.Containsf(t, sender.sent[0].TemplateModel["unsubscribe_url"], "/v1/unsubscribe?token=", test.description)
+		}
+	}
+}
+
+func TestPostmarkSender(t *testing.T) {
+	// Create a new local Postmark API stub.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		switch body["To"] {
+		case "inactive@example.com":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"ErrorCode":406,"Message":"inactive recipient"}`))
+		case "busy@example.com":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			assert.Equal(t, "token", r.Header.Get("X-Postmark-Server-Token"))
+			assert.Equal(t, "outbound", body["MessageStream"])
+			assert.Equal(t, "new_answer", body["TemplateAlias"])
+			_, _ = w.Write([]byte(`{"ErrorCode":0,"Message":"OK","MessageID":"b7bc2f4a"}`))
+		}
+	}))
+	defer server.Close()
+
+	// Create a new sender with local stub.
+	sender := NewPostmarkSender("token")
+	sender.BaseURL = server.URL
+	message := &Message{Stream: repository.EmailStreamTransactional, Template: repository.EmailTemplateNewAnswer}
+
+	// Check successful sending.
+	message.To = "a@example.com"
+	messageID, err := sender.Send(context.Background(), message)
+	assert.NoError(t, err)
+	assert.Equal(t, "b7bc2f4a", messageID)
+
+	// Check permanent error.
+	message.To = "inactive@example.com"
+	_, err = sender.Send(context.Background(), message)
+	assert.True(t, IsPermanent(err))
+
+	// Check temporary error.
+	message.To = "busy@example.com"
+	_, err = sender.Send(context.Background(), message)
+	assert.Error(t, err)
+	assert.False(t, IsPermanent(err))
+}
+
+func TestFileSender(t *testing.T) {
+	// Create a new sender with temporary folder.
+	sender := &FileSender{Dir: t.TempDir()}
+
+	// Write email to the file.
+	path, err := sender.Send(context.Background(), &Message{
+		From:          "no-reply@example.com",
+		To:            "a@example.com",
+		Stream:        repository.EmailStreamTransactional,
+		Template:      repository.EmailTemplateAnswerAccepted,
+		TemplateModel: map[string]interface{}{"first_name": "John", "project_title": "Komentory"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, sender.Dir, filepath.Dir(path))
+
+	// Check content of the email.
+	raw, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(raw), "To: a@example.com\r\n")
+	assert.Contains(t, string(raw), "Subject: Your answer on Komentory was accepted\r\n")
+}
@@ -0,0 +1,339 @@
+package mail
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/helpers"
+	"Komentory/api/pkg/repository"
+	"Komentory/api/platform/database"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Store interface for queued emails and their recipients.
+type Store interface {
+	CreateEmailMessage(m *models.EmailMessage) error
+	ClaimDueEmailMessages(limit int, lockTimeout time.Duration) ([]models.EmailMessage, error)
+	GetEmailRecipientByID(userID uuid.UUID) (models.EmailRecipient, error)
+	FinishEmailMessage(id uuid.UUID, status, providerMessageID, sendingError string) error
+	RetryEmailMessage(id uuid.UUID, nextAttemptAt time.Time, sendingError string) error
+}
+
+// Mailer struct to describe background sender of the queued emails.
+// Before each attempt, subscriptions and deliverability state of the recipient are checked.
+// Failed attempts are retried with exponential backoff (1, 4, 16 ... minutes).
+type Mailer struct {
+	sender  Sender
+	store   Store
+	notify  chan struct{}
+	enabled bool // emails are queued only if sender is configured (by this or other API instance)
+
+	// Settings of the emails:
+	From           string // address of the sender
+	AppURL         string // URL of the frontend for links in emails
+	UnsubscribeURL string // URL of the unsubscribe endpoint (token is added to the query)
+	MaxAttempts    int    // max count of the sending attempts
+}
+
+// DefaultMailer mailer used by controllers and jobs.
+// Sender is set only for started mailer, but emails are queued, if MAIL_SENDER is set
+// (they are sent by API instance with started mailer).
+var DefaultMailer = NewMailer(nil, &databaseStore{})
+
+// ErrSenderNotConfigured error for emails, which are not queued, because MAIL_SENDER is empty
+// (they would never be sent).
+var ErrSenderNotConfigured = errors.New("mail sender is not configured, email is not queued")
+
+// NewMailer func for create a new mailer with the given sender and store.
+// Settings of the emails are set by MAIL_FROM, MAIL_APP_URL, MAIL_UNSUBSCRIBE_URL
+// and MAIL_MAX_ATTEMPTS (default: 5) environment variables.
+// Emails are queued only if sender is given or MAIL_SENDER is set.
+func NewMailer(sender Sender, store Store) *Mailer {
+	// Define max count of the sending attempts.
+	maxAttempts, err := strconv.Atoi(os.Getenv("MAIL_MAX_ATTEMPTS"))
+	if err != nil || maxAttempts <= 0 {
+		maxAttempts = 5
+	}
+
+	return &Mailer{
+		sender:         sender,
+		store:          store,
+		notify:         make(chan struct{}, 1),
+		enabled:        sender != nil || os.Getenv("MAIL_SENDER") != "",
+		From:           os.Getenv("MAIL_FROM"),
+		AppURL:         os.Getenv("MAIL_APP_URL"),
+		UnsubscribeURL: os.Getenv("MAIL_UNSUBSCRIBE_URL"),
+		MaxAttempts:    maxAttempts,
+	}
+}
+
+// StartDefaultMailer func for start default mailer with sender from MAIL_SENDER environment variable.
+// Mailer is started only if MAIL_SENDER is set.
+func StartDefaultMailer(ctx context.Context) error {
+	// Check, if sending of emails is enabled.
+	if os.Getenv("MAIL_SENDER") == "" {
+		return nil
+	}
+
+	// Create a new sender.
+	sender, err := NewSender()
+	if err != nil {
+		return err
+	}
+
+	// Create and start default mailer.
+	DefaultMailer = NewMailer(sender, &databaseStore{})
+	DefaultMailer.Start(ctx, time.Minute)
+
+	return nil
+}
+
+// Enqueue func for adding a new email to the default mailer queue.
+func Enqueue(userID uuid.UUID, stream, template string, model map[string]interface{}) error {
+	return DefaultMailer.Enqueue(userID, stream, template, model)
+}
+
+// Enqueue method for adding a new email for the user to the queue and waking up the mailer.
+// Returns ErrSenderNotConfigured, if sending of emails is disabled.
+func (m *Mailer) Enqueue(userID uuid.UUID, stream, template string, model map[string]interface{}) error {
+	// Check, if sending of emails is enabled.
+	if !m.enabled {
+		return ErrSenderNotConfigured
+	}
+
+	// Define current time.
+	now := time.Now()
+
+	// Save a new email to the queue.
+	if err := m.store.CreateEmailMessage(&models.EmailMessage{
+		ID:            uuid.New(),
+		CreatedAt:     now,
+		UserID:        userID,
+		Stream:        stream,
+		Template:      template,
+		TemplateModel: model,
+		Status:        repository.EmailMessageStatusPending,
+		NextAttemptAt: now,
+	}); err != nil {
+		return err
+	}
+
+	// Wake up the mailer (without blocking).
+	select {
+	case m.notify <- struct{}{}:
+	default: // mailer is already notified
+	}
+
+	return nil
+}
+
+// Start method for sending due emails until context is done.
+// Emails are checked after each enqueue and by the given interval (for retries).
+func (m *Mailer) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-m.notify:
+			case <-ticker.C:
+			}
+
+			if err := m.SendDue(ctx); err != nil {
+				log.Printf("mail: %v", err)
+			}
+		}
+	}()
+}
+
+// SendDue method for sending all emails with due sending attempt from the queue.
+func (m *Mailer) SendDue(ctx context.Context) error {
+	for {
+		// Claim next batch of the due emails.
+		messages, err := m.store.ClaimDueEmailMessages(10, 5*time.Minute)
+		if err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			return nil
+		}
+
+		for i := range messages {
+			if err := m.Send(ctx, &messages[i]); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Send method for one sending attempt of the queued email and saving its outcome to the store.
+// Returns only errors of the store.
+func (m *Mailer) Send(ctx context.Context, message *models.EmailMessage) error {
+	// Get recipient of the email.
+	recipient, err := m.store.GetEmailRecipientByID(message.UserID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return m.store.FinishEmailMessage(message.ID, repository.EmailMessageStatusFailed, "", "recipient not found")
+	case err != nil:
+		return m.retry(message, err)
+	}
+
+	// Check subscriptions and deliverability state of the recipient.
+	if !recipient.IsSubscribed(message.Stream) {
+		return m.store.FinishEmailMessage(
+			message.ID, repository.EmailMessageStatusSkipped, "",
+			fmt.Sprintf("recipient is not subscribed to the %s stream", message.Stream),
+		)
+	}
+	if !recipient.CanReceiveEmails() {
+		return m.store.FinishEmailMessage(
+			message.ID, repository.EmailMessageStatusSkipped, "",
+			"email address of the recipient is hard-bounced or marked email as spam",
+		)
+	}
+
+	// Check, if sender is set.
+	if m.sender == nil {
+		return m.retry(message, errors.New("mail sender is not set"))
+	}
+
+	// Send email with the recipient variables.
+	sendCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	email, err := m.buildMessage(message, &recipient)
+	if err != nil {
+		return m.retry(message, err)
+	}
+	providerMessageID, err := m.sender.Send(sendCtx, email)
+	switch {
+	case err == nil:
+		return m.store.FinishEmailMessage(message.ID, repository.EmailMessageStatusSent, providerMessageID, "")
+	case IsPermanent(err):
+		return m.store.FinishEmailMessage(message.ID, repository.EmailMessageStatusFailed, "", err.Error())
+	default:
+		return m.retry(message, err)
+	}
+}
+
+// retry (private) method for scheduling the next sending attempt or failing the email,
+// if all attempts are used.
+func (m *Mailer) retry(message *models.EmailMessage, sendingError error) error {
+	// Check, if all attempts are used.
+	if message.Attempts >= m.MaxAttempts {
+		return m.store.FinishEmailMessage(message.ID, repository.EmailMessageStatusFailed, "", sendingError.Error())
+	}
+
+	return m.store.RetryEmailMessage(message.ID, time.Now().Add(RetryDelay(message.Attempts)), sendingError.Error())
+}
+
+// buildMessage (private) method for building email with the template model of the queued email
+// and variables of the recipient (first_name, app_url, unsubscribe_url).
+func (m *Mailer) buildMessage(message *models.EmailMessage, recipient *models.EmailRecipient) (*Message, error) {
+	// Copy template model of the queued email.
+	model := map[string]interface{}{}
+	for key, value := range message.TemplateModel {
+		model[key] = value
+	}
+
+	// Set variables of the recipient.
+	model["first_name"] = recipient.Attrs.FirstName
+	model["app_url"] = m.AppURL
+	unsubscribeToken, err := helpers.GenerateUnsubscribeToken(recipient.ID, message.Stream)
+	if err != nil {
+		return nil, err
+	}
+	model["unsubscribe_url"] = m.UnsubscribeURL + "?token=" + unsubscribeToken
+
+	return &Message{
+		From:          m.From,
+		To:            recipient.Email,
+		Stream:        message.Stream,
+		Template:      message.Template,
+		TemplateModel: model,
+	}, nil
+}
+
+// RetryDelay func for getting delay before the next sending attempt after the given count of attempts.
+// Delay grows exponentially (1, 4, 16 ... minutes) up to 6 hours.
+func RetryDelay(attempts int) time.Duration {
+	// Define delay.
+	delay := time.Minute
+	for i := 1; i < attempts && delay < 6*time.Hour; i++ {
+		delay *= 4
+	}
+
+	if delay > 6*time.Hour {
+		return 6 * time.Hour
+	}
+	return delay
+}
+
+// databaseStore (private) struct to describe store of the queued emails in the database.
+type databaseStore struct{}
+
+// CreateEmailMessage method for saving a new email to the database.
+func (s *databaseStore) CreateEmailMessage(message *models.EmailMessage) error {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return err
+	}
+
+	return db.CreateEmailMessage(message)
+}
+
+// ClaimDueEmailMessages method for claiming due emails from the database.
+func (s *databaseStore) ClaimDueEmailMessages(limit int, lockTimeout time.Duration) ([]models.EmailMessage, error) {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return nil, err
+	}
+
+	return db.ClaimDueEmailMessages(limit, lockTimeout)
+}
+
+// GetEmailRecipientByID method for getting recipient from the database.
+func (s *databaseStore) GetEmailRecipientByID(userID uuid.UUID) (models.EmailRecipient, error) {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return models.EmailRecipient{}, err
+	}
+
+	recipient, _, err := db.GetEmailRecipientByID(userID)
+
+	return recipient, err
+}
+
+// FinishEmailMessage method for saving final outcome of the email sending to the database.
+func (s *databaseStore) FinishEmailMessage(id uuid.UUID, status, providerMessageID, sendingError string) error {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return err
+	}
+
+	return db.FinishEmailMessage(id, status, providerMessageID, sendingError)
+}
+
+// RetryEmailMessage method for scheduling the next sending attempt in the database.
+func (s *databaseStore) RetryEmailMessage(id uuid.UUID, nextAttemptAt time.Time, sendingError string) error {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return err
+	}
+
+	return db.RetryEmailMessage(id, nextAttemptAt, sendingError)
+}
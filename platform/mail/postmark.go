@@ -0,0 +1,108 @@
+package mail
+
+import (
+	"Komentory/api/pkg/repository"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// PostmarkSender struct to describe sender of the template emails by Postmark API.
+// See: https://postmarkapp.com/developer/api/templates-api#email-with-template
+type PostmarkSender struct {
+	ServerToken string
+	BaseURL     string
+	Client      *http.Client
+
+	// Streams map of the email streams (transactional, marketing) to Postmark message stream IDs.
+	Streams map[string]string
+}
+
+// postmarkResponse (private) struct to describe response of the Postmark API.
+type postmarkResponse struct {
+	ErrorCode int    `json:"ErrorCode"`
+	Message   string `json:"Message"`
+	MessageID string `json:"MessageID"`
+}
+
+// NewPostmarkSender func for create a new Postmark sender with the given server token.
+// Message stream IDs are set by POSTMARK_TRANSACTIONAL_STREAM (default: outbound)
+// and POSTMARK_MARKETING_STREAM (default: broadcast).
+func NewPostmarkSender(serverToken string) *PostmarkSender {
+	// Define Postmark message streams.
+	transactionalStream, marketingStream := os.Getenv("POSTMARK_TRANSACTIONAL_STREAM"), os.Getenv("POSTMARK_MARKETING_STREAM")
+	if transactionalStream == "" {
+		transactionalStream = "outbound"
+	}
+	if marketingStream == "" {
+		marketingStream = "broadcast"
+	}
+
+	return &PostmarkSender{
+		ServerToken: serverToken,
+		BaseURL:     "https://api.postmarkapp.com",
+		Client:      &http.Client{Timeout: 10 * time.Second},
+		Streams: map[string]string{
+			repository.EmailStreamTransactional: transactionalStream,
+			repository.EmailStreamMarketing:     marketingStream,
+		},
+	}
+}
+
+// Send method for sending email with the template from Postmark account.
+// Errors with 422 status (like inactive recipient or unknown template) are permanent.
+func (s *PostmarkSender) Send(ctx context.Context, m *Message) (string, error) {
+	// Check, if message stream is known.
+	messageStream, ok := s.Streams[m.Stream]
+	if !ok {
+		return "", &PermanentError{Err: fmt.Errorf("unknown email stream (%s)", m.Stream)}
+	}
+
+	// Create JSON body of the request.
+	body, err := json.Marshal(map[string]interface{}{
+		"From":          m.From,
+		"To":            m.To,
+		"TemplateAlias": m.Template,
+		"TemplateModel": m.TemplateModel,
+		"MessageStream": messageStream,
+	})
+	if err != nil {
+		return "", &PermanentError{Err: err}
+	}
+
+	// Create a new request to Postmark API.
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.BaseURL+"/email/withTemplate", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Postmark-Server-Token", s.ServerToken)
+
+	// Send request.
+	response, err := s.Client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	// Parse response of the Postmark API.
+	result := &postmarkResponse{}
+	if err := json.NewDecoder(response.Body).Decode(result); err != nil && response.StatusCode == http.StatusOK {
+		return "", err
+	}
+
+	// Switch response statuses.
+	switch {
+	case response.StatusCode == http.StatusOK && result.ErrorCode == 0:
+		return result.MessageID, nil
+	case response.StatusCode == http.StatusUnprocessableEntity:
+		return "", &PermanentError{Err: fmt.Errorf("postmark: %s (code %d)", result.Message, result.ErrorCode)}
+	default:
+		return "", fmt.Errorf("postmark: %s (status %d, code %d)", result.Message, response.StatusCode, result.ErrorCode)
+	}
+}
@@ -0,0 +1,48 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/smtp"
+	"net/textproto"
+)
+
+// SMTPSender struct to describe local stand-in sender, which sends rendered emails by SMTP server
+// (like MailHog for development).
+type SMTPSender struct {
+	Addr     string
+	Username string
+	Password string
+}
+
+// Send method for sending rendered email by SMTP server.
+// Errors with 5xx codes of the SMTP server are permanent.
+func (s *SMTPSender) Send(_ context.Context, m *Message) (string, error) {
+	// Render local template.
+	raw, err := renderRawMessage(m)
+	if err != nil {
+		return "", err
+	}
+
+	// Set authentication, if username was given.
+	var auth smtp.Auth
+	if s.Username != "" {
+		host, _, err := net.SplitHostPort(s.Addr)
+		if err != nil {
+			return "", &PermanentError{Err: err}
+		}
+		auth = smtp.PlainAuth("", s.Username, s.Password, host)
+	}
+
+	// Send email.
+	if err := smtp.SendMail(s.Addr, auth, m.From, []string{m.To}, raw); err != nil {
+		var protocolError *textproto.Error
+		if errors.As(err, &protocolError) && protocolError.Code >= 500 {
+			return "", &PermanentError{Err: err}
+		}
+		return "", err
+	}
+
+	return "", nil
+}
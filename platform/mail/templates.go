@@ -0,0 +1,50 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// templatesFS (private) embedded plain text templates for local senders (file, SMTP).
+// Postmark sender uses templates with the same aliases from Postmark account.
+//go:embed templates/*.txt
+var templatesFS embed.FS
+
+// templates (private) parsed local templates.
+var templates = template.Must(
+	template.New("").Option("missingkey=zero").ParseFS(templatesFS, "templates/*.txt"),
+)
+
+// Render func for rendering local template with the given model to subject and text body.
+// First line of the template must be "Subject: <subject>".
+func Render(name string, model map[string]interface{}) (string, string, error) {
+	// Check, if template is exists.
+	if templates.Lookup(name+".txt") == nil {
+		return "", "", &PermanentError{Err: fmt.Errorf("template %s not found", name)}
+	}
+
+	// Render template with the given model.
+	var buffer bytes.Buffer
+	if err := templates.ExecuteTemplate(&buffer, name+".txt", model); err != nil {
+		return "", "", &PermanentError{Err: err}
+	}
+
+	// Split rendered template to subject and body.
+	subject, body, _ := cut(buffer.String(), "\n")
+	if !strings.HasPrefix(subject, "Subject: ") {
+		return "", "", &PermanentError{Err: fmt.Errorf("template %s has no subject", name)}
+	}
+
+	return strings.TrimPrefix(subject, "Subject: "), strings.TrimSpace(body) + "\n", nil
+}
+
+// cut (private) func for slicing string around the first instance of separator.
+func cut(s, sep string) (string, string, bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
@@ -0,0 +1,12 @@
+-- Create answer_triages table
+CREATE TABLE answer_triages (
+    answer_id UUID PRIMARY KEY REFERENCES answers (id) ON DELETE CASCADE,
+    project_id UUID NOT NULL REFERENCES projects (id) ON DELETE CASCADE,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    label VARCHAR (32) NOT NULL DEFAULT '',
+    state VARCHAR (32) NOT NULL DEFAULT 'new',
+    note TEXT NOT NULL DEFAULT ''
+);
+
+-- Add indexes
+CREATE INDEX answer_triages_project_id_state_label ON answer_triages (project_id, state, label);
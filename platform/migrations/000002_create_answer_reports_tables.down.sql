@@ -0,0 +1,6 @@
+-- Delete tables
+DROP TABLE IF EXISTS audit_logs;
+DROP TABLE IF EXISTS answer_reports;
+
+-- Delete hidden_at column from answers table
+ALTER TABLE answers DROP COLUMN IF EXISTS hidden_at;
@@ -0,0 +1,31 @@
+-- Add hidden_at column to answers table
+ALTER TABLE answers ADD COLUMN hidden_at TIMESTAMP NULL;
+
+-- Create answer_reports table
+CREATE TABLE answer_reports (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP NULL,
+    answer_id UUID NOT NULL REFERENCES answers (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    reason VARCHAR (32) NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    UNIQUE (answer_id, user_id)
+);
+
+-- Create audit_logs table
+CREATE TABLE audit_logs (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    actor_id UUID NULL, -- NULL for automatic (system) actions
+    object_type VARCHAR (32) NOT NULL,
+    object_id UUID NOT NULL,
+    object_user_id UUID NOT NULL,
+    action VARCHAR (32) NOT NULL,
+    reason TEXT NOT NULL DEFAULT ''
+);
+
+-- Add indexes
+CREATE INDEX answer_reports_unresolved ON answer_reports (answer_id) WHERE resolved_at IS NULL;
+CREATE INDEX audit_logs_object ON audit_logs (object_type, object_id);
+CREATE INDEX audit_logs_object_user_id ON audit_logs (object_user_id);
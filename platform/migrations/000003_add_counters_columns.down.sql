@@ -0,0 +1,14 @@
+-- Delete indexes
+DROP INDEX IF EXISTS answers_task_id_user_id;
+DROP INDEX IF EXISTS answers_project_id_user_id;
+
+-- Delete counters columns from tasks table
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS answers_count,
+    DROP COLUMN IF EXISTS contributors_count;
+
+-- Delete counters columns from projects table
+ALTER TABLE projects
+    DROP COLUMN IF EXISTS tasks_count,
+    DROP COLUMN IF EXISTS answers_count,
+    DROP COLUMN IF EXISTS contributors_count;
@@ -0,0 +1,33 @@
+-- Add counters columns to projects table
+ALTER TABLE projects
+    ADD COLUMN tasks_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN answers_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN contributors_count INT NOT NULL DEFAULT 0;
+
+-- Add counters columns to tasks table
+ALTER TABLE tasks
+    ADD COLUMN answers_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN contributors_count INT NOT NULL DEFAULT 0;
+
+-- Fill counters of the existing tasks
+UPDATE tasks AS t
+SET
+    answers_count = c.answers_count,
+    contributors_count = c.contributors_count
+FROM (
+    SELECT task_id, COUNT(*) AS answers_count, COUNT(DISTINCT user_id) AS contributors_count
+    FROM answers
+    GROUP BY task_id
+) AS c
+WHERE c.task_id = t.id;
+
+-- Fill counters of the existing projects
+UPDATE projects AS p
+SET
+    tasks_count = (SELECT COUNT(*) FROM tasks WHERE project_id = p.id),
+    answers_count = (SELECT COUNT(*) FROM answers WHERE project_id = p.id),
+    contributors_count = (SELECT COUNT(DISTINCT user_id) FROM answers WHERE project_id = p.id);
+
+-- Add indexes
+CREATE INDEX IF NOT EXISTS answers_task_id_user_id ON answers (task_id, user_id);
+CREATE INDEX IF NOT EXISTS answers_project_id_user_id ON answers (project_id, user_id);
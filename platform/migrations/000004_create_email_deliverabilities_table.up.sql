@@ -0,0 +1,10 @@
+-- Create email_deliverabilities table
+CREATE TABLE email_deliverabilities (
+    user_id UUID PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    hard_bounced_at TIMESTAMP NULL,
+    bounce_type VARCHAR (64) NOT NULL DEFAULT '',
+    complained_at TIMESTAMP NULL,
+    last_delivered_at TIMESTAMP NULL,
+    last_opened_at TIMESTAMP NULL
+);
@@ -0,0 +1,18 @@
+-- Create webhook_events table
+CREATE TABLE webhook_events (
+    id UUID PRIMARY KEY,
+    received_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    vendor VARCHAR (32) NOT NULL,
+    event_type VARCHAR (64) NOT NULL,
+    event_key VARCHAR (255) NOT NULL, -- vendor event ID or SHA-256 hash of the raw body
+    raw_body TEXT NOT NULL,
+    status VARCHAR (16) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    locked_at TIMESTAMP NULL,
+    processed_at TIMESTAMP NULL,
+    error TEXT NOT NULL DEFAULT '',
+    UNIQUE (vendor, event_key)
+);
+
+-- Add indexes
+CREATE INDEX webhook_events_status ON webhook_events (status, received_at);
@@ -0,0 +1,2 @@
+-- Delete tables
+DROP TABLE IF EXISTS email_messages;
@@ -0,0 +1,20 @@
+-- Create email_messages table (outbound emails queue)
+CREATE TABLE email_messages (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    stream VARCHAR (32) NOT NULL,
+    template VARCHAR (64) NOT NULL,
+    template_model JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR (16) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    locked_at TIMESTAMP NULL,
+    sent_at TIMESTAMP NULL,
+    provider_message_id VARCHAR (255) NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT ''
+);
+
+-- Add indexes
+CREATE INDEX email_messages_status_next_attempt_at ON email_messages (status, next_attempt_at);
+CREATE INDEX email_messages_user_id ON email_messages (user_id);
@@ -0,0 +1,6 @@
+-- Create weekly_digests table (end of the period of the last sent digest for each project owner)
+CREATE TABLE weekly_digests (
+    user_id UUID PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    sent_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    period_end TIMESTAMP NOT NULL
+);
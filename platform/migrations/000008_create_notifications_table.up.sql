@@ -0,0 +1,17 @@
+-- Create notifications table
+CREATE TABLE notifications (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    read_at TIMESTAMP NULL,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    actor_id UUID NULL REFERENCES users (id) ON DELETE SET NULL,
+    notification_type VARCHAR (64) NOT NULL,
+    object_type VARCHAR (32) NOT NULL,
+    object_id UUID NOT NULL,
+    project_id UUID NOT NULL REFERENCES projects (id) ON DELETE CASCADE,
+    payload JSONB NOT NULL DEFAULT '{}'
+);
+
+-- Add indexes
+CREATE INDEX notifications_user_id_created_at ON notifications (user_id, created_at DESC);
+CREATE INDEX notifications_user_id_unread ON notifications (user_id) WHERE read_at IS NULL;
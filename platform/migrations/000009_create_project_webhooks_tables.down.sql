@@ -0,0 +1,3 @@
+-- Delete tables
+DROP TABLE IF EXISTS project_webhook_deliveries;
+DROP TABLE IF EXISTS project_webhooks;
@@ -0,0 +1,39 @@
+-- Create project_webhooks table (endpoints of the integrators)
+CREATE TABLE project_webhooks (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NULL,
+    project_id UUID NOT NULL REFERENCES projects (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    url VARCHAR (2048) NOT NULL,
+    secret VARCHAR (255) NOT NULL, -- key of the HMAC-SHA256 signature
+    events JSONB NOT NULL DEFAULT '[]', -- empty list for all events
+    active BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+-- Create project_webhook_deliveries table (outbound queue and delivery log)
+-- URL and secret are copied to the delivery, so project.deleted event is delivered after cascade deleting of the webhook.
+CREATE TABLE project_webhook_deliveries (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    webhook_id UUID NULL REFERENCES project_webhooks (id) ON DELETE SET NULL,
+    project_id UUID NOT NULL,
+    url VARCHAR (2048) NOT NULL,
+    secret VARCHAR (255) NOT NULL,
+    event_type VARCHAR (64) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR (16) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    locked_at TIMESTAMP NULL,
+    delivered_at TIMESTAMP NULL,
+    response_status INT NOT NULL DEFAULT 0,
+    response_body TEXT NOT NULL DEFAULT '', -- first 1 Kb of the last response
+    duration_ms INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT ''
+);
+
+-- Add indexes
+CREATE INDEX project_webhooks_project_id ON project_webhooks (project_id);
+CREATE INDEX project_webhook_deliveries_status_next_attempt_at ON project_webhook_deliveries (status, next_attempt_at);
+CREATE INDEX project_webhook_deliveries_webhook_id ON project_webhook_deliveries (webhook_id, created_at);
@@ -0,0 +1,19 @@
+-- Create domain_events table (transactional outbox of the project, task and answer changes)
+CREATE TABLE domain_events (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    aggregate_type VARCHAR (32) NOT NULL,
+    aggregate_id UUID NOT NULL,
+    project_id UUID NOT NULL,
+    event_type VARCHAR (32) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    locked_at TIMESTAMP NULL,
+    dispatched_at TIMESTAMP NULL,
+    error TEXT NOT NULL DEFAULT ''
+);
+
+-- Add indexes
+CREATE INDEX domain_events_pending ON domain_events (next_attempt_at) WHERE dispatched_at IS NULL;
+CREATE INDEX domain_events_aggregate ON domain_events (aggregate_type, aggregate_id, created_at);
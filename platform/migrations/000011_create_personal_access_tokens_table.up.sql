@@ -0,0 +1,16 @@
+-- Create personal_access_tokens table
+CREATE TABLE personal_access_tokens (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name VARCHAR (255) NOT NULL,
+    token_hash VARCHAR (64) NOT NULL UNIQUE,
+    token_prefix VARCHAR (16) NOT NULL,
+    credentials JSONB NOT NULL DEFAULT '[]',
+    expires_at TIMESTAMP NOT NULL,
+    last_used_at TIMESTAMP NULL,
+    revoked_at TIMESTAMP NULL
+);
+
+-- Add indexes
+CREATE INDEX personal_access_tokens_user_id_created_at ON personal_access_tokens (user_id, created_at DESC);
@@ -0,0 +1,17 @@
+-- Create revoked_tokens table
+CREATE TABLE revoked_tokens (
+    jti VARCHAR (255) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL
+);
+
+-- Create user_token_revocations table
+CREATE TABLE user_token_revocations (
+    user_id UUID PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    tokens_issued_before TIMESTAMP NOT NULL,
+    revoked_by UUID NULL REFERENCES users (id) ON DELETE SET NULL
+);
+
+-- Add indexes
+CREATE INDEX revoked_tokens_expires_at ON revoked_tokens (expires_at);
@@ -0,0 +1,13 @@
+-- Create project_collaborators table
+CREATE TABLE project_collaborators (
+    project_id UUID NOT NULL REFERENCES projects (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NULL,
+    role VARCHAR (16) NOT NULL,
+    invited_by UUID NULL REFERENCES users (id) ON DELETE SET NULL,
+    PRIMARY KEY (project_id, user_id)
+);
+
+-- Add indexes
+CREATE INDEX project_collaborators_user_id ON project_collaborators (user_id);
@@ -0,0 +1,9 @@
+-- Create rate_limit_counters table
+CREATE TABLE rate_limit_counters (
+    key VARCHAR (255) PRIMARY KEY,
+    count INT NOT NULL DEFAULT 0,
+    reset_at TIMESTAMP NOT NULL
+);
+
+-- Add indexes
+CREATE INDEX rate_limit_counters_reset_at ON rate_limit_counters (reset_at);
@@ -0,0 +1,231 @@
+package outbox
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/repository"
+	"Komentory/api/platform/database"
+	"Komentory/api/platform/stream"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Komentory/utilities"
+	"github.com/google/uuid"
+)
+
+// Subscriber func for handling one domain event from the outbox.
+// Events are delivered at least once (and may be delivered again after errors or crashes),
+// so subscribers must be idempotent.
+type Subscriber func(ctx context.Context, event *models.DomainEvent) error
+
+// Store interface for claiming domain events from the outbox and saving outcome of the dispatching.
+type Store interface {
+	ClaimPendingDomainEvents(limit int, lockTimeout time.Duration) ([]models.DomainEvent, error)
+	FinishDomainEvent(id uuid.UUID) error
+	RetryDomainEvent(id uuid.UUID, nextAttemptAt time.Time, dispatchError string) error
+}
+
+// Dispatcher struct to describe background dispatcher of the domain events from the outbox
+// to the in-process subscribers. Event is marked as dispatched only after all subscribers
+// handled it without errors, otherwise it is retried (for all subscribers) with exponential backoff.
+type Dispatcher struct {
+	mu          sync.RWMutex
+	names       []string
+	subscribers map[string]Subscriber
+	store       Store
+	notify      chan struct{}
+}
+
+// DefaultDispatcher dispatcher used by subscribers of the application.
+var DefaultDispatcher = NewDispatcher(&databaseStore{})
+
+// NewDispatcher func for create a new dispatcher with the given store.
+func NewDispatcher(store Store) *Dispatcher {
+	return &Dispatcher{
+		subscribers: map[string]Subscriber{},
+		store:       store,
+		notify:      make(chan struct{}, 1),
+	}
+}
+
+// Subscribe func for adding subscriber with the given (unique) name to the default dispatcher.
+func Subscribe(name string, subscriber Subscriber) {
+	DefaultDispatcher.Subscribe(name, subscriber)
+}
+
+// StartDefaultDispatcher func for start default dispatcher, which is woken up by PostgreSQL
+// notifications of the committed events (and checks the outbox every minute for retries).
+func StartDefaultDispatcher(ctx context.Context) error {
+	// Build PostgreSQL connection URL.
+	url, err := utilities.ConnectionURLBuilder(utilities.DBConnectionName)
+	if err != nil {
+		return err
+	}
+
+	// Listen notifications of the committed events.
+	listener := &stream.PostgresPubSub{URL: url, Channel: repository.DomainEventsChannel}
+	if err := listener.Subscribe(ctx, func([]byte) { DefaultDispatcher.Notify() }); err != nil {
+		return err
+	}
+
+	// Start default dispatcher (and dispatch events, which were saved before start).
+	DefaultDispatcher.Start(ctx, time.Minute)
+	DefaultDispatcher.Notify()
+
+	return nil
+}
+
+// Subscribe method for adding (or replacing) subscriber with the given name.
+// Subscribers are called in order of their adding.
+func (d *Dispatcher) Subscribe(name string, subscriber Subscriber) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.subscribers[name]; !ok {
+		d.names = append(d.names, name)
+	}
+	d.subscribers[name] = subscriber
+}
+
+// Notify method for waking up the dispatcher after a new event was committed (without blocking).
+func (d *Dispatcher) Notify() {
+	select {
+	case d.notify <- struct{}{}:
+	default: // dispatcher is already notified
+	}
+}
+
+// Start method for dispatching events until context is done.
+// Events are checked after each notify and by the given interval (for retries and failed API instances).
+func (d *Dispatcher) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-d.notify:
+			case <-ticker.C:
+			}
+
+			if err := d.DispatchPending(ctx); err != nil {
+				log.Printf("outbox: %v", err)
+			}
+		}
+	}()
+}
+
+// DispatchPending method for dispatching all events with due attempt from the outbox.
+func (d *Dispatcher) DispatchPending(ctx context.Context) error {
+	for {
+		// Claim next batch of the events.
+		events, err := d.store.ClaimPendingDomainEvents(100, 5*time.Minute)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		for i := range events {
+			if err := d.Dispatch(ctx, &events[i]); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Dispatch method for delivering one claimed event to all subscribers and saving outcome to the store.
+// Returns only errors of the store.
+func (d *Dispatcher) Dispatch(ctx context.Context, event *models.DomainEvent) error {
+	// Copy subscribers, so they can be changed while dispatching.
+	d.mu.RLock()
+	names := append([]string{}, d.names...)
+	subscribers := make([]Subscriber, len(names))
+	for i, name := range names {
+		subscribers[i] = d.subscribers[name]
+	}
+	d.mu.RUnlock()
+
+	// Deliver event to each subscriber and collect errors.
+	errs := []string{}
+	for i, subscriber := range subscribers {
+		if err := call(ctx, subscriber, event); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", names[i], err))
+		}
+	}
+
+	// Check, if all subscribers handled the event.
+	if len(errs) > 0 {
+		return d.store.RetryDomainEvent(event.ID, time.Now().Add(RetryDelay(event.Attempts)), strings.Join(errs, "; "))
+	}
+
+	return d.store.FinishDomainEvent(event.ID)
+}
+
+// call (private) func for calling subscriber with recovering from panic.
+func call(ctx context.Context, subscriber Subscriber, event *models.DomainEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("subscriber panic: %v", r)
+		}
+	}()
+
+	return subscriber(ctx, event)
+}
+
+// RetryDelay func for getting delay before the next dispatching attempt after the given count of attempts.
+// Delay grows exponentially (2, 4, 8 ... seconds) up to 1 hour, events are never dropped.
+func RetryDelay(attempts int) time.Duration {
+	// Define delay.
+	delay := 2 * time.Second
+	for i := 1; i < attempts && delay < time.Hour; i++ {
+		delay *= 2
+	}
+
+	if delay > time.Hour {
+		return time.Hour
+	}
+	return delay
+}
+
+// databaseStore (private) struct to describe outbox in the database.
+type databaseStore struct{}
+
+// ClaimPendingDomainEvents method for claiming events from the database.
+func (s *databaseStore) ClaimPendingDomainEvents(limit int, lockTimeout time.Duration) ([]models.DomainEvent, error) {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return nil, err
+	}
+
+	return db.ClaimPendingDomainEvents(limit, lockTimeout)
+}
+
+// FinishDomainEvent method for marking event as dispatched in the database.
+func (s *databaseStore) FinishDomainEvent(id uuid.UUID) error {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return err
+	}
+
+	return db.FinishDomainEvent(id)
+}
+
+// RetryDomainEvent method for scheduling the next dispatching attempt in the database.
+func (s *databaseStore) RetryDomainEvent(id uuid.UUID, nextAttemptAt time.Time, dispatchError string) error {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return err
+	}
+
+	return db.RetryDomainEvent(id, nextAttemptAt, dispatchError)
+}
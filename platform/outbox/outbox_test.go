@@ -0,0 +1,126 @@
+package outbox
+
+import (
+	"Komentory/api/app/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubStore (private) struct to describe outbox, which keeps events in memory.
+type stubStore struct {
+	events     []*models.DomainEvent
+	dispatched map[uuid.UUID]bool
+}
+
+// ClaimPendingDomainEvents method for claiming not dispatched events with due attempt from memory.
+func (s *stubStore) ClaimPendingDomainEvents(_ int, _ time.Duration) ([]models.DomainEvent, error) {
+	events := []models.DomainEvent{}
+	for _, e := range s.events {
+		if !s.dispatched[e.ID] && !e.NextAttemptAt.After(time.Now()) && e.LockedAt == nil {
+			now := time.Now()
+			e.Attempts++
+			e.LockedAt = &now
+			events = append(events, *e)
+		}
+	}
+	return events, nil
+}
+
+// FinishDomainEvent method for marking event as dispatched in memory.
+func (s *stubStore) FinishDomainEvent(id uuid.UUID) error {
+	s.dispatched[id] = true
+	return nil
+}
+
+// RetryDomainEvent method for scheduling the next attempt in memory.
+func (s *stubStore) RetryDomainEvent(id uuid.UUID, nextAttemptAt time.Time, dispatchError string) error {
+	for _, e := range s.events {
+		if e.ID == id {
+			e.NextAttemptAt, e.LockedAt, e.Error = nextAttemptAt, nil, dispatchError
+		}
+	}
+	return nil
+}
+
+func TestDispatchPending(t *testing.T) {
+	// Create events in the outbox.
+	first := &models.DomainEvent{ID: uuid.New(), AggregateType: "answer", EventType: "created"}
+	second := &models.DomainEvent{ID: uuid.New(), AggregateType: "answer", EventType: "updated"}
+	store := &stubStore{events: []*models.DomainEvent{first, second}, dispatched: map[uuid.UUID]bool{}}
+
+	// Add subscribers, one of them fails once for the second event and one panics.
+	dispatcher := NewDispatcher(store)
+	received, failed := []string{}, false
+	dispatcher.Subscribe("log", func(_ context.Context, e *models.DomainEvent) error {
+		received = append(received, e.EventType)
+		return nil
+	})
+	dispatcher.Subscribe("flaky", func(_ context.Context, e *models.DomainEvent) error {
+		if e.ID == second.ID && !failed {
+			failed = true
+			return errors.New("cache is not available")
+		}
+		return nil
+	})
+
+	// Dispatch events: the second event is not dispatched and scheduled for retry.
+	assert.NoError(t, dispatcher.DispatchPending(context.Background()))
+	assert.Equal(t, []string{"created", "updated"}, received)
+	assert.True(t, store.dispatched[first.ID])
+	assert.False(t, store.dispatched[second.ID])
+	assert.Equal(t, "flaky: cache is not available", second.Error)
+	assert.True(t, second.NextAttemptAt.After(time.Now()))
+
+	// Retry is delivered again to all subscribers (at least once).
+	second.NextAttemptAt = time.Now()
+	assert.NoError(t, dispatcher.DispatchPending(context.Background()))
+	assert.Equal(t, []string{"created", "updated", "updated"}, received)
+	assert.True(t, store.dispatched[second.ID])
+	assert.Equal(t, 2, second.Attempts)
+}
+
+func TestDispatchPanic(t *testing.T) {
+	// Create event in the outbox and subscriber, which panics.
+	event := &models.DomainEvent{ID: uuid.New()}
+	store := &stubStore{events: []*models.DomainEvent{event}, dispatched: map[uuid.UUID]bool{}}
+	dispatcher := NewDispatcher(store)
+	dispatcher.Subscribe("broken", func(context.Context, *models.DomainEvent) error { panic("nil map") })
+
+	// Check, if panic is saved as error of the event.
+	assert.NoError(t, dispatcher.DispatchPending(context.Background()))
+	assert.False(t, store.dispatched[event.ID])
+	assert.Equal(t, "broken: subscriber panic: nil map", event.Error)
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 2*time.Second, RetryDelay(1))
+	assert.Equal(t, 8*time.Second, RetryDelay(3))
+	assert.Equal(t, time.Hour, RetryDelay(30))
+}
+
+func TestAnswerEventType(t *testing.T) {
+	// Define a structure for specifying input and output data of a single test case.
+	tests := []struct {
+		description     string
+		domainEventType string
+		visible         bool
+		expected        string
+	}{
+		{"new visible answer", "created", true, "answer.created"},
+		{"new not visible answer", "created", false, ""},
+		{"updated visible answer", "updated", true, "answer.updated"},
+		{"updated not visible answer", "updated", false, "answer.deleted"},
+		{"deleted answer", "deleted", false, "answer.deleted"},
+		{"changed triage state", "status_changed", true, ""},
+	}
+
+	// Iterate through test single test cases.
+	for _, test := range tests {
+		assert.Equalf(t, test.expected, answerEventType(test.domainEventType, test.visible), test.description)
+	}
+}
@@ -0,0 +1,193 @@
+package outbox
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/repository"
+	"Komentory/api/platform/database"
+	"Komentory/api/platform/stream"
+	"Komentory/api/platform/webhooks"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// SubscribeDefaults func for adding subscribers of the application to the default dispatcher:
+// answer streams (SSE and WebSocket) and webhooks of the projects.
+func SubscribeDefaults() {
+	Subscribe("answer_streams", PublishAnswerEvent)
+	Subscribe("project_webhooks", EmitProjectWebhookEvent)
+}
+
+// PublishAnswerEvent func for publishing domain event of the answer to the answer streams.
+func PublishAnswerEvent(ctx context.Context, event *models.DomainEvent) error {
+	// Only answers are streamed.
+	if event.AggregateType != repository.DomainAggregateAnswer {
+		return nil
+	}
+
+	// Get event of the answer.
+	eventType, taskID, _, err := answerEvent(event)
+	if err != nil || eventType == "" {
+		return err
+	}
+
+	return stream.DefaultBroadcaster.Publish(ctx, &stream.Event{
+		Type:      eventType,
+		ProjectID: event.ProjectID,
+		TaskID:    taskID,
+		AnswerID:  event.AggregateID,
+	})
+}
+
+// EmitProjectWebhookEvent func for queueing domain event of the project, task or answer
+// to the webhooks of the project. Data of the event is the current state of the object.
+func EmitProjectWebhookEvent(_ context.Context, event *models.DomainEvent) error {
+	// Deliveries of the deleted project are queued with deleting of the project (webhooks are deleted with it).
+	if event.AggregateType == repository.DomainAggregateProject && event.EventType == repository.DomainEventDeleted {
+		webhooks.DefaultDispatcher.Wake()
+		return nil
+	}
+
+	// Get event of the project webhooks.
+	eventType, data, err := projectWebhookEvent(event)
+	if err != nil || eventType == "" {
+		return err
+	}
+
+	return webhooks.DefaultDispatcher.Emit(event.ID, event.ProjectID, eventType, data)
+}
+
+// projectWebhookEvent (private) func for getting type and data of the project webhook event
+// by the domain event. Empty type means, that webhooks don't get this event.
+func projectWebhookEvent(event *models.DomainEvent) (string, interface{}, error) {
+	// Answers are sent like to the answer streams (with data of the active answer).
+	if event.AggregateType == repository.DomainAggregateAnswer {
+		eventType, taskID, answer, err := answerEvent(event)
+		if eventType == repository.AnswerEventDeleted {
+			return eventType, fiber.Map{"id": event.AggregateID, "task_id": taskID}, err
+		}
+		return eventType, answer, err
+	}
+
+	// Get type of the event.
+	eventType, ok := webhookEventTypes[event.AggregateType][event.EventType]
+	if !ok {
+		return "", nil, nil
+	}
+
+	// Deleted objects have only ID.
+	if event.EventType == repository.DomainEventDeleted {
+		return eventType, fiber.Map{"id": event.AggregateID}, nil
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Get current state of the object (object, deleted later, gets its own deleted event).
+	var data interface{}
+	switch event.AggregateType {
+	case repository.DomainAggregateProject:
+		data, _, err = db.GetProjectByID(event.AggregateID)
+	case repository.DomainAggregateTask:
+		data, _, err = db.GetTaskByID(event.AggregateID)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil, nil
+	}
+
+	return eventType, data, err
+}
+
+// webhookEventTypes (private) types of the project webhook events by aggregate and type of the domain event
+// (there is no "project.created" event, because webhooks are registered on the existing project).
+var webhookEventTypes = map[string]map[string]string{
+	repository.DomainAggregateProject: {
+		repository.DomainEventUpdated: repository.ProjectEventUpdated,
+		repository.DomainEventDeleted: repository.ProjectEventDeleted,
+	},
+	repository.DomainAggregateTask: {
+		repository.DomainEventCreated: repository.TaskEventCreated,
+		repository.DomainEventUpdated: repository.TaskEventUpdated,
+		repository.DomainEventDeleted: repository.TaskEventDeleted,
+	},
+}
+
+// answerEvent (private) func for getting type of the answer event, task ID and current state of the answer
+// by the domain event. Empty type means, that streams and webhooks don't get this event.
+func answerEvent(event *models.DomainEvent) (string, uuid.UUID, *models.GetAnswer, error) {
+	// Get task ID from payload of the event.
+	taskID, _ := uuid.Parse(stringValue(event.Payload["task_id"]))
+
+	// Deleted answer has only ID.
+	if event.EventType == repository.DomainEventDeleted {
+		return repository.AnswerEventDeleted, taskID, nil, nil
+	}
+	if event.EventType != repository.DomainEventCreated && event.EventType != repository.DomainEventUpdated {
+		return "", taskID, nil, nil
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return "", taskID, nil, err
+	}
+
+	// Get current state of the answer (hidden answers are not found).
+	answer, _, err := db.GetAnswerByID(event.AggregateID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", taskID, nil, err
+	}
+	visible := err == nil && answer.Status == 1
+
+	// Get task ID of the hidden answer (answer, deleted later, gets its own deleted event).
+	if err != nil {
+		foundedAnswer, _, err := db.FindAnswerByID(event.AggregateID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", taskID, nil, nil
+		}
+		if err != nil {
+			return "", taskID, nil, err
+		}
+		answer.TaskID = foundedAnswer.TaskID
+	}
+
+	// Get type of the event by visibility of the answer.
+	eventType := answerEventType(event.EventType, visible)
+	if eventType == repository.AnswerEventDeleted {
+		return eventType, answer.TaskID, nil, nil
+	}
+
+	return eventType, answer.TaskID, &answer, nil
+}
+
+// answerEventType (private) func for getting type of the answer event (for streams and webhooks)
+// by type of the domain event and visibility of the answer (active and not hidden):
+//  - new visible answer is created, new not visible answer is not sent;
+//  - updated visible answer is updated, updated not visible answer is deleted (removed from the lists);
+//  - deleted answer is deleted;
+func answerEventType(domainEventType string, visible bool) string {
+	switch {
+	case domainEventType == repository.DomainEventDeleted:
+		return repository.AnswerEventDeleted
+	case domainEventType == repository.DomainEventCreated && visible:
+		return repository.AnswerEventCreated
+	case domainEventType == repository.DomainEventUpdated && visible:
+		return repository.AnswerEventUpdated
+	case domainEventType == repository.DomainEventUpdated:
+		return repository.AnswerEventDeleted
+	default:
+		return ""
+	}
+}
+
+// stringValue (private) func for getting string from the value of the payload.
+func stringValue(value interface{}) string {
+	s, _ := value.(string)
+	return s
+}
@@ -0,0 +1,197 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Komentory/utilities"
+	"github.com/google/uuid"
+)
+
+// Event struct to describe one event of the answer (created, updated, deleted).
+// ID is a time of the event in unix nanoseconds (for resume by Last-Event-ID).
+type Event struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	ProjectID uuid.UUID `json:"project_id"`
+	TaskID    uuid.UUID `json:"task_id"`
+	AnswerID  uuid.UUID `json:"answer_id"`
+}
+
+// Filter struct to describe events of which project or task are streamed to the subscriber.
+// Empty (uuid.Nil) fields match any value.
+type Filter struct {
+	ProjectID uuid.UUID
+	TaskID    uuid.UUID
+}
+
+// Subscription struct to describe one subscriber (like SSE or WebSocket connection) of the broadcaster.
+type Subscription struct {
+	filter      Filter
+	events      chan *Event
+	broadcaster *Broadcaster
+	closeOnce   sync.Once
+}
+
+// Broadcaster struct to describe broadcaster of the events to subscribers of the current API instance.
+// Events are published through pub/sub backend, so subscribers of all API instances get them.
+// The last events are kept in history for resuming of the streams.
+type Broadcaster struct {
+	pubsub      PubSub
+	mu          sync.RWMutex
+	subscribers map[*Subscription]struct{}
+	history     []*Event
+	historySize int
+}
+
+// DefaultBroadcaster broadcaster used by controllers (in-memory, until StartDefaultBroadcaster is called).
+var DefaultBroadcaster = NewBroadcaster(NewMemoryPubSub(), 1000)
+
+// NewBroadcaster func for create a new broadcaster with the given pub/sub backend and size of the history.
+func NewBroadcaster(pubsub PubSub, historySize int) *Broadcaster {
+	return &Broadcaster{
+		pubsub:      pubsub,
+		subscribers: map[*Subscription]struct{}{},
+		historySize: historySize,
+	}
+}
+
+// StartDefaultBroadcaster func for start default broadcaster with pub/sub backend from STREAM_PUBSUB:
+//  - "postgres", for PostgreSQL LISTEN/NOTIFY (events are delivered across multiple API instances);
+//  - "memory" or "", for the current API instance only;
+// Size of the history is set by STREAM_HISTORY_SIZE (default: 1000).
+func StartDefaultBroadcaster(ctx context.Context) error {
+	// Define size of the history.
+	historySize, err := strconv.Atoi(os.Getenv("STREAM_HISTORY_SIZE"))
+	if err != nil || historySize <= 0 {
+		historySize = 1000
+	}
+
+	// Define pub/sub backend.
+	var pubsub PubSub = NewMemoryPubSub()
+	if os.Getenv("STREAM_PUBSUB") == "postgres" {
+		// Build PostgreSQL connection URL.
+		url, err := utilities.ConnectionURLBuilder(utilities.DBConnectionName)
+		if err != nil {
+			return err
+		}
+		pubsub = &PostgresPubSub{URL: url, Channel: "answer_events"}
+	}
+
+	// Create and start default broadcaster.
+	DefaultBroadcaster = NewBroadcaster(pubsub, historySize)
+
+	return DefaultBroadcaster.Start(ctx)
+}
+
+// Start method for receiving events from pub/sub backend.
+func (b *Broadcaster) Start(ctx context.Context) error {
+	return b.pubsub.Subscribe(ctx, b.dispatch)
+}
+
+// Publish method for publishing event through pub/sub backend.
+// ID and time of the event are set, if they are empty.
+func (b *Broadcaster) Publish(ctx context.Context, event *Event) error {
+	// Set ID and time of the event.
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	if event.ID == "" {
+		event.ID = strconv.FormatInt(event.CreatedAt.UnixNano(), 10)
+	}
+
+	// Encode event to JSON.
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return b.pubsub.Publish(ctx, payload)
+}
+
+// Subscribe method for creating a new subscription with the given filter.
+// Returns events from history after the given last event ID (empty for a new stream).
+func (b *Broadcaster) Subscribe(filter Filter, lastEventID string) (*Subscription, []*Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// Create a new subscription.
+	subscription := &Subscription{
+		filter:      filter,
+		events:      make(chan *Event, 64),
+		broadcaster: b,
+	}
+	b.subscribers[subscription] = struct{}{}
+
+	// Collect missed events from history.
+	missed := []*Event{}
+	if lastID, err := strconv.ParseInt(lastEventID, 10, 64); err == nil {
+		for _, event := range b.history {
+			if id, _ := strconv.ParseInt(event.ID, 10, 64); id > lastID && filter.Match(event) {
+				missed = append(missed, event)
+			}
+		}
+	}
+
+	return subscription, missed
+}
+
+// dispatch (private) method for saving received event to history and sending it to subscribers.
+// Slow subscribers, which do not read events, are closed.
+func (b *Broadcaster) dispatch(payload []byte) {
+	// Decode event from JSON.
+	event := &Event{}
+	if err := json.Unmarshal(payload, event); err != nil {
+		log.Printf("stream: %v", err)
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// Save event to history.
+	b.history = append(b.history, event)
+	if len(b.history) > b.historySize {
+		b.history = b.history[len(b.history)-b.historySize:]
+	}
+
+	// Send event to subscribers without blocking.
+	for subscription := range b.subscribers {
+		if !subscription.filter.Match(event) {
+			continue
+		}
+		select {
+		case subscription.events <- event:
+		default:
+			delete(b.subscribers, subscription)
+			subscription.closeOnce.Do(func() { close(subscription.events) })
+		}
+	}
+}
+
+// Match method for checking, if event matches the filter.
+func (f Filter) Match(event *Event) bool {
+	return (f.ProjectID == uuid.Nil || f.ProjectID == event.ProjectID) &&
+		(f.TaskID == uuid.Nil || f.TaskID == event.TaskID)
+}
+
+// Events method for getting channel of the subscription events.
+// Channel is closed, if subscriber was too slow or subscription was closed.
+func (s *Subscription) Events() <-chan *Event {
+	return s.events
+}
+
+// Close method for removing subscription from the broadcaster.
+func (s *Subscription) Close() {
+	s.broadcaster.mu.Lock()
+	defer s.broadcaster.mu.Unlock()
+
+	delete(s.broadcaster.subscribers, s)
+	s.closeOnce.Do(func() { close(s.events) })
+}
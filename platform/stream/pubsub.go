@@ -0,0 +1,136 @@
+package stream
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// PubSub interface for delivering published events to all API instances.
+type PubSub interface {
+	Publish(ctx context.Context, payload []byte) error
+	Subscribe(ctx context.Context, handler func(payload []byte)) error
+}
+
+// MemoryPubSub struct to describe pub/sub backend inside the current API instance only.
+type MemoryPubSub struct {
+	mu       sync.RWMutex
+	handlers []func(payload []byte)
+}
+
+// NewMemoryPubSub func for create a new in-memory pub/sub backend.
+func NewMemoryPubSub() *MemoryPubSub {
+	return &MemoryPubSub{}
+}
+
+// Publish method for delivering payload to all subscribers of the current API instance.
+func (p *MemoryPubSub) Publish(_ context.Context, payload []byte) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, handler := range p.handlers {
+		handler(payload)
+	}
+
+	return nil
+}
+
+// Subscribe method for adding handler of the published payloads.
+func (p *MemoryPubSub) Subscribe(_ context.Context, handler func(payload []byte)) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.handlers = append(p.handlers, handler)
+
+	return nil
+}
+
+// PostgresPubSub struct to describe pub/sub backend by PostgreSQL LISTEN/NOTIFY
+// for delivering events across multiple API instances.
+// Payload must be less than 8000 bytes.
+type PostgresPubSub struct {
+	URL     string
+	Channel string
+
+	mu   sync.Mutex
+	conn *pgx.Conn // connection for publishing
+}
+
+// Publish method for sending payload to the channel by pg_notify.
+// Connection for publishing is reused and restored after errors.
+func (p *PostgresPubSub) Publish(ctx context.Context, payload []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Create a new connection, if it is not exists.
+	if p.conn == nil {
+		conn, err := pgx.Connect(ctx, p.URL)
+		if err != nil {
+			return err
+		}
+		p.conn = conn
+	}
+
+	// Send notification.
+	if _, err := p.conn.Exec(ctx, "SELECT pg_notify($1, $2)", p.Channel, string(payload)); err != nil {
+		p.conn.Close(context.Background())
+		p.conn = nil
+		return err
+	}
+
+	return nil
+}
+
+// Subscribe method for listening the channel in background until context is done.
+// Connection is restored after errors.
+func (p *PostgresPubSub) Subscribe(ctx context.Context, handler func(payload []byte)) error {
+	// Check connection before start listening.
+	conn, err := p.listen(ctx)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			// Wait for notifications until error.
+			for conn != nil {
+				notification, err := conn.WaitForNotification(ctx)
+				if err != nil {
+					conn.Close(context.Background())
+					conn = nil
+					break
+				}
+				handler([]byte(notification.Payload))
+			}
+
+			// Reconnect after pause, if context is not done.
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+				conn, _ = p.listen(ctx)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// listen (private) method for creating a new connection, which listens the channel.
+func (p *PostgresPubSub) listen(ctx context.Context) (*pgx.Conn, error) {
+	// Create a new connection.
+	conn, err := pgx.Connect(ctx, p.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start listening the channel.
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{p.Channel}.Sanitize()); err != nil {
+		conn.Close(context.Background())
+		return nil, err
+	}
+
+	return conn, nil
+}
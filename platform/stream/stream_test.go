@@ -0,0 +1,91 @@
+package stream
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestBroadcaster (private) func for create a started broadcaster with in-memory pub/sub backend.
+func newTestBroadcaster(t *testing.T, historySize int) *Broadcaster {
+	b := NewBroadcaster(NewMemoryPubSub(), historySize)
+	assert.NoError(t, b.Start(context.Background()))
+	return b
+}
+
+func TestBroadcasterFilter(t *testing.T) {
+	b := newTestBroadcaster(t, 10)
+	projectID, otherProjectID, taskID := uuid.New(), uuid.New(), uuid.New()
+
+	// Subscribe to events of the project and of the task.
+	byProject, _ := b.Subscribe(Filter{ProjectID: projectID}, "")
+	byTask, _ := b.Subscribe(Filter{TaskID: taskID}, "")
+	defer byProject.Close()
+	defer byTask.Close()
+
+	// Publish events of two projects.
+	assert.NoError(t, b.Publish(context.Background(), &Event{Type: "answer.created", ProjectID: otherProjectID, TaskID: uuid.New()}))
+	assert.NoError(t, b.Publish(context.Background(), &Event{Type: "answer.updated", ProjectID: projectID, TaskID: taskID}))
+
+	// Only events of the project and of the task are received.
+	assert.Len(t, byProject.Events(), 1)
+	assert.Len(t, byTask.Events(), 1)
+	event := <-byProject.Events()
+	assert.Equal(t, "answer.updated", event.Type)
+	assert.NotEmpty(t, event.ID)
+}
+
+func TestBroadcasterResume(t *testing.T) {
+	b := newTestBroadcaster(t, 2)
+	projectID, now := uuid.New(), time.Now()
+
+	// Publish three events (only two last events are kept in history).
+	for i := 0; i < 3; i++ {
+		createdAt := now.Add(time.Duration(i) * time.Second)
+		assert.NoError(t, b.Publish(context.Background(), &Event{Type: "answer.created", CreatedAt: createdAt, ProjectID: projectID}))
+	}
+
+	tests := []struct {
+		name        string
+		lastEventID string
+		missed      int
+	}{
+		{"new stream", "", 0},
+		{"resume after first event", strconv.FormatInt(now.UnixNano(), 10), 2},
+		{"resume after last event", strconv.FormatInt(now.Add(2*time.Second).UnixNano(), 10), 0},
+		{"resume before history", strconv.FormatInt(now.Add(-time.Second).UnixNano(), 10), 2},
+		{"wrong last event ID", "wrong", 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			subscription, missed := b.Subscribe(Filter{ProjectID: projectID}, test.lastEventID)
+			defer subscription.Close()
+			assert.Len(t, missed, test.missed)
+		})
+	}
+}
+
+func TestBroadcasterSlowSubscriber(t *testing.T) {
+	b := newTestBroadcaster(t, 10)
+
+	// Subscribe and never read events.
+	subscription, _ := b.Subscribe(Filter{}, "")
+
+	// Publish more events, than buffer of the subscription can keep.
+	for i := 0; i <= cap(subscription.events); i++ {
+		assert.NoError(t, b.Publish(context.Background(), &Event{Type: "answer.created"}))
+	}
+
+	// Drain buffered events, then channel must be closed.
+	for range subscription.Events() {
+	}
+	assert.Empty(t, b.subscribers)
+
+	// Closing of already closed subscription is safe.
+	subscription.Close()
+}
@@ -0,0 +1,197 @@
+package unfurl
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/helpers"
+	"Komentory/api/pkg/repository"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// Fetcher interface for fetching metadata (title, description, favicon) of the given link.
+type Fetcher interface {
+	Fetch(ctx context.Context, link string) (*models.LinkMetadata, error)
+}
+
+// HTTPFetcher struct to describe fetcher of the link metadata from HTML page by HTTP.
+//  - MaxBodySize is a limit of bytes to read from the page;
+//  - AllowPrivateNetworks allows requests to loopback and private IP addresses (only for tests);
+type HTTPFetcher struct {
+	Client               *http.Client
+	MaxBodySize          int64
+	AllowPrivateNetworks bool
+}
+
+// NewHTTPFetcher func for create a new HTTP fetcher with timeout from env (LINK_UNFURL_TIMEOUT_SECONDS).
+func NewHTTPFetcher() *HTTPFetcher {
+	// Define timeout of the one fetch.
+	timeoutSeconds, err := strconv.Atoi(os.Getenv("LINK_UNFURL_TIMEOUT_SECONDS"))
+	if err != nil || timeoutSeconds <= 0 {
+		timeoutSeconds = 5
+	}
+
+	return &HTTPFetcher{
+		Client:      &http.Client{Timeout: time.Duration(timeoutSeconds) * time.Second},
+		MaxBodySize: 512 * 1024, // 512 Kb
+	}
+}
+
+// Fetch method for fetching metadata of the given link from its HTML page.
+func (f *HTTPFetcher) Fetch(ctx context.Context, link string) (*models.LinkMetadata, error) {
+	// Check, if link is safe to fetch.
+	if err := helpers.ValidateURL(link); err != nil {
+		return nil, err
+	}
+
+	// Create a new request.
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/html")
+	req.Header.Set("User-Agent", "KomentoryLinkUnfurl/1.0")
+
+	// Send request by the client, which denies private networks and unsafe redirects.
+	resp, err := f.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Check response status and content type.
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("link responded with status %d", resp.StatusCode)
+	}
+	if contentType := resp.Header.Get("Content-Type"); !strings.Contains(contentType, "text/html") {
+		return nil, fmt.Errorf("link has unsupported content type (%s)", contentType)
+	}
+
+	// Parse metadata from the (limited) HTML page.
+	metadata := parseMetadata(io.LimitReader(resp.Body, f.MaxBodySize), resp.Request.URL)
+	metadata.URL = link
+	metadata.FetchedAt = time.Now()
+
+	return metadata, nil
+}
+
+// client (private) method for getting copy of the HTTP client with safe dialer and redirect policy.
+func (f *HTTPFetcher) client() *http.Client {
+	// Copy client settings.
+	client := *f.Client
+
+	// Deny connections to loopback and private IP addresses.
+	if !f.AllowPrivateNetworks {
+		client.Transport = helpers.PublicNetworkTransport(client.Timeout)
+	}
+
+	// Check each redirect URL and limit count of redirects.
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 5 {
+			return errors.New("link has too many redirects")
+		}
+		return helpers.ValidateURL(req.URL.String())
+	}
+
+	return &client
+}
+
+// parseMetadata (private) func for parsing title, description and favicon from the HTML page.
+// Open Graph tags have priority over <title> and <meta name="description"> tags.
+func parseMetadata(body io.Reader, pageURL *url.URL) *models.LinkMetadata {
+	// Define metadata and fallback values.
+	metadata := &models.LinkMetadata{}
+	title, description, favicon := "", "", "/favicon.ico"
+
+	// Create a new HTML tokenizer.
+	tokenizer := html.NewTokenizer(body)
+
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break // end of the page (or limit of the body)
+		}
+
+		// Get current tag.
+		token := tokenizer.Token()
+		if tokenType == html.EndTagToken && token.Data == "head" {
+			break // all metadata is in <head> tag
+		}
+		if tokenType != html.StartTagToken && tokenType != html.SelfClosingTagToken {
+			continue
+		}
+
+		// Switch tags with metadata.
+		switch token.Data {
+		case "title":
+			if title == "" && tokenizer.Next() == html.TextToken {
+				title = string(tokenizer.Text())
+			}
+		case "meta":
+			name := strings.ToLower(tokenAttr(token, "property") + tokenAttr(token, "name"))
+			switch name {
+			case "og:title":
+				metadata.Title = tokenAttr(token, "content")
+			case "og:description":
+				metadata.Description = tokenAttr(token, "content")
+			case "description":
+				description = tokenAttr(token, "content")
+			}
+		case "link":
+			for _, rel := range strings.Fields(strings.ToLower(tokenAttr(token, "rel"))) {
+				if rel == "icon" && tokenAttr(token, "href") != "" {
+					favicon = tokenAttr(token, "href")
+				}
+			}
+		}
+	}
+
+	// Set fallback values, if Open Graph tags are not found.
+	if metadata.Title == "" {
+		metadata.Title = title
+	}
+	if metadata.Description == "" {
+		metadata.Description = description
+	}
+
+	// Clean up and limit text values.
+	metadata.Title = truncateString(strings.Join(strings.Fields(metadata.Title), " "), repository.LinkMetadataTitleMaxLength)
+	metadata.Description = truncateString(
+		strings.Join(strings.Fields(metadata.Description), " "), repository.LinkMetadataDescriptionMaxLength,
+	)
+
+	// Resolve favicon URL relative to the page and keep it only if it is safe.
+	if faviconURL, err := pageURL.Parse(favicon); err == nil && helpers.ValidateURL(faviconURL.String()) == nil {
+		metadata.Favicon = faviconURL.String()
+	}
+
+	return metadata
+}
+
+// tokenAttr (private) func for getting value of the given attribute of the HTML token.
+func tokenAttr(token html.Token, key string) string {
+	for _, attr := range token.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+// truncateString (private) func for truncating string to the given count of runes.
+func truncateString(value string, maxLength int) string {
+	if utf8.RuneCountInString(value) <= maxLength {
+		return value
+	}
+	return string([]rune(value)[:maxLength])
+}
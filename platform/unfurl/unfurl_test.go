@@ -0,0 +1,112 @@
+package unfurl
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/helpers"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubStore (private) struct to describe store, which keeps metadata and hash of the links in memory.
+type stubStore struct {
+	metadata map[uuid.UUID][]models.LinkMetadata
+	hashes   map[uuid.UUID]string
+}
+
+// UpdateLinksMetadata method for saving metadata in memory.
+func (s *stubStore) UpdateLinksMetadata(_ string, objectID uuid.UUID, linksHash string, metadata []models.LinkMetadata) error {
+	s.metadata[objectID], s.hashes[objectID] = metadata, linksHash
+	return nil
+}
+
+func TestHTTPFetcher(t *testing.T) {
+	// Create a new local HTTP stub with pages.
+	mux := http.NewServeMux()
+	mux.HandleFunc("/og", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, `<html><head>
+			<title>Page title</title>
+			<meta property="og:title" content="OG  title">
+			<meta name="description" content="Page description">
+			<link rel="shortcut icon" href="/static/icon.png">
+		</head><body><title>Wrong</title></body></html>`)
+	})
+	mux.HandleFunc("/plain", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<title>Plain</title><link rel="icon" href="javascript:alert(1)">`)
+	})
+	mux.HandleFunc("/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// Create a new fetcher, which allows local stub.
+	fetcher := NewHTTPFetcher()
+	fetcher.AllowPrivateNetworks = true
+
+	// Check Open Graph metadata with fallback description and relative favicon.
+	metadata, err := fetcher.Fetch(context.Background(), server.URL+"/og")
+	assert.NoError(t, err)
+	assert.Equal(t, server.URL+"/og", metadata.URL)
+	assert.Equal(t, "OG title", metadata.Title)
+	assert.Equal(t, "Page description", metadata.Description)
+	assert.Equal(t, server.URL+"/static/icon.png", metadata.Favicon)
+
+	// Check page without <head> and with unsafe favicon.
+	metadata, err = fetcher.Fetch(context.Background(), server.URL+"/plain")
+	assert.NoError(t, err)
+	assert.Equal(t, "Plain", metadata.Title)
+	assert.Equal(t, "", metadata.Favicon)
+
+	// Check not HTML page, not found page and unsafe link.
+	_, err = fetcher.Fetch(context.Background(), server.URL+"/json")
+	assert.Error(t, err)
+	_, err = fetcher.Fetch(context.Background(), server.URL+"/404")
+	assert.Error(t, err)
+	_, err = fetcher.Fetch(context.Background(), "javascript:alert(1)")
+	assert.Error(t, err)
+
+	// Check, if private networks are denied by default.
+	_, err = NewHTTPFetcher().Fetch(context.Background(), server.URL+"/og")
+	assert.Error(t, err)
+}
+
+func TestWorkerProcess(t *testing.T) {
+	// Create a new local HTTP stub.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<title>Stub</title>`)
+	}))
+	defer server.Close()
+
+	// Create a new worker with local fetcher and stub store.
+	fetcher := NewHTTPFetcher()
+	fetcher.AllowPrivateNetworks = true
+	store := &stubStore{metadata: map[uuid.UUID][]models.LinkMetadata{}, hashes: map[uuid.UUID]string{}}
+	worker := NewWorker(fetcher, store, 1)
+
+	// Process job with one good and one broken link.
+	objectID := uuid.New()
+	links := []string{server.URL, "http://127.0.0.1:1/closed"}
+	err := worker.Process(context.Background(), Job{
+		ObjectType: "task",
+		ObjectID:   objectID,
+		Links:      links,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, store.metadata[objectID], 1)
+	assert.Equal(t, "Stub", store.metadata[objectID][0].Title)
+	assert.Equal(t, helpers.LinksHash(links), store.hashes[objectID])
+
+	// Check, if full queue drops a new job.
+	assert.True(t, worker.Enqueue(Job{ObjectID: objectID}))
+	assert.False(t, worker.Enqueue(Job{ObjectID: objectID}))
+}
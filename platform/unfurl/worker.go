@@ -0,0 +1,136 @@
+package unfurl
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/helpers"
+	"Komentory/api/platform/database"
+	"context"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// Job struct to describe unfurling of all links of the one object (project, task, answer).
+type Job struct {
+	ObjectType string
+	ObjectID   uuid.UUID
+	Links      []string
+}
+
+// Store interface for saving fetched metadata of the object links.
+type Store interface {
+	UpdateLinksMetadata(objectType string, objectID uuid.UUID, linksHash string, metadata []models.LinkMetadata) error
+}
+
+// Worker struct to describe background worker for unfurling links with the given fetcher.
+type Worker struct {
+	fetcher Fetcher
+	store   Store
+	jobs    chan Job
+}
+
+// DefaultWorker worker used by controllers (nil, if link unfurling is disabled).
+var DefaultWorker *Worker
+
+// NewWorker func for create a new worker with the given fetcher, store and size of the jobs queue.
+func NewWorker(fetcher Fetcher, store Store, queueSize int) *Worker {
+	return &Worker{
+		fetcher: fetcher,
+		store:   store,
+		jobs:    make(chan Job, queueSize),
+	}
+}
+
+// StartDefaultWorker func for start default worker with HTTP fetcher and database store.
+// Worker is started only if LINK_UNFURL_ENABLED is "true".
+func StartDefaultWorker(ctx context.Context) {
+	// Check, if link unfurling is enabled.
+	if os.Getenv("LINK_UNFURL_ENABLED") != "true" {
+		return
+	}
+
+	// Define count of the concurrent workers.
+	workersCount, err := strconv.Atoi(os.Getenv("LINK_UNFURL_WORKERS_COUNT"))
+	if err != nil || workersCount <= 0 {
+		workersCount = 2
+	}
+
+	// Create and start default worker.
+	DefaultWorker = NewWorker(NewHTTPFetcher(), &databaseStore{}, 100)
+	DefaultWorker.Start(ctx, workersCount)
+}
+
+// Enqueue func for adding a new job to the default worker queue.
+// Does nothing, if link unfurling is disabled.
+func Enqueue(objectType string, objectID uuid.UUID, links []string) {
+	if DefaultWorker != nil {
+		DefaultWorker.Enqueue(Job{ObjectType: objectType, ObjectID: objectID, Links: links})
+	}
+}
+
+// Enqueue method for adding a new job to the queue without blocking.
+// Returns false, if queue is full and job was dropped.
+func (w *Worker) Enqueue(job Job) bool {
+	select {
+	case w.jobs <- job:
+		return true
+	default:
+		log.Printf("unfurl: queue is full, job for %s %s was dropped", job.ObjectType, job.ObjectID)
+		return false
+	}
+}
+
+// Start method for starting the given count of goroutines to process jobs until context is done.
+func (w *Worker) Start(ctx context.Context, workersCount int) {
+	for i := 0; i < workersCount; i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-w.jobs:
+					if err := w.Process(ctx, job); err != nil {
+						log.Printf("unfurl: %s %s: %v", job.ObjectType, job.ObjectID, err)
+					}
+				}
+			}
+		}()
+	}
+}
+
+// Process method for fetching metadata of all job links and saving it to the store with hash of the links.
+// Links, which could not be fetched, are skipped.
+func (w *Worker) Process(ctx context.Context, job Job) error {
+	// Define list of metadata.
+	metadata := []models.LinkMetadata{}
+
+	for _, link := range job.Links {
+		// Fetch metadata of the link.
+		linkMetadata, err := w.fetcher.Fetch(ctx, link)
+		if err != nil {
+			log.Printf("unfurl: %s: %v", link, err)
+			continue
+		}
+
+		metadata = append(metadata, *linkMetadata)
+	}
+
+	// Save metadata to the store.
+	return w.store.UpdateLinksMetadata(job.ObjectType, job.ObjectID, helpers.LinksHash(job.Links), metadata)
+}
+
+// databaseStore (private) struct to describe store of the link metadata in the database.
+type databaseStore struct{}
+
+// UpdateLinksMetadata method for saving link metadata to attributes of the object in database.
+func (s *databaseStore) UpdateLinksMetadata(objectType string, objectID uuid.UUID, linksHash string, metadata []models.LinkMetadata) error {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return err
+	}
+
+	return db.UpdateLinksMetadata(objectType, objectID, linksHash, metadata)
+}
@@ -0,0 +1,384 @@
+package webhooks
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/helpers"
+	"Komentory/api/pkg/repository"
+	"Komentory/api/platform/database"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeliveryStore interface for project webhooks and queued deliveries of their events.
+type DeliveryStore interface {
+	GetActiveProjectWebhooks(projectID uuid.UUID) ([]models.ProjectWebhook, error)
+	CreateProjectWebhookDelivery(d *models.ProjectWebhookDelivery) error
+	ClaimDueProjectWebhookDeliveries(limit int, lockTimeout time.Duration) ([]models.ProjectWebhookDelivery, error)
+	UpdateProjectWebhookDelivery(d *models.ProjectWebhookDelivery) error
+}
+
+// Dispatcher struct to describe background sender of the project events to the webhooks of integrators.
+// Each delivery is signed by the secret of the webhook (see Sign func) and failed attempts
+// are retried with exponential backoff (1, 2, 4 ... minutes).
+type Dispatcher struct {
+	client *http.Client
+	store  DeliveryStore
+	notify chan struct{}
+
+	// Settings of the deliveries:
+	MaxAttempts int // max count of the delivery attempts
+}
+
+// DefaultDispatcher dispatcher used by subscriber of the domain events.
+var DefaultDispatcher = NewDispatcher(NewHTTPClient(), &databaseDeliveryStore{})
+
+// NewDispatcher func for create a new dispatcher with the given HTTP client and store.
+// Max count of the delivery attempts is set by WEBHOOK_DELIVERY_MAX_ATTEMPTS (default: 10).
+func NewDispatcher(client *http.Client, store DeliveryStore) *Dispatcher {
+	// Define max count of the delivery attempts.
+	maxAttempts, err := strconv.Atoi(os.Getenv("WEBHOOK_DELIVERY_MAX_ATTEMPTS"))
+	if err != nil || maxAttempts <= 0 {
+		maxAttempts = 10
+	}
+
+	return &Dispatcher{
+		client:      client,
+		store:       store,
+		notify:      make(chan struct{}, 1),
+		MaxAttempts: maxAttempts,
+	}
+}
+
+// NewHTTPClient func for create a new HTTP client for deliveries with timeout from env
+// (WEBHOOK_DELIVERY_TIMEOUT_SECONDS, default: 10). Redirects are not followed and connections
+// to private networks are denied, unless WEBHOOK_ALLOW_PRIVATE_NETWORKS is "true" (only for tests).
+func NewHTTPClient() *http.Client {
+	// Define timeout of the one delivery attempt.
+	timeoutSeconds, err := strconv.Atoi(os.Getenv("WEBHOOK_DELIVERY_TIMEOUT_SECONDS"))
+	if err != nil || timeoutSeconds <= 0 {
+		timeoutSeconds = 10
+	}
+	timeout := time.Duration(timeoutSeconds) * time.Second
+
+	// Create a new client without redirects.
+	client := &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	// Deny connections to loopback and private IP addresses.
+	if os.Getenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS") != "true" {
+		client.Transport = helpers.PublicNetworkTransport(timeout)
+	}
+
+	return client
+}
+
+// StartDefaultDispatcher func for start default dispatcher with settings from env.
+func StartDefaultDispatcher(ctx context.Context) {
+	DefaultDispatcher = NewDispatcher(NewHTTPClient(), &databaseDeliveryStore{})
+	DefaultDispatcher.Start(ctx, time.Minute)
+}
+
+// GenerateSecret func for generating a new random secret of the project webhook.
+func GenerateSecret() (string, error) {
+	// Read random bytes.
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+
+	return "whsec_" + hex.EncodeToString(key), nil
+}
+
+// Emit method for queueing event (by ID of the domain event) to all active webhooks of the project,
+// which are subscribed to it, and waking up the dispatcher.
+func (d *Dispatcher) Emit(eventID, projectID uuid.UUID, eventType string, data interface{}) error {
+	// Get active webhooks of the project.
+	projectWebhooks, err := d.store.GetActiveProjectWebhooks(projectID)
+	if err != nil {
+		return err
+	}
+
+	return d.emit(eventID, projectID, projectWebhooks, eventType, data)
+}
+
+// emit (private) method for saving a new delivery of the event for each subscribed webhook
+// and waking up the dispatcher. Event can be emitted again (after errors), so ID of the delivery
+// is made from IDs of the event and webhook, and payload has ID of the event for deduplication.
+func (d *Dispatcher) emit(eventID, projectID uuid.UUID, projectWebhooks []models.ProjectWebhook, eventType string, data interface{}) error {
+	// Define payload of the event (the same for all webhooks).
+	now := time.Now()
+	payload := models.ProjectWebhookDeliveryPayload{
+		"id":         eventID,
+		"type":       eventType,
+		"created_at": now,
+		"project_id": projectID,
+		"data":       data,
+	}
+
+	// Save a new delivery for each subscribed webhook.
+	queued := 0
+	for i := range projectWebhooks {
+		if !projectWebhooks[i].Active || !projectWebhooks[i].Subscribed(eventType) {
+			continue
+		}
+		delivery := newDelivery(&projectWebhooks[i], eventType, payload, now)
+		delivery.ID = uuid.NewSHA1(eventID, projectWebhooks[i].ID[:])
+		if err := d.store.CreateProjectWebhookDelivery(delivery); err != nil {
+			return err
+		}
+		queued++
+	}
+
+	// Wake up the dispatcher.
+	if queued > 0 {
+		d.Wake()
+	}
+
+	return nil
+}
+
+// Wake method for waking up the dispatcher to send due deliveries (without blocking).
+func (d *Dispatcher) Wake() {
+	select {
+	case d.notify <- struct{}{}:
+	default: // dispatcher is already notified
+	}
+}
+
+// Ping method for sending test event to the given webhook right now (without retries).
+// Returns delivery with the response of the webhook, which is saved to the delivery log.
+func (d *Dispatcher) Ping(ctx context.Context, webhook *models.ProjectWebhook) (*models.ProjectWebhookDelivery, error) {
+	// Define a new delivery, which is locked by the current API instance.
+	now := time.Now()
+	delivery := newDelivery(webhook, repository.ProjectWebhookEventPing, models.ProjectWebhookDeliveryPayload{
+		"id":         uuid.New(),
+		"type":       repository.ProjectWebhookEventPing,
+		"created_at": now,
+		"project_id": webhook.ProjectID,
+		"data":       map[string]interface{}{"webhook_id": webhook.ID},
+	}, now)
+	delivery.Status = repository.ProjectWebhookDeliveryStatusSending
+	delivery.Attempts = 1
+	delivery.LockedAt = &now
+
+	// Save delivery to the log.
+	if err := d.store.CreateProjectWebhookDelivery(delivery); err != nil {
+		return nil, err
+	}
+
+	// Send test event and save the outcome.
+	d.attempt(ctx, delivery)
+	if delivery.Status != repository.ProjectWebhookDeliveryStatusDelivered {
+		delivery.Status = repository.ProjectWebhookDeliveryStatusFailed
+	}
+
+	return delivery, d.store.UpdateProjectWebhookDelivery(delivery)
+}
+
+// Start method for sending due deliveries until context is done.
+// Deliveries are checked after each emit and by the given interval (for retries).
+func (d *Dispatcher) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-d.notify:
+			case <-ticker.C:
+			}
+
+			if err := d.DeliverDue(ctx); err != nil {
+				log.Printf("webhooks: %v", err)
+			}
+		}
+	}()
+}
+
+// DeliverDue method for sending all deliveries with due attempt from the queue.
+func (d *Dispatcher) DeliverDue(ctx context.Context) error {
+	for {
+		// Claim next batch of the due deliveries.
+		deliveries, err := d.store.ClaimDueProjectWebhookDeliveries(10, 5*time.Minute)
+		if err != nil {
+			return err
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		for i := range deliveries {
+			if err := d.Deliver(ctx, &deliveries[i]); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Deliver method for one attempt of the claimed delivery and saving its outcome to the store
+// (delivered, scheduled for the next attempt or failed, if all attempts are used).
+// Returns only errors of the store.
+func (d *Dispatcher) Deliver(ctx context.Context, delivery *models.ProjectWebhookDelivery) error {
+	// Send event to the webhook.
+	d.attempt(ctx, delivery)
+
+	// Schedule the next attempt or fail the delivery, if all attempts are used.
+	if delivery.Status != repository.ProjectWebhookDeliveryStatusDelivered {
+		if delivery.Attempts >= d.MaxAttempts {
+			delivery.Status = repository.ProjectWebhookDeliveryStatusFailed
+		} else {
+			delivery.Status = repository.ProjectWebhookDeliveryStatusPending
+			delivery.NextAttemptAt = time.Now().Add(RetryDelay(delivery.Attempts))
+		}
+	}
+
+	return d.store.UpdateProjectWebhookDelivery(delivery)
+}
+
+// attempt (private) method for sending signed payload of the delivery to the webhook URL.
+// Response (or error) of the webhook is saved to the delivery fields.
+func (d *Dispatcher) attempt(ctx context.Context, delivery *models.ProjectWebhookDelivery) {
+	// Reset outcome of the previous attempt.
+	delivery.ResponseStatus, delivery.ResponseBody, delivery.Error = 0, "", ""
+	start := time.Now()
+	defer func() {
+		delivery.DurationMs = int(time.Since(start).Milliseconds())
+	}()
+
+	// Encode payload to JSON.
+	body, err := json.Marshal(delivery.Payload)
+	if err != nil {
+		delivery.Error = err.Error()
+		return
+	}
+
+	// Create a new signed request.
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		delivery.Error = err.Error()
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "KomentoryWebhooks/1.0")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, delivery.ID.String())
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, start, body))
+
+	// Send request to the webhook.
+	resp, err := d.client.Do(req)
+	if err != nil {
+		delivery.Error = err.Error()
+		return
+	}
+	defer resp.Body.Close()
+
+	// Save response of the webhook (first 1 Kb of the body).
+	responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	delivery.ResponseStatus = resp.StatusCode
+	delivery.ResponseBody = string(bytes.ToValidUTF8(responseBody, nil))
+
+	// Check, if webhook accepted the event.
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		delivery.Error = fmt.Sprintf("webhook responded with status %d", resp.StatusCode)
+		return
+	}
+	delivery.Status = repository.ProjectWebhookDeliveryStatusDelivered
+	delivery.DeliveredAt = &start
+}
+
+// newDelivery (private) func for creating a new pending delivery of the payload to the given webhook.
+func newDelivery(webhook *models.ProjectWebhook, eventType string, payload models.ProjectWebhookDeliveryPayload, now time.Time) *models.ProjectWebhookDelivery {
+	webhookID := webhook.ID
+	return &models.ProjectWebhookDelivery{
+		ID:            uuid.New(),
+		CreatedAt:     now,
+		WebhookID:     &webhookID,
+		ProjectID:     webhook.ProjectID,
+		URL:           webhook.URL,
+		Secret:        webhook.Secret,
+		EventType:     eventType,
+		Payload:       payload,
+		Status:        repository.ProjectWebhookDeliveryStatusPending,
+		NextAttemptAt: now,
+	}
+}
+
+// RetryDelay func for getting delay before the next delivery attempt after the given count of attempts.
+// Delay grows exponentially (1, 2, 4 ... minutes) up to 12 hours.
+func RetryDelay(attempts int) time.Duration {
+	// Define delay.
+	delay := time.Minute
+	for i := 1; i < attempts && delay < 12*time.Hour; i++ {
+		delay *= 2
+	}
+
+	if delay > 12*time.Hour {
+		return 12 * time.Hour
+	}
+	return delay
+}
+
+// databaseDeliveryStore (private) struct to describe store of the project webhooks and deliveries in the database.
+type databaseDeliveryStore struct{}
+
+// GetActiveProjectWebhooks method for getting active webhooks of the project from the database.
+func (s *databaseDeliveryStore) GetActiveProjectWebhooks(projectID uuid.UUID) ([]models.ProjectWebhook, error) {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return nil, err
+	}
+
+	return db.GetActiveProjectWebhooks(projectID)
+}
+
+// CreateProjectWebhookDelivery method for saving a new delivery to the database.
+func (s *databaseDeliveryStore) CreateProjectWebhookDelivery(delivery *models.ProjectWebhookDelivery) error {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return err
+	}
+
+	return db.CreateProjectWebhookDelivery(delivery)
+}
+
+// ClaimDueProjectWebhookDeliveries method for claiming due deliveries from the database.
+func (s *databaseDeliveryStore) ClaimDueProjectWebhookDeliveries(limit int, lockTimeout time.Duration) ([]models.ProjectWebhookDelivery, error) {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return nil, err
+	}
+
+	return db.ClaimDueProjectWebhookDeliveries(limit, lockTimeout)
+}
+
+// UpdateProjectWebhookDelivery method for saving outcome of the delivery attempt to the database.
+func (s *databaseDeliveryStore) UpdateProjectWebhookDelivery(delivery *models.ProjectWebhookDelivery) error {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return err
+	}
+
+	return db.UpdateProjectWebhookDelivery(delivery)
+}
@@ -0,0 +1,187 @@
+package webhooks
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/repository"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubDeliveryStore (private) struct to describe store, which keeps webhooks and deliveries in memory.
+type stubDeliveryStore struct {
+	webhooks   []models.ProjectWebhook
+	deliveries map[uuid.UUID]*models.ProjectWebhookDelivery
+}
+
+// GetActiveProjectWebhooks method for getting webhooks of the project from memory.
+func (s *stubDeliveryStore) GetActiveProjectWebhooks(projectID uuid.UUID) ([]models.ProjectWebhook, error) {
+	webhooks := []models.ProjectWebhook{}
+	for _, w := range s.webhooks {
+		if w.ProjectID == projectID && w.Active {
+			webhooks = append(webhooks, w)
+		}
+	}
+	return webhooks, nil
+}
+
+// CreateProjectWebhookDelivery method for saving delivery in memory.
+func (s *stubDeliveryStore) CreateProjectWebhookDelivery(d *models.ProjectWebhookDelivery) error {
+	if _, ok := s.deliveries[d.ID]; ok {
+		return nil
+	}
+	delivery := *d
+	s.deliveries[d.ID] = &delivery
+	return nil
+}
+
+// ClaimDueProjectWebhookDeliveries method for claiming all pending deliveries with due attempt from memory.
+func (s *stubDeliveryStore) ClaimDueProjectWebhookDeliveries(_ int, _ time.Duration) ([]models.ProjectWebhookDelivery, error) {
+	deliveries := []models.ProjectWebhookDelivery{}
+	for _, d := range s.deliveries {
+		if d.Status == repository.ProjectWebhookDeliveryStatusPending && !d.NextAttemptAt.After(time.Now()) {
+			d.Status = repository.ProjectWebhookDeliveryStatusSending
+			d.Attempts++
+			deliveries = append(deliveries, *d)
+		}
+	}
+	return deliveries, nil
+}
+
+// UpdateProjectWebhookDelivery method for saving outcome of the delivery attempt in memory.
+func (s *stubDeliveryStore) UpdateProjectWebhookDelivery(d *models.ProjectWebhookDelivery) error {
+	delivery := *d
+	s.deliveries[d.ID] = &delivery
+	return nil
+}
+
+func TestDispatcherDeliverDue(t *testing.T) {
+	// Start local receiver, which verifies signatures and fails for the "/fail" path.
+	received := []string{}
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := VerifySignature("secret", r.Header.Get(HeaderSignature), body, time.Minute, time.Now()); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		received = append(received, r.URL.Path+" "+r.Header.Get(HeaderEvent))
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("internal error"))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	// Create webhooks of the project (all events, only tasks, failed, inactive).
+	projectID := uuid.New()
+	store := &stubDeliveryStore{
+		webhooks: []models.ProjectWebhook{
+			{ID: uuid.New(), ProjectID: projectID, URL: receiver.URL + "/all", Secret: "secret", Active: true},
+			{ID: uuid.New(), ProjectID: projectID, URL: receiver.URL + "/tasks", Secret: "secret", Active: true, Events: models.ProjectWebhookEvents{repository.TaskEventCreated}},
+			{ID: uuid.New(), ProjectID: projectID, URL: receiver.URL + "/fail", Secret: "secret", Active: true},
+			{ID: uuid.New(), ProjectID: projectID, URL: receiver.URL + "/inactive", Secret: "secret"},
+		},
+		deliveries: map[uuid.UUID]*models.ProjectWebhookDelivery{},
+	}
+	dispatcher := NewDispatcher(receiver.Client(), store)
+	dispatcher.MaxAttempts = 2
+
+	// Emit event and deliver it.
+	eventID := uuid.New()
+	assert.NoError(t, dispatcher.Emit(eventID, projectID, repository.AnswerEventCreated, map[string]interface{}{"id": uuid.New()}))
+	assert.Len(t, store.deliveries, 2)
+
+	// Event emitted again (after errors) is not queued twice.
+	assert.NoError(t, dispatcher.Emit(eventID, projectID, repository.AnswerEventCreated, map[string]interface{}{"id": uuid.New()}))
+	assert.Len(t, store.deliveries, 2)
+	assert.NoError(t, dispatcher.DeliverDue(context.Background()))
+	assert.ElementsMatch(t, []string{"/all answer.created", "/fail answer.created"}, received)
+
+	// Check outcomes of the deliveries (failed delivery is scheduled for retry).
+	for _, d := range store.deliveries {
+		switch d.URL {
+		case receiver.URL + "/all":
+			assert.Equal(t, repository.ProjectWebhookDeliveryStatusDelivered, d.Status)
+			assert.Equal(t, http.StatusNoContent, d.ResponseStatus)
+			assert.NotNil(t, d.DeliveredAt)
+		case receiver.URL + "/fail":
+			assert.Equal(t, repository.ProjectWebhookDeliveryStatusPending, d.Status)
+			assert.Equal(t, http.StatusInternalServerError, d.ResponseStatus)
+			assert.Equal(t, "internal error", d.ResponseBody)
+			assert.True(t, d.NextAttemptAt.After(time.Now()))
+
+			// Make the next attempt due, it is the last one.
+			d.NextAttemptAt = time.Now()
+		}
+	}
+	assert.NoError(t, dispatcher.DeliverDue(context.Background()))
+	for _, d := range store.deliveries {
+		if d.URL == receiver.URL+"/fail" {
+			assert.Equal(t, repository.ProjectWebhookDeliveryStatusFailed, d.Status)
+			assert.Equal(t, 2, d.Attempts)
+		}
+	}
+}
+
+func TestDispatcherPing(t *testing.T) {
+	// Start local receiver, which accepts all events.
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get(HeaderEvent)))
+	}))
+	defer receiver.Close()
+
+	// Send test event to the webhook.
+	store := &stubDeliveryStore{deliveries: map[uuid.UUID]*models.ProjectWebhookDelivery{}}
+	webhook := &models.ProjectWebhook{ID: uuid.New(), ProjectID: uuid.New(), URL: receiver.URL, Secret: "secret"}
+	delivery, err := NewDispatcher(receiver.Client(), store).Ping(context.Background(), webhook)
+
+	// Check, if ping was delivered and saved to the log.
+	assert.NoError(t, err)
+	assert.Equal(t, repository.ProjectWebhookDeliveryStatusDelivered, delivery.Status)
+	assert.Equal(t, "ping", delivery.ResponseBody)
+	assert.Equal(t, delivery, store.deliveries[delivery.ID])
+}
+
+func TestVerifySignature(t *testing.T) {
+	// Sign body of the delivery.
+	body, now := []byte(`{"type":"ping"}`), time.Now()
+	header := Sign("secret", now, body)
+
+	tests := []struct {
+		name    string
+		secret  string
+		header  string
+		body    []byte
+		now     time.Time
+		wantErr bool
+	}{
+		{"valid signature", "secret", header, body, now, false},
+		{"rotated secret", "secret", header + ",v1=other", body, now, false},
+		{"wrong secret", "other", header, body, now, true},
+		{"changed body", "secret", header, []byte(`{"type":"task.created"}`), now, true},
+		{"old signature", "secret", header, body, now.Add(10 * time.Minute), true},
+		{"wrong header", "secret", "v1=abc", body, now, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := VerifySignature(test.secret, test.header, test.body, 5*time.Minute, test.now)
+			assert.Equal(t, test.wantErr, err != nil)
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Minute, RetryDelay(1))
+	assert.Equal(t, 2*time.Minute, RetryDelay(2))
+	assert.Equal(t, 8*time.Minute, RetryDelay(4))
+	assert.Equal(t, 12*time.Hour, RetryDelay(20))
+}
@@ -0,0 +1,319 @@
+package webhooks
+
+import (
+	"Komentory/api/pkg/repository"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"sync"
+)
+
+// VendorAuth struct to describe authentication of the inbound webhooks of one vendor:
+//  - Strategy == basic_auth | hmac | token;
+//  - User == user name for the BasicAuth strategy;
+//  - Secrets == current and previous (while rotation) password, HMAC key or token;
+//  - Header == header with HMAC-SHA256 signature of the body or with shared token;
+//  - HeaderPrefix == prefix of the signature or token in the header (like "sha256=");
+//  - SignatureEncoding == hex | base64;
+//  - AllowedIPs == networks of the vendor, empty for any IP;
+type VendorAuth struct {
+	Strategy          string
+	User              string
+	Secrets           []string
+	Header            string
+	HeaderPrefix      string
+	SignatureEncoding string
+	AllowedIPs        []*net.IPNet
+}
+
+// InboundRequest struct to describe inbound webhook request for authentication.
+type InboundRequest struct {
+	IP     string
+	Header func(key string) string
+	Body   []byte
+}
+
+// Verify method for checking IP and credentials of the inbound webhook request.
+func (a *VendorAuth) Verify(r *InboundRequest) error {
+	// Check IP of the request.
+	if !a.allowedIP(net.ParseIP(r.IP)) {
+		return fmt.Errorf("IP %s is not allowed", r.IP)
+	}
+
+	// Check credentials by the strategy.
+	switch a.Strategy {
+	case repository.WebhookAuthBasicAuth:
+		return a.verifyBasicAuth(r.Header("Authorization"))
+	case repository.WebhookAuthHMAC:
+		return a.verifySignature(r.Header(a.Header), r.Body)
+	case repository.WebhookAuthToken:
+		return a.verifyToken(r.Header(a.Header))
+	default:
+		return fmt.Errorf("unknown strategy %s", a.Strategy)
+	}
+}
+
+// allowedIP (private) method for checking IP of the request by allow-list.
+func (a *VendorAuth) allowedIP(ip net.IP) bool {
+	// Any IP is allowed, if allow-list is empty.
+	if len(a.AllowedIPs) == 0 {
+		return true
+	}
+
+	for _, network := range a.AllowedIPs {
+		if ip != nil && network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// verifyBasicAuth (private) method for checking user and password from the Authorization header.
+func (a *VendorAuth) verifyBasicAuth(header string) error {
+	// Decode credentials from the header.
+	if !strings.HasPrefix(header, "Basic ") {
+		return errors.New("basic auth header is missing")
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(header, "Basic "))
+	if err != nil {
+		return errors.New("basic auth header is not valid")
+	}
+	credentials := strings.SplitN(string(decoded), ":", 2)
+	if len(credentials) != 2 {
+		return errors.New("basic auth header is not valid")
+	}
+
+	// Compare user and password in constant time (both are always compared).
+	userMatched := equalSecret(credentials[0], a.User)
+	passwordMatched := matchSecret(credentials[1], a.Secrets)
+	if !userMatched || !passwordMatched {
+		return errors.New("user or password is not matched")
+	}
+
+	return nil
+}
+
+// verifySignature (private) method for checking HMAC-SHA256 signature of the body by any secret.
+func (a *VendorAuth) verifySignature(header string, body []byte) error {
+	// Decode signature from the header.
+	if header == "" || !strings.HasPrefix(header, a.HeaderPrefix) {
+		return errors.New("signature header is missing")
+	}
+	value := strings.TrimPrefix(header, a.HeaderPrefix)
+	var received []byte
+	var err error
+	if a.SignatureEncoding == "base64" {
+		received, err = base64.StdEncoding.DecodeString(value)
+	} else {
+		received, err = hex.DecodeString(value)
+	}
+	if err != nil {
+		return errors.New("signature header is not valid")
+	}
+
+	// Compare signatures in constant time (all secrets are always compared).
+	matched := false
+	for _, secret := range a.Secrets {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		if hmac.Equal(received, mac.Sum(nil)) && secret != "" {
+			matched = true
+		}
+	}
+	if !matched {
+		return errors.New("signature is not matched")
+	}
+
+	return nil
+}
+
+// verifyToken (private) method for checking shared token from the header by any secret.
+func (a *VendorAuth) verifyToken(header string) error {
+	// Get token from the header.
+	if header == "" || !strings.HasPrefix(header, a.HeaderPrefix) {
+		return errors.New("token header is missing")
+	}
+
+	// Compare token in constant time.
+	if !matchSecret(strings.TrimPrefix(header, a.HeaderPrefix), a.Secrets) {
+		return errors.New("token is not matched")
+	}
+
+	return nil
+}
+
+// matchSecret (private) func for comparing value with all secrets in constant time.
+// All secrets are compared, so time doesn't depend on the index of the matched secret.
+func matchSecret(value string, secrets []string) bool {
+	matched := false
+	for _, secret := range secrets {
+		if equalSecret(value, secret) && secret != "" {
+			matched = true
+		}
+	}
+
+	return matched
+}
+
+// equalSecret (private) func for comparing two strings in constant time.
+// Hashes are compared, so time doesn't depend on the length of the strings.
+func equalSecret(value, secret string) bool {
+	valueHash, secretHash := sha256.Sum256([]byte(value)), sha256.Sum256([]byte(secret))
+	return subtle.ConstantTimeCompare(valueHash[:], secretHash[:]) == 1
+}
+
+// AuthRegistry struct to describe authentication of the inbound webhooks by vendors.
+type AuthRegistry struct {
+	mu      sync.RWMutex
+	vendors map[string]*VendorAuth
+}
+
+// DefaultAuthRegistry registry used by webhook routes.
+var DefaultAuthRegistry = NewAuthRegistry()
+
+// NewAuthRegistry func for create a new empty registry.
+func NewAuthRegistry() *AuthRegistry {
+	return &AuthRegistry{vendors: map[string]*VendorAuth{}}
+}
+
+// Register method for setting authentication of the given vendor.
+func (r *AuthRegistry) Register(vendor string, auth *VendorAuth) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.vendors[vendor] = auth
+}
+
+// RegisterFromEnv method for setting authentication of the given vendor from .env file.
+// Vendor with not valid configuration is not registered (all its webhooks are denied).
+func (r *AuthRegistry) RegisterFromEnv(vendor string) error {
+	auth, err := VendorAuthFromEnv(vendor)
+	if err != nil {
+		return err
+	}
+
+	r.Register(vendor, auth)
+
+	return nil
+}
+
+// Verify method for checking inbound webhook request of the given vendor.
+func (r *AuthRegistry) Verify(vendor string, req *InboundRequest) error {
+	r.mu.RLock()
+	auth, ok := r.vendors[vendor]
+	r.mu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("vendor %s is not configured", vendor)
+	}
+
+	return auth.Verify(req)
+}
+
+// VendorAuthFromEnv func for reading authentication of the given vendor from .env file
+// (WEBHOOK_AUTH_<VENDOR>_STRATEGY, _USER, _SECRET, _SECRET_PREVIOUS, _HEADER, _HEADER_PREFIX,
+// _SIGNATURE_ENCODING and _ALLOWED_IPS).
+func VendorAuthFromEnv(vendor string) (*VendorAuth, error) {
+	// Define prefix of the vendor variables.
+	prefix := fmt.Sprintf("WEBHOOK_AUTH_%s_", strings.ToUpper(strings.ReplaceAll(vendor, "-", "_")))
+
+	// Use legacy variables of the vendor, if new variables are not set yet.
+	if os.Getenv(prefix+"STRATEGY") == "" {
+		if auth, ok := legacyVendorAuthFromEnv(vendor); ok {
+			log.Printf("webhook auth: legacy variables are used for the vendor %s, move them to %s* variables", vendor, prefix)
+			return auth, nil
+		}
+	}
+
+	// Define authentication of the vendor.
+	auth := &VendorAuth{
+		Strategy:          os.Getenv(prefix + "STRATEGY"),
+		User:              os.Getenv(prefix + "USER"),
+		Header:            os.Getenv(prefix + "HEADER"),
+		HeaderPrefix:      os.Getenv(prefix + "HEADER_PREFIX"),
+		SignatureEncoding: os.Getenv(prefix + "SIGNATURE_ENCODING"),
+	}
+
+	// Set current and previous secrets (both are valid while rotation).
+	for _, key := range []string{"SECRET", "SECRET_PREVIOUS"} {
+		if secret := os.Getenv(prefix + key); secret != "" {
+			auth.Secrets = append(auth.Secrets, secret)
+		}
+	}
+	if len(auth.Secrets) == 0 {
+		return nil, fmt.Errorf("%sSECRET is not set", prefix)
+	}
+
+	// Check options of the strategy.
+	switch auth.Strategy {
+	case repository.WebhookAuthBasicAuth:
+		if auth.User == "" {
+			return nil, fmt.Errorf("%sUSER is not set", prefix)
+		}
+	case repository.WebhookAuthHMAC:
+		if auth.Header == "" {
+			auth.Header = "X-Webhook-Signature"
+		}
+		if auth.SignatureEncoding == "" {
+			auth.SignatureEncoding = "hex"
+		}
+		if auth.SignatureEncoding != "hex" && auth.SignatureEncoding != "base64" {
+			return nil, fmt.Errorf("wrong signature encoding %s", auth.SignatureEncoding)
+		}
+	case repository.WebhookAuthToken:
+		if auth.Header == "" {
+			auth.Header = "X-Webhook-Token"
+		}
+	default:
+		return nil, fmt.Errorf("wrong strategy %q of the vendor %s", auth.Strategy, vendor)
+	}
+
+	// Parse allowed IPs and networks of the vendor.
+	for _, value := range strings.Split(os.Getenv(prefix+"ALLOWED_IPS"), ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if !strings.Contains(value, "/") {
+			if ip := net.ParseIP(value); ip != nil && ip.To4() != nil {
+				value += "/32"
+			} else {
+				value += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("wrong allowed IP %s", value)
+		}
+		auth.AllowedIPs = append(auth.AllowedIPs, network)
+	}
+
+	return auth, nil
+}
+
+// legacyVendorAuthFromEnv (private) func for reading authentication of the given vendor from
+// legacy variables of .env file (POSTMARK_BASICAUTH_USER and POSTMARK_BASICAUTH_PASSWORD for Postmark).
+// Deprecated: these variables are supported only until migration to the WEBHOOK_AUTH_<VENDOR>_* variables.
+func legacyVendorAuthFromEnv(vendor string) (*VendorAuth, bool) {
+	// Check, if vendor has legacy variables.
+	if vendor != repository.WebhookVendorPostmark {
+		return nil, false
+	}
+
+	// Get legacy BasicAuth credentials of Postmark.
+	user, password := os.Getenv("POSTMARK_BASICAUTH_USER"), os.Getenv("POSTMARK_BASICAUTH_PASSWORD")
+	if user == "" || password == "" {
+		return nil, false
+	}
+
+	return &VendorAuth{Strategy: repository.WebhookAuthBasicAuth, User: user, Secrets: []string{password}}, true
+}
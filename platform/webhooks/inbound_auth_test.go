@@ -0,0 +1,134 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVendorAuthVerify(t *testing.T) {
+	// Define body and helpers for the request headers.
+	body := []byte(`{"RecordType":"Bounce"}`)
+	basicAuth := func(user, password string) string {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+password))
+	}
+	sign := func(secret string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+	_, vendorNetwork, _ := net.ParseCIDR("10.0.0.0/8")
+
+	// Define authentication of the vendors (with current and previous secrets).
+	basic := &VendorAuth{Strategy: "basic_auth", User: "user", Secrets: []string{"new", "old"}}
+	signature := &VendorAuth{Strategy: "hmac", Secrets: []string{"new", "old"}, Header: "X-Signature", HeaderPrefix: "sha256=", SignatureEncoding: "hex"}
+	token := &VendorAuth{Strategy: "token", Secrets: []string{"new"}, Header: "X-Token", AllowedIPs: []*net.IPNet{vendorNetwork}}
+
+	// Define a structure for specifying input and output data of a single test case.
+	tests := []struct {
+		description string
+		auth        *VendorAuth
+		ip          string
+		headers     map[string]string
+		expected    bool
+	}{
+		// Successful test cases:
+		{"success: basic auth with current password", basic, "1.2.3.4", map[string]string{"Authorization": basicAuth("user", "new")}, true},
+		{"success: basic auth with previous password", basic, "1.2.3.4", map[string]string{"Authorization": basicAuth("user", "old")}, true},
+		{"success: signature by current secret", signature, "1.2.3.4", map[string]string{"X-Signature": sign("new")}, true},
+		{"success: signature by previous secret", signature, "1.2.3.4", map[string]string{"X-Signature": sign("old")}, true},
+		{"success: token from allowed network", token, "10.1.2.3", map[string]string{"X-Token": "new"}, true},
+
+		// Failed test cases:
+		{"fail: basic auth with wrong user", basic, "1.2.3.4", map[string]string{"Authorization": basicAuth("admin", "new")}, false},
+		{"fail: basic auth with wrong password", basic, "1.2.3.4", map[string]string{"Authorization": basicAuth("user", "wrong")}, false},
+		{"fail: basic auth without header", basic, "1.2.3.4", map[string]string{}, false},
+		{"fail: signature by wrong secret", signature, "1.2.3.4", map[string]string{"X-Signature": sign("wrong")}, false},
+		{"fail: signature without prefix", signature, "1.2.3.4", map[string]string{"X-Signature": sign("new")[7:]}, false},
+		{"fail: signature is not hex", signature, "1.2.3.4", map[string]string{"X-Signature": "sha256=zz"}, false},
+		{"fail: wrong token", token, "10.1.2.3", map[string]string{"X-Token": "old"}, false},
+		{"fail: token from not allowed IP", token, "1.2.3.4", map[string]string{"X-Token": "new"}, false},
+		{"fail: unknown strategy", &VendorAuth{Strategy: "none"}, "1.2.3.4", map[string]string{}, false},
+	}
+
+	// Iterate through test single test cases.
+	for _, test := range tests {
+		headers := test.headers
+		err := test.auth.Verify(&InboundRequest{
+			IP:     test.ip,
+			Header: func(key string) string { return headers[key] },
+			Body:   body,
+		})
+		assert.Equalf(t, test.expected, err == nil, test.description)
+	}
+}
+
+func TestAuthRegistry(t *testing.T) {
+	// Define vendor configuration.
+	t.Setenv("WEBHOOK_AUTH_ACME_STRATEGY", "token")
+	t.Setenv("WEBHOOK_AUTH_ACME_SECRET", "new")
+	t.Setenv("WEBHOOK_AUTH_ACME_SECRET_PREVIOUS", "old")
+	t.Setenv("WEBHOOK_AUTH_ACME_ALLOWED_IPS", "192.168.0.1, 10.0.0.0/8")
+
+	// Register vendor from .env file.
+	registry := NewAuthRegistry()
+	assert.NoError(t, registry.RegisterFromEnv("acme"))
+	request := func(ip, token string) *InboundRequest {
+		return &InboundRequest{IP: ip, Header: func(key string) string {
+			if key == "X-Webhook-Token" {
+				return token
+			}
+			return ""
+		}}
+	}
+
+	assert.NoError(t, registry.Verify("acme", request("192.168.0.1", "new")))
+	assert.NoError(t, registry.Verify("acme", request("10.0.0.5", "old")))
+	assert.Error(t, registry.Verify("acme", request("192.168.0.2", "new")))
+
+	// Not configured vendor is denied.
+	assert.Error(t, registry.Verify("postmark", request("192.168.0.1", "new")))
+
+	// Wrong configurations are not registered.
+	t.Setenv("WEBHOOK_AUTH_BROKEN_STRATEGY", "basic_auth")
+	t.Setenv("WEBHOOK_AUTH_BROKEN_SECRET", "secret")
+	assert.Error(t, registry.RegisterFromEnv("broken")) // without user
+	t.Setenv("WEBHOOK_AUTH_BROKEN_STRATEGY", "hmac")
+	t.Setenv("WEBHOOK_AUTH_BROKEN_SIGNATURE_ENCODING", "base32")
+	assert.Error(t, registry.RegisterFromEnv("broken"))  // wrong encoding
+	assert.Error(t, registry.RegisterFromEnv("unknown")) // without strategy and secret
+	assert.Error(t, registry.Verify("broken", request("192.168.0.1", "secret")))
+}
+
+func TestLegacyVendorAuth(t *testing.T) {
+	// Define legacy configuration of Postmark (without WEBHOOK_AUTH_POSTMARK_* variables).
+	t.Setenv("WEBHOOK_AUTH_POSTMARK_STRATEGY", "")
+	t.Setenv("WEBHOOK_AUTH_POSTMARK_SECRET", "")
+	t.Setenv("POSTMARK_BASICAUTH_USER", "user")
+	t.Setenv("POSTMARK_BASICAUTH_PASSWORD", "password")
+
+	// Check, if legacy credentials are used for Postmark only.
+	auth, err := VendorAuthFromEnv("postmark")
+	assert.NoError(t, err)
+	assert.Equal(t, &VendorAuth{Strategy: "basic_auth", User: "user", Secrets: []string{"password"}}, auth)
+	_, err = VendorAuthFromEnv("acme")
+	assert.Error(t, err)
+
+	// Check, if new variables have priority over legacy variables.
+	t.Setenv("WEBHOOK_AUTH_POSTMARK_STRATEGY", "token")
+	t.Setenv("WEBHOOK_AUTH_POSTMARK_SECRET", "new")
+	auth, err = VendorAuthFromEnv("postmark")
+	assert.NoError(t, err)
+	assert.Equal(t, "token", auth.Strategy)
+
+	// Check, if legacy configuration without password is not used.
+	t.Setenv("WEBHOOK_AUTH_POSTMARK_STRATEGY", "")
+	t.Setenv("POSTMARK_BASICAUTH_PASSWORD", "")
+	_, err = VendorAuthFromEnv("postmark")
+	assert.Error(t, err)
+}
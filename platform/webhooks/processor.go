@@ -0,0 +1,153 @@
+package webhooks
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/repository"
+	"Komentory/api/platform/database"
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Handler func for processing raw body of the webhook event with the given type.
+type Handler func(rawBody []byte) error
+
+// Store interface for claiming stored webhook events and saving outcome of the processing.
+type Store interface {
+	ClaimPendingWebhookEvents(limit int, lockTimeout time.Duration) ([]models.WebhookEvent, error)
+	FinishWebhookEvent(id uuid.UUID, status, processingError string) error
+}
+
+// Processor struct to describe background processor of the stored webhook events.
+// Events are processed out of the HTTP request (after notify or by interval),
+// so the webhook vendor gets response right after the event was saved.
+type Processor struct {
+	mu       sync.RWMutex
+	handlers map[string]Handler
+	store    Store
+	notify   chan struct{}
+}
+
+// DefaultProcessor processor used by webhook routes and controllers.
+var DefaultProcessor = NewProcessor(&databaseStore{})
+
+// NewProcessor func for create a new processor with the given store.
+func NewProcessor(store Store) *Processor {
+	return &Processor{
+		handlers: map[string]Handler{},
+		store:    store,
+		notify:   make(chan struct{}, 1),
+	}
+}
+
+// Register method for setting handler of the given event type.
+func (p *Processor) Register(eventType string, handler Handler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.handlers[eventType] = handler
+}
+
+// Notify method for waking up the processor after a new event was saved (without blocking).
+func (p *Processor) Notify() {
+	select {
+	case p.notify <- struct{}{}:
+	default: // processor is already notified
+	}
+}
+
+// Start method for processing events until context is done.
+// Events are checked after each notify and by the given interval (for failed API instances).
+func (p *Processor) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-p.notify:
+			case <-ticker.C:
+			}
+
+			if err := p.ProcessPending(); err != nil {
+				log.Printf("webhooks: %v", err)
+			}
+		}
+	}()
+}
+
+// ProcessPending method for processing all pending events from the store.
+func (p *Processor) ProcessPending() error {
+	for {
+		// Claim next batch of the pending events.
+		events, err := p.store.ClaimPendingWebhookEvents(10, 5*time.Minute)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		for i := range events {
+			// Process event and save outcome to the store.
+			status, processingError := repository.WebhookEventStatusProcessed, ""
+			if err := p.Process(&events[i]); err != nil {
+				status, processingError = repository.WebhookEventStatusFailed, err.Error()
+			}
+			if err := p.store.FinishWebhookEvent(events[i].ID, status, processingError); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Process method for processing one event by the handler of its type.
+func (p *Processor) Process(event *models.WebhookEvent) (err error) {
+	// Get handler of the event type.
+	p.mu.RLock()
+	handler, ok := p.handlers[event.EventType]
+	p.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("no handler for event type (%s)", event.EventType)
+	}
+
+	// Recover from panic in the handler to save it as failed event.
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panic: %v", r)
+		}
+	}()
+
+	return handler([]byte(event.RawBody))
+}
+
+// databaseStore (private) struct to describe store of the webhook events in the database.
+type databaseStore struct{}
+
+// ClaimPendingWebhookEvents method for claiming pending events from the database.
+func (s *databaseStore) ClaimPendingWebhookEvents(limit int, lockTimeout time.Duration) ([]models.WebhookEvent, error) {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return nil, err
+	}
+
+	return db.ClaimPendingWebhookEvents(limit, lockTimeout)
+}
+
+// FinishWebhookEvent method for saving outcome of the event processing to the database.
+func (s *databaseStore) FinishWebhookEvent(id uuid.UUID, status, processingError string) error {
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		return err
+	}
+
+	return db.FinishWebhookEvent(id, status, processingError)
+}
@@ -0,0 +1,45 @@
+package webhooks
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/repository"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NewEvent func for creating a new pending event from the raw body of the webhook request.
+func NewEvent(vendor, eventType string, rawBody []byte) *models.WebhookEvent {
+	return &models.WebhookEvent{
+		ID:         uuid.New(),
+		ReceivedAt: time.Now(),
+		Vendor:     vendor,
+		EventType:  eventType,
+		EventKey:   EventKey(rawBody),
+		RawBody:    string(rawBody),
+		Status:     repository.WebhookEventStatusPending,
+	}
+}
+
+// EventKey func for getting key of the event for duplicates detection.
+// Returns "<RecordType>:<ID>", if vendor sent event ID (like Postmark bounces),
+// or SHA-256 hash of the raw body (retries of the same event have the same body).
+func EventKey(rawBody []byte) string {
+	// Try to get vendor event ID from the body.
+	event := struct {
+		ID         json.Number `json:"ID"`
+		RecordType string      `json:"RecordType"`
+	}{}
+	if err := json.Unmarshal(rawBody, &event); err == nil && event.ID != "" && event.ID != "0" {
+		return fmt.Sprintf("%s:%s", event.RecordType, event.ID)
+	}
+
+	// Define hash of the raw body.
+	hash := sha256.Sum256(rawBody)
+
+	return "sha256:" + hex.EncodeToString(hash[:])
+}
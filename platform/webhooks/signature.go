@@ -0,0 +1,76 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// HeaderEvent header with type of the event in the delivery (like "answer.created").
+	HeaderEvent string = "X-Komentory-Event"
+	// HeaderDelivery header with ID of the delivery (the same for all attempts).
+	HeaderDelivery string = "X-Komentory-Delivery"
+	// HeaderSignature header with time and HMAC-SHA256 signature of the delivery.
+	HeaderSignature string = "X-Komentory-Signature"
+)
+
+// Sign func for signing body of the delivery by the webhook secret.
+// Returns value of the signature header in format "t=<unix time>,v1=<hex HMAC-SHA256>",
+// where HMAC is calculated for the "<unix time>.<body>" string.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	unixTime := strconv.FormatInt(timestamp.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", unixTime, signature(secret, unixTime, body))
+}
+
+// VerifySignature func for checking signature header of the delivery (for integrators and tests).
+// Signatures older than the given tolerance are rejected to prevent replay attacks.
+func VerifySignature(secret, header string, body []byte, tolerance time.Duration, now time.Time) error {
+	// Parse time and signatures from the header.
+	unixTime, signatures := "", []string{}
+	for _, part := range strings.Split(header, ",") {
+		pair := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(pair) != 2 {
+			continue
+		}
+		switch pair[0] {
+		case "t":
+			unixTime = pair[1]
+		case "v1":
+			signatures = append(signatures, pair[1])
+		}
+	}
+
+	// Check time of the signature.
+	seconds, err := strconv.ParseInt(unixTime, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return errors.New("signature header is not valid")
+	}
+	if age := now.Sub(time.Unix(seconds, 0)); age > tolerance || age < -tolerance {
+		return errors.New("signature is too old or from the future")
+	}
+
+	// Compare signatures in constant time.
+	expected := signature(secret, unixTime, body)
+	for _, s := range signatures {
+		if hmac.Equal([]byte(s), []byte(expected)) {
+			return nil
+		}
+	}
+
+	return errors.New("signature is not matched")
+}
+
+// signature (private) func for calculating hex HMAC-SHA256 of the "<unix time>.<body>" string.
+func signature(secret, unixTime string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unixTime))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
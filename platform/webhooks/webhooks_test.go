@@ -0,0 +1,68 @@
+package webhooks
+
+import (
+	"Komentory/api/app/models"
+	"Komentory/api/pkg/repository"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubStore (private) struct to describe store, which keeps events in memory.
+type stubStore struct {
+	pending  []models.WebhookEvent
+	outcomes map[uuid.UUID]string
+}
+
+// ClaimPendingWebhookEvents method for claiming all pending events from memory.
+func (s *stubStore) ClaimPendingWebhookEvents(_ int, _ time.Duration) ([]models.WebhookEvent, error) {
+	events := s.pending
+	s.pending = nil
+	return events, nil
+}
+
+// FinishWebhookEvent method for saving outcome of the event processing in memory.
+func (s *stubStore) FinishWebhookEvent(id uuid.UUID, status, processingError string) error {
+	s.outcomes[id] = strings.TrimSpace(status + " " + processingError)
+	return nil
+}
+
+func TestEventKey(t *testing.T) {
+	// Check key by vendor event ID.
+	assert.Equal(t, "Bounce:42", EventKey([]byte(`{"ID":42,"RecordType":"Bounce"}`)))
+
+	// Check key by hash of the raw body (same body has the same key).
+	key := EventKey([]byte(`{"RecordType":"Delivery","MessageID":"a"}`))
+	assert.True(t, strings.HasPrefix(key, "sha256:"))
+	assert.Equal(t, key, EventKey([]byte(`{"RecordType":"Delivery","MessageID":"a"}`)))
+	assert.NotEqual(t, key, EventKey([]byte(`{"RecordType":"Delivery","MessageID":"b"}`)))
+}
+
+func TestProcessPending(t *testing.T) {
+	// Create stored events.
+	processed := NewEvent(repository.WebhookVendorPostmark, "ok", []byte(`{}`))
+	failed := NewEvent(repository.WebhookVendorPostmark, "fail", []byte(`{}`))
+	panicked := NewEvent(repository.WebhookVendorPostmark, "panic", []byte(`{}`))
+	unknown := NewEvent(repository.WebhookVendorPostmark, "unknown", []byte(`{}`))
+
+	// Create a new processor with handlers.
+	store := &stubStore{
+		pending:  []models.WebhookEvent{*processed, *failed, *panicked, *unknown},
+		outcomes: map[uuid.UUID]string{},
+	}
+	processor := NewProcessor(store)
+	processor.Register("ok", func([]byte) error { return nil })
+	processor.Register("fail", func([]byte) error { return errors.New("user not found") })
+	processor.Register("panic", func([]byte) error { panic("nil map") })
+
+	// Check outcomes of the processing.
+	assert.NoError(t, processor.ProcessPending())
+	assert.Equal(t, "processed", store.outcomes[processed.ID])
+	assert.Equal(t, "failed user not found", store.outcomes[failed.ID])
+	assert.Equal(t, "failed handler panic: nil map", store.outcomes[panicked.ID])
+	assert.Equal(t, "failed no handler for event type (unknown)", store.outcomes[unknown.ID])
+}